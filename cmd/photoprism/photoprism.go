@@ -56,6 +56,10 @@ func main() {
 		commands.IndexCommand,
 		commands.ImportCommand,
 		commands.MomentsCommand,
+		commands.GeotagCommand,
+		commands.LightroomCommand,
+		commands.PlacesCommand,
+		commands.TimeShiftCommand,
 		commands.OptimizeCommand,
 		commands.PurgeCommand,
 		commands.CleanUpCommand,
@@ -386,8 +386,13 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		change := entity.NewChange(s.User.UserUID, entity.ChangeManual)
+		change.Album(a.AlbumUID, photos.UIDs(), true)
+
 		added := a.AddPhotos(photos.UIDs())
 
+		logError("album", change.Save())
+
 		if len(added) > 0 {
 			if len(added) == 1 {
 				event.SuccessMsg(i18n.MsgEntryAddedTo, txt.Quote(a.Title()))
@@ -435,8 +440,13 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		change := entity.NewChange(s.User.UserUID, entity.ChangeManual)
+		change.Album(a.AlbumUID, f.Photos, false)
+
 		removed := a.RemovePhotos(f.Photos)
 
+		logError("album", change.Save())
+
 		if len(removed) > 0 {
 			if len(removed) == 1 {
 				event.SuccessMsg(i18n.MsgEntryRemovedFrom, txt.Quote(a.Title()))
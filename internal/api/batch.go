@@ -51,6 +51,9 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		// Remove archived photos from albums.
 		logError("archive", entity.Db().Model(&entity.PhotoAlbum{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("hidden", true).Error)
 
+		// Choose another best frame for bursts.
+		logError("archive", entity.UpdatePhotoBursts(f.Photos))
+
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("photos: %s", err)
 		}
@@ -101,7 +104,12 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 				log.Errorf("photo: %s (approve)", err.Error())
 			} else {
 				approved = append(approved, p)
-				SavePhotoAsYaml(p)
+
+				if err := p.LoadLabels(); err != nil {
+					log.Errorf("photo: %s (load labels)", err)
+				} else {
+					SavePhotoAsYaml(p)
+				}
 			}
 		}
 
@@ -145,6 +153,8 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 			return
 		}
 
+		logError("archive", entity.UpdatePhotoBursts(f.Photos))
+
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("photos: %s", err)
 		}
@@ -216,7 +226,14 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 
 		log.Infof("photos: mark %s as private", f.String())
 
-		err := entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
+		photos, err := query.PhotoSelection(form.Selection{Photos: f.Photos})
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		err = entity.Db().Model(entity.Photo{}).Where("photo_uid IN (?)", f.Photos).UpdateColumn("photo_private",
 			gorm.Expr("CASE WHEN photo_private > 0 THEN 0 ELSE 1 END")).Error
 
 		if err != nil {
@@ -224,6 +241,17 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 			return
 		}
 
+		change := entity.NewChange(s.User.UserUID, entity.ChangeBatch)
+
+		for _, p := range photos {
+			updated := p
+			updated.PhotoPrivate = !p.PhotoPrivate
+			change.Photo(&p, &updated)
+		}
+
+		logError("photos", change.Save())
+		logError("photos", entity.UpdatePhotoBursts(f.Photos))
+
 		if err := entity.UpdatePhotoCounts(); err != nil {
 			log.Errorf("photos: %s", err)
 		}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/batch/photos/edit
+func BatchPhotosEdit(router *gin.RouterGroup) {
+	router.POST("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.BatchEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Selection.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: editing %s", f.String())
+
+		photos, err := query.PhotoSelection(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results, err := entity.SavePhotoBatch(photos, f, s.User.UserUID)
+
+		if err != nil && len(results) > 0 {
+			log.Errorf("photos: %s (batch edit)", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, results)
+			return
+		} else if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Private != nil {
+			FlushCoverCache()
+		}
+
+		for _, r := range results {
+			if len(r.Changed) == 0 {
+				continue
+			}
+
+			if p, err := query.PhotoPreloadByUID(r.PhotoUID); err != nil {
+				log.Errorf("photos: %s (batch edit)", err)
+			} else {
+				SavePhotoAsYaml(p)
+				SavePhotoAsXmp(p)
+			}
+
+			PublishPhotoEvent(EntityUpdated, r.PhotoUID, c)
+		}
+
+		UpdateClientConfig()
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestBatchPhotosEdit(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+
+		// The artist must differ from the previous test run, so that it changes.
+		artist := "Batch Artist " + rnd.Token(8)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y21"], "keywords": {"value": "batch", "action": "append"}, "artist": {"value": "`+artist+`", "action": "set"}}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y21", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "0.Changed").String(), "Artist")
+
+		GetPhoto(router)
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y21")
+		assert.Equal(t, artist, gjson.Get(r.Body.String(), "Details.Artist").String())
+		assert.Contains(t, gjson.Get(r.Body.String(), "Details.Keywords").String(), "batch")
+	})
+
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"title": {"value": "Foo", "action": "set"}}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y21"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": 123}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/batch/photos/timeshift
+func BatchPhotosTimeShift(router *gin.RouterGroup) {
+	router.POST("/batch/photos/timeshift", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.TimeShift
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: shifting time of %s", f.String())
+
+		results, err := photoprism.NewTimeShift(service.Config()).Start(f, s.User.UserUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if !f.DryRun && len(results) > 0 {
+			for _, r := range results {
+				PublishPhotoEvent(EntityUpdated, r.PhotoUID, c)
+			}
+
+			UpdateClientConfig()
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
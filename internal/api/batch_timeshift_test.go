@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestBatchPhotosTimeShift(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": ["pt9jtdre2lvl0yh8"], "offset": -3600, "dryRun": true}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, "2006-01-01T01:00:00Z", gjson.Get(r.Body.String(), "0.NewTakenAtLocal").String())
+	})
+
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"offset": 3600}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), gjson.Get(r.Body.String(), "error").String())
+	})
+
+	t.Run("no offset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": ["pt9jtdre2lvl0yh8"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": 123}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/duplicates
+//
+// Query:
+//   distance: int Max Hamming distance of perceptual hashes (default 10)
+//   count:    int Max result count
+//   offset:   int Result offset
+func GetNearDuplicates(router *gin.RouterGroup) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+		distance := query.SimilarDistance
+
+		if c.Query("distance") != "" {
+			distance = txt.Int(c.Query("distance"))
+		}
+
+		if resp, err := query.NearDuplicates(distance, limit, offset); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, limit)
+			AddOffsetHeader(c, offset)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetNearDuplicates(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetNearDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.LessOrEqual(t, int64(2), gjson.Get(r.Body.String(), "0.#").Int())
+	})
+	t.Run("exact", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetNearDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?distance=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/track"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/tracks
+func UploadTracks(router *gin.RouterGroup) {
+	router.POST("/tracks", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpload)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := os.MkdirAll(conf.TracksPath(), os.ModePerm); err != nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		var uploads []string
+
+		for _, file := range f.File["files"] {
+			fileName := filepath.Join(conf.TracksPath(), filepath.Base(file.Filename))
+
+			if !track.IsTrack(fileName) {
+				log.Infof("tracks: %s is not a supported track file", txt.Quote(filepath.Base(file.Filename)))
+				continue
+			}
+
+			log.Debugf("tracks: saving file %s", txt.Quote(filepath.Base(file.Filename)))
+
+			if err := c.SaveUploadedFile(file, fileName); err != nil {
+				AbortSaveFailed(c)
+				return
+			}
+
+			// Remove files without usable track points.
+			if _, err := track.ReadFile(fileName); err != nil {
+				log.Warnf("tracks: %s", err)
+
+				if err := os.Remove(fileName); err != nil {
+					log.Errorf("tracks: %s", err)
+				}
+
+				continue
+			}
+
+			uploads = append(uploads, filepath.Base(fileName))
+		}
+
+		if len(uploads) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"files": uploads})
+	})
+}
+
+// POST /api/v1/geotag
+func StartGeotag(router *gin.RouterGroup) {
+	router.POST("/geotag", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		var f form.GeotagOptions
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		start := time.Now()
+
+		opt := photoprism.GeotagOptions{
+			Offset:   conf.GeotagOffset(),
+			MaxGap:   conf.GeotagMaxGap(),
+			TimeZone: f.TimeZone,
+			DryRun:   f.DryRun,
+		}
+
+		// Only files in the tracks path may be used.
+		for _, fileName := range f.Files {
+			opt.Files = append(opt.Files, filepath.Join(conf.TracksPath(), filepath.Base(fileName)))
+		}
+
+		if f.Offset != nil {
+			opt.Offset = time.Duration(*f.Offset) * time.Second
+		}
+
+		if f.MaxGap != nil {
+			opt.MaxGap = time.Duration(*f.MaxGap) * time.Second
+		}
+
+		results, err := service.Geotag().Start(opt)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if !opt.DryRun {
+			for _, r := range results {
+				PublishPhotoEvent(EntityUpdated, r.PhotoUID, c)
+			}
+		}
+
+		log.Infof("geotag: matched %d photos in %s", len(results), time.Since(start))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
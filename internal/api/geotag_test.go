@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+const geotagTestGpx = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="Test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="52.5000" lon="13.4000"><time>2007-12-31T23:55:00Z</time></trkpt>
+    <trkpt lat="52.5100" lon="13.4100"><time>2008-01-01T00:05:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+func TestUploadTracks(t *testing.T) {
+	upload := func(fileName, content string) *httptest.ResponseRecorder {
+		app, router, _ := NewApiTest()
+		UploadTracks(router)
+
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+
+		if part, err := mw.CreateFormFile("files", fileName); err != nil {
+			t.Fatal(err)
+		} else if _, err := part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := mw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		req, _ := http.NewRequest("POST", "/api/v1/tracks", body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("gpx", func(t *testing.T) {
+		r := upload("upload-test.gpx", geotagTestGpx)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "upload-test.gpx", gjson.Get(r.Body.String(), "files.0").String())
+
+		fileName := filepath.Join(service.Config().TracksPath(), "upload-test.gpx")
+
+		assert.FileExists(t, fileName)
+
+		_ = os.Remove(fileName)
+	})
+
+	t.Run("no points", func(t *testing.T) {
+		r := upload("empty.gpx", `<gpx></gpx>`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.NoFileExists(t, filepath.Join(service.Config().TracksPath(), "empty.gpx"))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		r := upload("photo.jpg", "not a track")
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestStartGeotag(t *testing.T) {
+	conf := service.Config()
+
+	if err := os.MkdirAll(conf.TracksPath(), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := filepath.Join(conf.TracksPath(), "geotag-test.gpx")
+
+	if err := ioutil.WriteFile(fileName, []byte(geotagTestGpx), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	t.Run("dry run", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartGeotag(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag", `{"files": ["geotag-test.gpx"], "maxGap": 3600, "dryRun": true}`)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		found := false
+
+		for _, uid := range gjson.Get(r.Body.String(), "#.UID").Array() {
+			if uid.String() == "pt9jtdre2lvl0yh9" {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartGeotag(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag", `{"files": ["../missing.gpx"], "dryRun": true}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartGeotag(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/geotag", `{"files": 1}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
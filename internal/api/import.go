@@ -45,6 +45,13 @@ func StartImport(router *gin.RouterGroup) {
 			return
 		}
 
+		if f.Template != "" {
+			if err := fs.PathTemplate(f.Template).Validate(); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
 		subPath := ""
 		path := conf.ImportPath()
 
@@ -77,6 +84,11 @@ func StartImport(router *gin.RouterGroup) {
 			opt.Albums = f.Albums
 		}
 
+		if f.Template != "" {
+			log.Debugf("import: files will be named using template %s", txt.Quote(f.Template))
+			opt.Template = f.Template
+		}
+
 		imp.Start(opt)
 
 		if subPath != "" && path != conf.ImportPath() && fs.IsEmpty(path) {
@@ -135,3 +147,69 @@ func CancelImport(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgImportCanceled))
 	})
 }
+
+// POST /api/v1/import-template
+//
+// Validates a destination path template and previews the resulting paths for sample files.
+//
+// Parameters:
+//   template: string path template, uses the import settings if empty
+//   files: []string sample files relative to the import folder, uses the first files found if empty
+//   albums: []string album uids or titles for the {album} token
+func PreviewImportTemplate(router *gin.RouterGroup) {
+	router.POST("/import-template", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionImport)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.Import {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.ImportTemplate
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		imp := service.Import()
+		tpl := imp.Template(photoprism.ImportOptions{Template: f.Template})
+		tokens, err := tpl.Tokens()
+
+		if err == nil {
+			err = tpl.Validate()
+		}
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		var fileNames []string
+
+		if len(f.Files) == 0 {
+			fileNames = imp.PreviewFiles(5)
+		} else {
+			for _, name := range f.Files {
+				// Files must be located in the import folder.
+				fileNames = append(fileNames, filepath.Join(conf.ImportPath(), filepath.Clean("/"+name)))
+			}
+		}
+
+		files, err := imp.Preview(tpl, fileNames, photoprism.ImportAlbumTitle(f.Albums))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"template": tpl, "tokens": tokens, "files": files})
+	})
+}
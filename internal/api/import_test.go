@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
 )
 
 func TestCancelImport(t *testing.T) {
@@ -28,3 +30,35 @@ func TestCancelImport(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.Code)
 	})
 }
+
+func TestPreviewImportTemplate(t *testing.T) {
+	t.Run("default template", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PreviewImportTemplate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/import-template", `{"files": ["missing.jpg"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, fs.DefaultPathTemplate, gjson.Get(r.Body.String(), "template").String())
+		assert.Equal(t, "missing.jpg", gjson.Get(r.Body.String(), "files.0.fileName").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "files.0.error").String())
+	})
+	t.Run("custom template", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PreviewImportTemplate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/import-template", `{"template": "{make}/{yyyy}/{name}", "files": []}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, `["make","name","yyyy"]`, gjson.Get(r.Body.String(), "tokens").Raw)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PreviewImportTemplate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/import-template", `{"template": "{make}/{foo}"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Unknown template token {foo}", gjson.Get(r.Body.String(), "error").String())
+	})
+	t.Run("bad request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PreviewImportTemplate(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/import-template", `{"template": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
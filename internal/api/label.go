@@ -49,6 +49,32 @@ func GetLabels(router *gin.RouterGroup) {
 	})
 }
 
+// GET /api/v1/labels/:uid/tree
+//
+// Returns the label with all of its descendants in the label hierarchy.
+//
+// Parameters:
+//   uid: string Label UID
+func GetLabelTree(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/tree", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		result, err := query.LabelTree(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
 // PUT /api/v1/labels/:uid
 func UpdateLabel(router *gin.RouterGroup) {
 	router.PUT("/labels/:uid", func(c *gin.Context) {
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// GET /api/v1/labels/:uid/synonyms
+//
+// Parameters:
+//   uid: string Label UID
+func GetLabelSynonyms(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/synonyms", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.LabelByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Synonyms())
+	})
+}
+
+// PUT /api/v1/labels/:uid/synonyms
+//
+// Replaces all synonyms of a label with the list of names and locales in the request body.
+//
+// Parameters:
+//   uid: string Label UID
+func UpdateLabelSynonyms(router *gin.RouterGroup) {
+	router.PUT("/labels/:uid/synonyms", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f []form.LabelSynonym
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		id := c.Param("uid")
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		synonyms := make(entity.LabelSynonyms, len(f))
+
+		for i, synonym := range f {
+			synonyms[i] = entity.LabelSynonym{SynonymName: synonym.Name, SynonymLocale: synonym.Locale}
+		}
+
+		if err := m.SetSynonyms(synonyms); err != nil {
+			log.Errorf("label: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m.Synonyms())
+	})
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetLabelSynonyms(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelSynonyms(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c2/synonyms")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "Landschaft", gjson.Get(r.Body.String(), "0.Name").String())
+		assert.Equal(t, "de", gjson.Get(r.Body.String(), "0.Locale").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelSynonyms(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/xxx/synonyms")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateLabelSynonyms(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelSynonyms(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms", `[{"Name": "Torte", "Locale": "de"}, {"Name": "Gâteau", "Locale": "fr"}, {"Name": "Kuchen"}]`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "torte", gjson.Get(r.Body.String(), "0.Slug").String())
+		assert.Equal(t, "gateau", gjson.Get(r.Body.String(), "1.Slug").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelSynonyms(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms", `{"Name": 123}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateLabelSynonyms(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/labels/xxx/synonyms", `[]`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
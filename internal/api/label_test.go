@@ -18,6 +18,14 @@ func TestGetLabels(t *testing.T) {
 		assert.LessOrEqual(t, int64(4), count.Int())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("children", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabels(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels?count=15&parent=lt9k3pw1wowuy313")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "Europe", gjson.Get(r.Body.String(), "0.Name").String())
+	})
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		GetLabels(router)
@@ -26,6 +34,24 @@ func TestGetLabels(t *testing.T) {
 	})
 }
 
+func TestGetLabelTree(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelTree(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy313/tree")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Places", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "Europe", gjson.Get(r.Body.String(), "Children.0.Name").String())
+		assert.Equal(t, "Berlin", gjson.Get(r.Body.String(), "Children.0.Children.0.Name").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelTree(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/xxx/tree")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestUpdateLabel(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -35,6 +35,15 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// SavePhotoAsXmp writes metadata changes to an XMP sidecar file, existing sidecar files are updated.
+func SavePhotoAsXmp(p entity.Photo) {
+	if fileName, err := photoprism.SaveXmp(service.Config(), p); err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+	} else if fileName != "" {
+		log.Debugf("photo: updated xmp file %s", txt.Quote(filepath.Base(fileName)))
+	}
+}
+
 // GET /api/v1/photos/:uid
 //
 // Parameters:
@@ -77,6 +86,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		// TODO: Proof-of-concept for form handling - might need refactoring
 		// 1) Init form with model values
 		f, err := form.NewPhoto(m)
@@ -111,7 +122,13 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		if old.PhotoPrivate != p.PhotoPrivate || (old.PhotoQuality < 3) != (p.PhotoQuality < 3) {
+			logError("photo", entity.UpdatePhotoBursts([]string{p.PhotoUID}))
+		}
+
+		SavePhotoChange(old, p, s, entity.ChangeManual)
 		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(p)
 
 		c.JSON(http.StatusOK, p)
 	})
@@ -242,13 +259,17 @@ func LikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		if err := m.SetFavorite(true); err != nil {
 			log.Errorf("photo: %s", err.Error())
 			AbortSaveFailed(c)
 			return
 		}
 
+		SavePhotoChange(old, m, s, entity.ChangeManual)
 		SavePhotoAsYaml(m)
+		SavePhotoAsXmp(m)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
 
@@ -277,13 +298,17 @@ func DislikePhoto(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		if err := m.SetFavorite(false); err != nil {
 			log.Errorf("photo: %s", err.Error())
 			AbortSaveFailed(c)
 			return
 		}
 
+		SavePhotoChange(old, m, s, entity.ChangeManual)
 		SavePhotoAsYaml(m)
+		SavePhotoAsXmp(m)
 
 		PublishPhotoEvent(EntityUpdated, id, c)
 
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/photos/:uid/burst
+//
+// Chooses the photo as best frame of its burst, so that it's shown when the burst is collapsed.
+//
+// Parameters:
+//   uid: string Photo UID as returned by the API
+func PhotoBurstBest(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/burst", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		burst := entity.FindBurst(p.BurstUID)
+
+		if burst == nil {
+			log.Errorf("photo: %s is not part of a burst", txt.Quote(uid))
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := burst.SetBest(p.PhotoUID, entity.SrcManual); err != nil {
+			log.Errorf("photo: %s (set best frame)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, burst)
+	})
+}
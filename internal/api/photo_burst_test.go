@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoBurstBest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		burst := entity.NewBurst("", "TestPhotoBurstBest", time.Now())
+
+		if err := burst.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		photo := entity.PhotoFixtures.Get("Photo05")
+
+		if err := photo.Update("BurstUID", burst.BurstUID); err != nil {
+			t.Fatal(err)
+		}
+
+		defer photo.Update("BurstUID", "")
+
+		app, router, _ := NewApiTest()
+		PhotoBurstBest(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUID+"/burst")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		if result := entity.FindBurst(burst.BurstUID); result == nil {
+			t.Fatal("burst should not be nil")
+		} else {
+			assert.Equal(t, photo.PhotoUID, result.PhotoUID)
+			assert.Equal(t, entity.SrcManual, result.BestSrc)
+		}
+	})
+
+	t.Run("not part of a burst", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoBurstBest(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh7/burst")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("not existing photo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoBurstBest(router)
+		r := PerformRequest(app, "POST", "/api/v1/photos/xxx/burst")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
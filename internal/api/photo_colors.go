@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/colors"
+)
+
+// PhotoColors represents the dominant colors of a photo.
+type PhotoColors struct {
+	PhotoUID  string                `json:"PhotoUID"`
+	FileUID   string                `json:"FileUID"`
+	MainColor string                `json:"MainColor"`
+	Chroma    uint8                 `json:"Chroma"`
+	Palette   []string              `json:"Palette"`
+	Colors    colors.DominantColors `json:"Colors"`
+}
+
+// GET /api/v1/photos/:uid/colors
+//
+// Returns the dominant colors of the primary file.
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func GetPhotoColors(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/colors", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		f, err := query.FileByPhotoUID(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		indexed := colors.HexColors(f.FileColors)
+		cells := colors.Cells(indexed, colors.HexLightMap(f.FileLuminance))
+
+		result := PhotoColors{
+			PhotoUID:  f.PhotoUID,
+			FileUID:   f.FileUID,
+			MainColor: f.FileMainColor,
+			Chroma:    f.FileChroma,
+			Palette:   make([]string, len(cells)),
+			Colors:    indexed.Dominant(),
+		}
+
+		for i, cell := range cells {
+			result.Palette[i] = cell.Hex()
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
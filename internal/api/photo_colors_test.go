@@ -0,0 +1,28 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoColors(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoColors(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12/colors")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "blue", gjson.Get(r.Body.String(), "MainColor").String())
+		assert.Equal(t, int64(9), gjson.Get(r.Body.String(), "Palette.#").Int())
+		assert.Equal(t, "black", gjson.Get(r.Body.String(), "Colors.0.Slug").String())
+		assert.Equal(t, int64(33), gjson.Get(r.Body.String(), "Colors.0.Percent").Int())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoColors(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/colors")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
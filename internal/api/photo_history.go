@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SavePhotoChange records the differences between two versions of a photo in the edit history.
+func SavePhotoChange(old, new entity.Photo, s session.Data, source string) {
+	change := entity.NewChange(s.User.UserUID, source)
+	change.Photo(&old, &new)
+
+	if change.Empty() {
+		return
+	}
+
+	logError("history", change.Save())
+}
+
+// publishChange updates sidecar files and notifies clients after a change was reverted.
+func publishChange(change *entity.Change, c *gin.Context) {
+	for _, uid := range change.PhotoUIDs() {
+		if p, err := query.PhotoPreloadByUID(uid); err != nil {
+			log.Errorf("history: %s", err)
+		} else {
+			SavePhotoAsYaml(p)
+			SavePhotoAsXmp(p)
+		}
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+	}
+
+	UpdateClientConfig()
+
+	event.SuccessMsg(i18n.MsgChangesSaved)
+}
+
+// GET /api/v1/photos/:uid/history
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func GetPhotoHistory(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/history", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		results, err := query.PhotoRevisions(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// POST /api/v1/photos/:uid/history/:id/revert
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   id: int Revision ID as returned by the API
+func RevertPhotoHistory(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/history/:id/revert", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		id, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil || id <= 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		if r := entity.FindRevision(uint(id)); r == nil || r.PhotoUID != uid {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		change, err := entity.RevertPhoto(uid, uint(id), s.User.UserUID)
+
+		if err != nil {
+			log.Errorf("history: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		publishChange(change, c)
+
+		c.JSON(http.StatusOK, change.Revisions)
+	})
+}
+
+// POST /api/v1/changes/:uid/undo
+//
+// Parameters:
+//   uid: string ChangeUID as returned by the API
+func UndoChange(router *gin.RouterGroup) {
+	router.POST("/changes/:uid/undo", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if revisions, err := query.ChangeRevisions(c.Param("uid")); err != nil || len(revisions) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		change, err := entity.UndoChange(c.Param("uid"), s.User.UserUID)
+
+		if err != nil {
+			log.Errorf("history: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		publishChange(change, c)
+
+		c.JSON(http.StatusOK, change.Revisions)
+	})
+}
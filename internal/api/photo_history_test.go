@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestPhotoHistory(t *testing.T) {
+	app, router, _ := NewApiTest()
+	UpdatePhoto(router)
+	GetPhotoHistory(router)
+	RevertPhotoHistory(router)
+	UndoChange(router)
+
+	r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y20", `{"Title": "History Title", "TitleSrc": "manual"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y20/history")
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	var id int64
+	var changeUID string
+
+	for _, rev := range gjson.Parse(r.Body.String()).Array() {
+		if rev.Get("Field").String() == "PhotoTitle" {
+			assert.Equal(t, "History Title", rev.Get("NewValue").String())
+			id = rev.Get("ID").Int()
+			changeUID = rev.Get("ChangeUID").String()
+			break
+		}
+	}
+
+	if !assert.NotZero(t, id) {
+		return
+	}
+
+	t.Run("undo", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/changes/"+changeUID+"/undo")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "History Title")
+	})
+
+	t.Run("revert", func(t *testing.T) {
+		r := PerformRequest(app, "POST", fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y20/history/%d/revert", id))
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+
+	t.Run("revert wrong photo", func(t *testing.T) {
+		r := PerformRequest(app, "POST", fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y21/history/%d/revert", id))
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+
+	t.Run("revert invalid id", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y20/history/xxx/revert")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+
+	t.Run("undo not found", func(t *testing.T) {
+		r := PerformRequest(app, "POST", "/api/v1/changes/cxxxxxxxxxxxxxx1/undo")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
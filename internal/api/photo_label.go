@@ -41,6 +41,8 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		labelEntity := entity.FirstOrCreateLabel(entity.NewLabel(f.LabelName, f.LabelPriority))
 
 		if labelEntity == nil {
@@ -80,6 +82,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoChange(old, p, s, entity.ChangeManual)
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, c.Param("uid"), c)
 
 		event.Success("label updated")
@@ -123,6 +128,8 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		if label.LabelSrc == classify.SrcManual || label.LabelSrc == classify.SrcKeyword {
 			logError("label", entity.Db().Delete(&label).Error)
 		} else {
@@ -144,6 +151,9 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoChange(old, p, s, entity.ChangeManual)
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, c.Param("uid"), c)
 
 		event.Success("label removed")
@@ -189,6 +199,8 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		old := m.Clone()
+
 		if err := c.BindJSON(&label); err != nil {
 			AbortBadRequest(c)
 			return
@@ -211,6 +223,9 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SavePhotoChange(old, p, s, entity.ChangeManual)
+		SavePhotoAsXmp(p)
+
 		PublishPhotoEvent(EntityUpdated, c.Param("uid"), c)
 
 		event.Success("label saved")
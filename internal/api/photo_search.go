@@ -72,3 +72,57 @@ func GetPhotos(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, result)
 	})
 }
+
+// GET /api/v1/facets/photos
+//
+// Returns photo counts grouped by year, month, country, camera, lens, label, color, file type
+// and album for the same query parameters as GET /api/v1/photos, except count and offset.
+//
+// Note: This endpoint can't be registered as /api/v1/photos/facets because the router
+// doesn't allow a static path segment next to the /api/v1/photos/:uid wildcard.
+func GetPhotoFacets(router *gin.RouterGroup) {
+	router.GET("/facets/photos", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Facets are computed for all matching photos, so count is optional.
+		f := form.PhotoSearch{Count: query.MaxResults}
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Guests may only see public content in shared albums.
+		if s.Guest() {
+			if f.Album == "" || !s.HasShare(f.Album) {
+				AbortUnauthorized(c)
+				return
+			}
+
+			f.Public = true
+			f.Private = false
+			f.Hidden = false
+			f.Archived = false
+			f.Review = false
+		}
+
+		result, err := query.PhotoSearchFacets(f)
+
+		if err != nil {
+			log.Error(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		AddTokenHeaders(c)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
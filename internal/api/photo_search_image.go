@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+
+	"github.com/disintegration/imaging"
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MaxSearchImageBytes is the max request size when searching by image.
+const MaxSearchImageBytes = 50 << 20
+
+// MaxSearchImagePixels is the max resolution of query images in pixels.
+const MaxSearchImagePixels = 100000000
+
+// SearchImageCandidates is the max number of photos with similar colors that are ranked in addition
+// to photos with a similar perceptual hash, so that cropped images can be found as well. Only the
+// closest candidates are queried, see query.PhotosByPalette.
+const SearchImageCandidates = 500
+
+// decodeSearchImage checks the resolution of an uploaded image before decoding it.
+func decodeSearchImage(file io.ReadSeeker) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Width*cfg.Height > MaxSearchImagePixels {
+		return nil, fmt.Errorf("resolution %dx%d exceeds limit", cfg.Width, cfg.Height)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return imaging.Decode(file, imaging.AutoOrientation(true))
+}
+
+// POST /api/v1/search/image
+//
+// Finds photos that look like an uploaded image without importing it. Candidates are photos
+// with a similar perceptual hash or similar colors, ranked by their combined distance.
+//
+// Form:
+//   file:    file  Query image
+//   count:   int   Max result count (default 12)
+//   hamming: int   Max Hamming distance of perceptual hashes (default 15)
+func SearchByImage(router *gin.RouterGroup) {
+	router.POST("/search/image", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() || s.Guest() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxSearchImageBytes)
+
+		upload, err := c.FormFile("file")
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		file, err := upload.Open()
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		defer file.Close()
+
+		img, err := decodeSearchImage(file)
+
+		if err != nil {
+			log.Errorf("search: %s in %s (decode image)", err, txt.Quote(upload.Filename))
+			AbortBadRequest(c)
+			return
+		}
+
+		sig := photoprism.NewImageSignature(img)
+
+		limit := txt.Int(c.PostForm("count"))
+
+		if limit <= 0 {
+			limit = 12
+		}
+
+		f := form.PhotoSearch{
+			Phash:   sig.Hash.Hex(),
+			Hamming: txt.Int(c.PostForm("hamming")),
+			Count:   query.MaxResults,
+			Merged:  true,
+		}
+
+		if f.Hamming <= 0 {
+			f.Hamming = photoprism.ImageSearchDistance
+		}
+
+		results, _, err := query.PhotoSearch(f)
+
+		if err != nil {
+			log.Error(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		// Add photos with similar colors, as the perceptual hash changes too much when images are cropped.
+		f.Phash = ""
+		f.Palette = sig.Palette().Hex()
+		f.ColorDist = photoprism.ImageSearchColorDistance
+		f.Count = SearchImageCandidates
+		f.Order = entity.SortOrderSimilar
+
+		colorResults, _, err := query.PhotoSearch(f)
+
+		if err != nil {
+			log.Error(err)
+			AbortBadRequest(c)
+			return
+		}
+
+		matches := sig.Matches(append(results, colorResults...), limit)
+
+		AddCountHeader(c, len(matches))
+		AddLimitHeader(c, limit)
+		AddTokenHeaders(c)
+
+		c.JSON(http.StatusOK, matches)
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func uploadSearchImage(t *testing.T, r http.Handler, path, fileName string, data []byte, fields map[string]string) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for k, v := range fields {
+		if err := writer.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if data != nil {
+		part, err := writer.CreateFormFile("file", fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := part.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("POST", path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestSearchByImage(t *testing.T) {
+	data, err := ioutil.ReadFile("../../assets/examples/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchByImage(router)
+		r := uploadSearchImage(t, app, "/api/v1/search/image", "elephants.jpg", data, map[string]string{"hamming": "64", "count": "2"})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		first := gjson.Get(r.Body.String(), "0.Distance").Int()
+		second := gjson.Get(r.Body.String(), "1.Distance").Int()
+		assert.LessOrEqual(t, first, second)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("no file", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchByImage(router)
+		r := uploadSearchImage(t, app, "/api/v1/search/image", "", nil, nil)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("too large", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchByImage(router)
+		// GIF header with a logical screen size of 65535x65535 pixels.
+		gif := []byte{'G', 'I', 'F', '8', '9', 'a', 0xff, 0xff, 0xff, 0xff, 0, 0, 0}
+		r := uploadSearchImage(t, app, "/api/v1/search/image", "bomb.gif", gif, nil)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid image", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchByImage(router)
+		r := uploadSearchImage(t, app, "/api/v1/search/image", "invalid.jpg", []byte("no image"), nil)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
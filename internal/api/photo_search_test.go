@@ -27,3 +27,34 @@ func TestGetPhotos(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, result.Code)
 	})
 }
+
+func TestGetPhotoFacets(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetPhotoFacets(router)
+		r := PerformRequest(app, "GET", "/api/v1/facets/photos")
+		assert.Equal(t, http.StatusOK, r.Code)
+		total := gjson.Get(r.Body.String(), "Total")
+		assert.LessOrEqual(t, int64(2), total.Int())
+		years := gjson.Get(r.Body.String(), "Years.#")
+		assert.LessOrEqual(t, int64(1), years.Int())
+	})
+
+	t.Run("favorites", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetPhotoFacets(router)
+		r := PerformRequest(app, "GET", "/api/v1/facets/photos?favorite=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		total := gjson.Get(r.Body.String(), "Total")
+		assert.LessOrEqual(t, int64(1), total.Int())
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoFacets(router)
+		result := PerformRequest(app, "GET", "/api/v1/facets/photos?camera=abc")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
 )
@@ -39,6 +42,35 @@ func TestUpdatePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 
+	t.Run("write xmp", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdatePhoto(router)
+
+		conf.Options().WriteXmp = true
+		defer func() { conf.Options().WriteXmp = false }()
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y14", `{"Title": "Updated02", "TitleSrc": "manual"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		p, err := query.PhotoByUID("pt9jtdre2lvl0y14")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := p.XmpFileName(conf.OriginalsPath(), "")
+
+		defer os.Remove(fileName)
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Updated02", data.Title)
+	})
+
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		UpdatePhoto(router)
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TilesRetryAfter is the number of seconds clients should wait before requesting tiles that are being created.
+const TilesRetryAfter = "2"
+
+// GET /api/v1/tiles/:hash/:token/*name
+//
+// Returns multi-resolution image tiles, which are created in the background on demand.
+// Responds with 202 Accepted and a Retry-After header while the tiles are being created.
+//
+// Parameters:
+//   hash: string sha1 file hash
+//   token: string url security token, see config
+//   name: string image.dzi and image_files/... for Deep Zoom, cube.json and cube_files/... for 360° cube maps
+func GetTile(router *gin.RouterGroup) {
+	router.GET("/tiles/:hash/:token/*name", func(c *gin.Context) {
+		if InvalidPreviewToken(c) {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
+		conf := service.Config()
+		fileHash := c.Param("hash")
+		name := strings.TrimPrefix(path.Clean(c.Param("name")), "/")
+		cube := name == thumb.CubeMapName || strings.HasPrefix(name, "cube_files/")
+
+		if !cube && name != thumb.DeepZoomName && !strings.HasPrefix(name, "image_files/") {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		tilesPath, err := thumb.TilesPath(fileHash, conf.ThumbPath())
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		fileName := filepath.Join(tilesPath, filepath.FromSlash(name))
+
+		// Create tiles in the background if they don't exist yet.
+		if !fs.FileExists(fileName) {
+			descriptor := thumb.DeepZoomName
+
+			if cube {
+				descriptor = thumb.CubeMapName
+			}
+
+			// Tiles outside the image don't exist once all tiles were created.
+			if fs.FileExists(filepath.Join(tilesPath, descriptor)) {
+				AbortEntityNotFound(c)
+				return
+			}
+
+			f, err := query.FileByHash(fileHash)
+
+			if err != nil {
+				AbortEntityNotFound(c)
+				return
+			}
+
+			// Find fallback if file is not a JPEG image.
+			if f.NoJPEG() {
+				f, err = query.FileByPhotoUID(f.PhotoUID)
+
+				if err != nil {
+					AbortEntityNotFound(c)
+					return
+				}
+			}
+
+			if f.FileError != "" || cube && f.FileProjection != entity.ProjectionEquirectangular {
+				AbortEntityNotFound(c)
+				return
+			}
+
+			originalName := photoprism.FileName(f.FileRoot, f.FileName)
+
+			if !fs.FileExists(originalName) {
+				log.Errorf("tiles: file %s is missing", txt.Quote(f.FileName))
+				AbortEntityNotFound(c)
+				return
+			}
+
+			if err := thumb.QueueTiles(originalName, fileHash, conf.ThumbPath(), cube); err == thumb.ErrTilesBusy {
+				c.Header("Retry-After", TilesRetryAfter)
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			} else if err != nil {
+				log.Error(err)
+				AbortEntityNotFound(c)
+				return
+			}
+
+			// Tiles are being created, try again later.
+			c.Header("Retry-After", TilesRetryAfter)
+			c.AbortWithStatus(http.StatusAccepted)
+			return
+		}
+
+		AddThumbCacheHeader(c)
+
+		switch name {
+		case thumb.DeepZoomName:
+			c.Header("Content-Type", "application/xml")
+		case thumb.CubeMapName:
+			c.Header("Content-Type", "application/json")
+		}
+
+		c.File(fileName)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTile(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/tiles/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/xxx/image.dzi")
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/tiles/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/../../config.yml")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/tiles/1/"+conf.PreviewToken()+"/image.dzi")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("could not find original", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/tiles/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/image_files/0/0_0.jpg")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("not a panorama", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetTile(router)
+		r := PerformRequest(app, "GET", "/api/v1/tiles/2cad9168fa6acc5c5c2965ddf6ec465ca42fd818/"+conf.PreviewToken()+"/cube.json")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,582 @@
+package classify
+
+import "github.com/photoprism/photoprism/internal/i18n"
+
+// Synonyms maps locales to alternative names of a label.
+type Synonyms map[i18n.Locale][]string
+
+// LabelSynonymsVersion must be increased when default synonyms are changed, so that they are
+// added to existing labels once.
+const LabelSynonymsVersion = 1
+
+// LabelSynonyms contains translations and alternative names of common labels, so that they can be
+// found in all supported languages. Portuguese synonyms are also used for Brazilian Portuguese.
+var LabelSynonyms = map[string]Synonyms{
+	"aircraft": {
+		i18n.English:            {"airplane", "aeroplane", "plane", "jet"},
+		i18n.German:             {"flugzeug"},
+		i18n.Spanish:            {"avión"},
+		i18n.French:             {"avion"},
+		i18n.Dutch:              {"vliegtuig"},
+		i18n.Polish:             {"samolot"},
+		i18n.Portuguese:         {"avião"},
+		i18n.Russian:            {"самолёт", "самолет"},
+		i18n.ChineseSimplified:  {"飞机"},
+		i18n.ChineseTraditional: {"飛機"},
+	},
+	"alpine": {
+		i18n.English:            {"mountains", "mountain", "alps"},
+		i18n.German:             {"berge", "berg", "alpen"},
+		i18n.Spanish:            {"montaña", "montañas"},
+		i18n.French:             {"montagne", "montagnes"},
+		i18n.Dutch:              {"bergen"},
+		i18n.Polish:             {"góry", "góra"},
+		i18n.Portuguese:         {"montanha", "montanhas"},
+		i18n.Russian:            {"горы", "гора"},
+		i18n.ChineseSimplified:  {"山", "高山"},
+		i18n.ChineseTraditional: {"山", "高山"},
+	},
+	"animal": {
+		i18n.English:            {"animals", "creature"},
+		i18n.German:             {"tier", "tiere"},
+		i18n.Spanish:            {"animales"},
+		i18n.French:             {"animaux"},
+		i18n.Dutch:              {"dier", "dieren"},
+		i18n.Polish:             {"zwierzę", "zwierzęta"},
+		i18n.Portuguese:         {"animais"},
+		i18n.Russian:            {"животное", "животные"},
+		i18n.ChineseSimplified:  {"动物"},
+		i18n.ChineseTraditional: {"動物"},
+	},
+	"architecture": {
+		i18n.German:             {"architektur"},
+		i18n.Spanish:            {"arquitectura"},
+		i18n.Dutch:              {"architectuur"},
+		i18n.Polish:             {"architektura"},
+		i18n.Portuguese:         {"arquitetura"},
+		i18n.Russian:            {"архитектура"},
+		i18n.ChineseSimplified:  {"建筑"},
+		i18n.ChineseTraditional: {"建築"},
+	},
+	"baby": {
+		i18n.English:            {"infant", "babies"},
+		i18n.German:             {"säugling"},
+		i18n.Spanish:            {"bebé"},
+		i18n.French:             {"bébé"},
+		i18n.Dutch:              {"zuigeling"},
+		i18n.Polish:             {"niemowlę"},
+		i18n.Portuguese:         {"bebê"},
+		i18n.Russian:            {"младенец", "малыш"},
+		i18n.ChineseSimplified:  {"婴儿"},
+		i18n.ChineseTraditional: {"嬰兒"},
+	},
+	"beach": {
+		i18n.English:            {"beaches", "sand"},
+		i18n.German:             {"strand"},
+		i18n.Spanish:            {"playa"},
+		i18n.French:             {"plage"},
+		i18n.Dutch:              {"strand"},
+		i18n.Polish:             {"plaża"},
+		i18n.Portuguese:         {"praia"},
+		i18n.Russian:            {"пляж"},
+		i18n.ChineseSimplified:  {"海滩"},
+		i18n.ChineseTraditional: {"海灘"},
+	},
+	"bear": {
+		i18n.German:             {"bär"},
+		i18n.Spanish:            {"oso"},
+		i18n.French:             {"ours"},
+		i18n.Dutch:              {"beer"},
+		i18n.Polish:             {"niedźwiedź"},
+		i18n.Portuguese:         {"urso"},
+		i18n.Russian:            {"медведь"},
+		i18n.ChineseSimplified:  {"熊"},
+		i18n.ChineseTraditional: {"熊"},
+	},
+	"bike": {
+		i18n.English:            {"bicycle", "motorcycle"},
+		i18n.German:             {"fahrrad", "motorrad"},
+		i18n.Spanish:            {"bicicleta", "moto"},
+		i18n.French:             {"vélo", "moto"},
+		i18n.Dutch:              {"fiets", "motor"},
+		i18n.Polish:             {"rower", "motocykl"},
+		i18n.Portuguese:         {"bicicleta", "moto"},
+		i18n.Russian:            {"велосипед", "мотоцикл"},
+		i18n.ChineseSimplified:  {"自行车", "摩托车"},
+		i18n.ChineseTraditional: {"腳踏車", "機車"},
+	},
+	"bird": {
+		i18n.German:             {"vogel", "vögel"},
+		i18n.Spanish:            {"pájaro", "ave"},
+		i18n.French:             {"oiseau", "oiseaux"},
+		i18n.Dutch:              {"vogel"},
+		i18n.Polish:             {"ptak"},
+		i18n.Portuguese:         {"pássaro", "ave"},
+		i18n.Russian:            {"птица"},
+		i18n.ChineseSimplified:  {"鸟"},
+		i18n.ChineseTraditional: {"鳥"},
+	},
+	"boat": {
+		i18n.German:             {"boot"},
+		i18n.Spanish:            {"barco", "bote"},
+		i18n.French:             {"bateau"},
+		i18n.Dutch:              {"boot"},
+		i18n.Polish:             {"łódź"},
+		i18n.Portuguese:         {"barco"},
+		i18n.Russian:            {"лодка"},
+		i18n.ChineseSimplified:  {"船"},
+		i18n.ChineseTraditional: {"船"},
+	},
+	"bridge": {
+		i18n.German:             {"brücke"},
+		i18n.Spanish:            {"puente"},
+		i18n.French:             {"pont"},
+		i18n.Dutch:              {"brug"},
+		i18n.Polish:             {"most"},
+		i18n.Portuguese:         {"ponte"},
+		i18n.Russian:            {"мост"},
+		i18n.ChineseSimplified:  {"桥"},
+		i18n.ChineseTraditional: {"橋"},
+	},
+	"building": {
+		i18n.German:             {"gebäude"},
+		i18n.Spanish:            {"edificio"},
+		i18n.French:             {"bâtiment"},
+		i18n.Dutch:              {"gebouw"},
+		i18n.Polish:             {"budynek"},
+		i18n.Portuguese:         {"edifício", "prédio"},
+		i18n.Russian:            {"здание"},
+		i18n.ChineseSimplified:  {"建筑物"},
+		i18n.ChineseTraditional: {"建築物"},
+	},
+	"bus": {
+		i18n.Spanish:            {"autobús"},
+		i18n.French:             {"autobus"},
+		i18n.Polish:             {"autobus"},
+		i18n.Portuguese:         {"ônibus", "autocarro"},
+		i18n.Russian:            {"автобус"},
+		i18n.ChineseSimplified:  {"公共汽车", "巴士"},
+		i18n.ChineseTraditional: {"公車", "巴士"},
+	},
+	"butterfly": {
+		i18n.German:             {"schmetterling"},
+		i18n.Spanish:            {"mariposa"},
+		i18n.French:             {"papillon"},
+		i18n.Dutch:              {"vlinder"},
+		i18n.Polish:             {"motyl"},
+		i18n.Portuguese:         {"borboleta"},
+		i18n.Russian:            {"бабочка"},
+		i18n.ChineseSimplified:  {"蝴蝶"},
+		i18n.ChineseTraditional: {"蝴蝶"},
+	},
+	"car": {
+		i18n.English:            {"automobile", "auto"},
+		i18n.German:             {"auto", "wagen"},
+		i18n.Spanish:            {"coche", "carro"},
+		i18n.French:             {"voiture"},
+		i18n.Dutch:              {"auto"},
+		i18n.Polish:             {"samochód"},
+		i18n.Portuguese:         {"carro"},
+		i18n.Russian:            {"машина", "автомобиль"},
+		i18n.ChineseSimplified:  {"汽车"},
+		i18n.ChineseTraditional: {"汽車"},
+	},
+	"cat": {
+		i18n.English:            {"kitten", "kitty"},
+		i18n.German:             {"katze", "kätzchen"},
+		i18n.Spanish:            {"gato"},
+		i18n.French:             {"chat"},
+		i18n.Dutch:              {"kat", "poes"},
+		i18n.Polish:             {"kot"},
+		i18n.Portuguese:         {"gato"},
+		i18n.Russian:            {"кошка", "кот"},
+		i18n.ChineseSimplified:  {"猫"},
+		i18n.ChineseTraditional: {"貓"},
+	},
+	"chicken": {
+		i18n.English:            {"hen", "rooster"},
+		i18n.German:             {"huhn"},
+		i18n.Spanish:            {"pollo", "gallina"},
+		i18n.French:             {"poulet", "poule"},
+		i18n.Dutch:              {"kip"},
+		i18n.Polish:             {"kurczak", "kura"},
+		i18n.Portuguese:         {"galinha", "frango"},
+		i18n.Russian:            {"курица"},
+		i18n.ChineseSimplified:  {"鸡"},
+		i18n.ChineseTraditional: {"雞"},
+	},
+	"church": {
+		i18n.English:            {"chapel", "cathedral"},
+		i18n.German:             {"kirche"},
+		i18n.Spanish:            {"iglesia"},
+		i18n.French:             {"église"},
+		i18n.Dutch:              {"kerk"},
+		i18n.Polish:             {"kościół"},
+		i18n.Portuguese:         {"igreja"},
+		i18n.Russian:            {"церковь", "храм"},
+		i18n.ChineseSimplified:  {"教堂"},
+		i18n.ChineseTraditional: {"教堂"},
+	},
+	"coffee": {
+		i18n.German:             {"kaffee"},
+		i18n.Spanish:            {"café"},
+		i18n.French:             {"café"},
+		i18n.Dutch:              {"koffie"},
+		i18n.Polish:             {"kawa"},
+		i18n.Portuguese:         {"café"},
+		i18n.Russian:            {"кофе"},
+		i18n.ChineseSimplified:  {"咖啡"},
+		i18n.ChineseTraditional: {"咖啡"},
+	},
+	"cow": {
+		i18n.English:            {"cattle"},
+		i18n.German:             {"kuh", "rind"},
+		i18n.Spanish:            {"vaca"},
+		i18n.French:             {"vache"},
+		i18n.Dutch:              {"koe"},
+		i18n.Polish:             {"krowa"},
+		i18n.Portuguese:         {"vaca"},
+		i18n.Russian:            {"корова"},
+		i18n.ChineseSimplified:  {"牛"},
+		i18n.ChineseTraditional: {"牛"},
+	},
+	"dessert": {
+		i18n.English:            {"sweets"},
+		i18n.German:             {"nachtisch"},
+		i18n.Spanish:            {"postre"},
+		i18n.Dutch:              {"toetje"},
+		i18n.Polish:             {"deser"},
+		i18n.Portuguese:         {"sobremesa"},
+		i18n.Russian:            {"десерт"},
+		i18n.ChineseSimplified:  {"甜点"},
+		i18n.ChineseTraditional: {"甜點"},
+	},
+	"dog": {
+		i18n.English:            {"puppy", "doggy", "hound"},
+		i18n.German:             {"hund", "welpe"},
+		i18n.Spanish:            {"perro", "cachorro"},
+		i18n.French:             {"chien", "chiot"},
+		i18n.Dutch:              {"hond", "puppy"},
+		i18n.Polish:             {"pies", "szczeniak"},
+		i18n.Portuguese:         {"cão", "cachorro"},
+		i18n.Russian:            {"собака", "щенок"},
+		i18n.ChineseSimplified:  {"狗"},
+		i18n.ChineseTraditional: {"狗"},
+	},
+	"drinks": {
+		i18n.English:            {"drink", "beverages"},
+		i18n.German:             {"getränk", "getränke"},
+		i18n.Spanish:            {"bebida"},
+		i18n.French:             {"boisson"},
+		i18n.Dutch:              {"drank", "drankje"},
+		i18n.Polish:             {"napój"},
+		i18n.Portuguese:         {"bebida"},
+		i18n.Russian:            {"напиток"},
+		i18n.ChineseSimplified:  {"饮料"},
+		i18n.ChineseTraditional: {"飲料"},
+	},
+	"duck": {
+		i18n.German:             {"ente"},
+		i18n.Spanish:            {"pato"},
+		i18n.French:             {"canard"},
+		i18n.Dutch:              {"eend"},
+		i18n.Polish:             {"kaczka"},
+		i18n.Portuguese:         {"pato"},
+		i18n.Russian:            {"утка"},
+		i18n.ChineseSimplified:  {"鸭"},
+		i18n.ChineseTraditional: {"鴨"},
+	},
+	"elephant": {
+		i18n.German:             {"elefant"},
+		i18n.Spanish:            {"elefante"},
+		i18n.French:             {"éléphant"},
+		i18n.Dutch:              {"olifant"},
+		i18n.Polish:             {"słoń"},
+		i18n.Portuguese:         {"elefante"},
+		i18n.Russian:            {"слон"},
+		i18n.ChineseSimplified:  {"大象"},
+		i18n.ChineseTraditional: {"大象"},
+	},
+	"fish": {
+		i18n.German:             {"fisch"},
+		i18n.Spanish:            {"pez", "pescado"},
+		i18n.French:             {"poisson"},
+		i18n.Dutch:              {"vis"},
+		i18n.Polish:             {"ryba"},
+		i18n.Portuguese:         {"peixe"},
+		i18n.Russian:            {"рыба"},
+		i18n.ChineseSimplified:  {"鱼"},
+		i18n.ChineseTraditional: {"魚"},
+	},
+	"flower": {
+		i18n.English:            {"blossom", "bloom"},
+		i18n.German:             {"blume", "blüte"},
+		i18n.Spanish:            {"flor"},
+		i18n.French:             {"fleur"},
+		i18n.Dutch:              {"bloem"},
+		i18n.Polish:             {"kwiat"},
+		i18n.Portuguese:         {"flor"},
+		i18n.Russian:            {"цветок", "цветы"},
+		i18n.ChineseSimplified:  {"花"},
+		i18n.ChineseTraditional: {"花"},
+	},
+	"food": {
+		i18n.English:            {"meal", "dish"},
+		i18n.German:             {"essen", "lebensmittel"},
+		i18n.Spanish:            {"comida"},
+		i18n.French:             {"nourriture", "repas"},
+		i18n.Dutch:              {"eten"},
+		i18n.Polish:             {"jedzenie"},
+		i18n.Portuguese:         {"comida"},
+		i18n.Russian:            {"еда"},
+		i18n.ChineseSimplified:  {"食物"},
+		i18n.ChineseTraditional: {"食物"},
+	},
+	"fruit": {
+		i18n.German:             {"obst", "frucht"},
+		i18n.Spanish:            {"fruta"},
+		i18n.Dutch:              {"vrucht"},
+		i18n.Polish:             {"owoc", "owoce"},
+		i18n.Portuguese:         {"fruta"},
+		i18n.Russian:            {"фрукты"},
+		i18n.ChineseSimplified:  {"水果"},
+		i18n.ChineseTraditional: {"水果"},
+	},
+	"insect": {
+		i18n.English:            {"bug"},
+		i18n.German:             {"insekt"},
+		i18n.Spanish:            {"insecto"},
+		i18n.French:             {"insecte"},
+		i18n.Dutch:              {"insecten"},
+		i18n.Polish:             {"owad"},
+		i18n.Portuguese:         {"inseto"},
+		i18n.Russian:            {"насекомое"},
+		i18n.ChineseSimplified:  {"昆虫"},
+		i18n.ChineseTraditional: {"昆蟲"},
+	},
+	"kitchen": {
+		i18n.German:             {"küche"},
+		i18n.Spanish:            {"cocina"},
+		i18n.French:             {"cuisine"},
+		i18n.Dutch:              {"keuken"},
+		i18n.Polish:             {"kuchnia"},
+		i18n.Portuguese:         {"cozinha"},
+		i18n.Russian:            {"кухня"},
+		i18n.ChineseSimplified:  {"厨房"},
+		i18n.ChineseTraditional: {"廚房"},
+	},
+	"landscape": {
+		i18n.English:            {"scenery"},
+		i18n.German:             {"landschaft"},
+		i18n.Spanish:            {"paisaje"},
+		i18n.French:             {"paysage"},
+		i18n.Dutch:              {"landschap"},
+		i18n.Polish:             {"krajobraz"},
+		i18n.Portuguese:         {"paisagem"},
+		i18n.Russian:            {"пейзаж"},
+		i18n.ChineseSimplified:  {"风景"},
+		i18n.ChineseTraditional: {"風景"},
+	},
+	"lion": {
+		i18n.German:             {"löwe"},
+		i18n.Spanish:            {"león"},
+		i18n.Dutch:              {"leeuw"},
+		i18n.Polish:             {"lew"},
+		i18n.Portuguese:         {"leão"},
+		i18n.Russian:            {"лев"},
+		i18n.ChineseSimplified:  {"狮子"},
+		i18n.ChineseTraditional: {"獅子"},
+	},
+	"monkey": {
+		i18n.German:             {"affe"},
+		i18n.Spanish:            {"mono"},
+		i18n.French:             {"singe"},
+		i18n.Dutch:              {"aap"},
+		i18n.Polish:             {"małpa"},
+		i18n.Portuguese:         {"macaco"},
+		i18n.Russian:            {"обезьяна"},
+		i18n.ChineseSimplified:  {"猴子"},
+		i18n.ChineseTraditional: {"猴子"},
+	},
+	"nature": {
+		i18n.German:             {"natur"},
+		i18n.Spanish:            {"naturaleza"},
+		i18n.Dutch:              {"natuur"},
+		i18n.Polish:             {"przyroda", "natura"},
+		i18n.Portuguese:         {"natureza"},
+		i18n.Russian:            {"природа"},
+		i18n.ChineseSimplified:  {"自然"},
+		i18n.ChineseTraditional: {"自然"},
+	},
+	"people": {
+		i18n.English:            {"person", "human"},
+		i18n.German:             {"menschen", "leute"},
+		i18n.Spanish:            {"gente", "personas"},
+		i18n.French:             {"gens", "personnes"},
+		i18n.Dutch:              {"mensen"},
+		i18n.Polish:             {"ludzie"},
+		i18n.Portuguese:         {"pessoas"},
+		i18n.Russian:            {"люди"},
+		i18n.ChineseSimplified:  {"人"},
+		i18n.ChineseTraditional: {"人"},
+	},
+	"plant": {
+		i18n.German:             {"pflanze"},
+		i18n.Spanish:            {"planta"},
+		i18n.French:             {"plante"},
+		i18n.Dutch:              {"planten"},
+		i18n.Polish:             {"roślina"},
+		i18n.Portuguese:         {"planta"},
+		i18n.Russian:            {"растение"},
+		i18n.ChineseSimplified:  {"植物"},
+		i18n.ChineseTraditional: {"植物"},
+	},
+	"portrait": {
+		i18n.English:            {"selfie"},
+		i18n.German:             {"porträt", "bildnis"},
+		i18n.Spanish:            {"retrato"},
+		i18n.Dutch:              {"portret"},
+		i18n.Polish:             {"portret"},
+		i18n.Portuguese:         {"retrato"},
+		i18n.Russian:            {"портрет"},
+		i18n.ChineseSimplified:  {"肖像", "人像"},
+		i18n.ChineseTraditional: {"肖像", "人像"},
+	},
+	"rabbit": {
+		i18n.English:            {"bunny"},
+		i18n.German:             {"hase", "kaninchen"},
+		i18n.Spanish:            {"conejo"},
+		i18n.French:             {"lapin"},
+		i18n.Dutch:              {"konijn"},
+		i18n.Polish:             {"królik"},
+		i18n.Portuguese:         {"coelho"},
+		i18n.Russian:            {"кролик", "заяц"},
+		i18n.ChineseSimplified:  {"兔子"},
+		i18n.ChineseTraditional: {"兔子"},
+	},
+	"seashore": {
+		i18n.English:            {"coast", "sea", "ocean"},
+		i18n.German:             {"küste", "meer"},
+		i18n.Spanish:            {"costa", "mar"},
+		i18n.French:             {"côte", "mer"},
+		i18n.Dutch:              {"kust", "zee"},
+		i18n.Polish:             {"wybrzeże", "morze"},
+		i18n.Portuguese:         {"costa", "mar"},
+		i18n.Russian:            {"побережье", "море"},
+		i18n.ChineseSimplified:  {"海岸", "海"},
+		i18n.ChineseTraditional: {"海岸", "海"},
+	},
+	"sheep": {
+		i18n.English:            {"lamb"},
+		i18n.German:             {"schaf"},
+		i18n.Spanish:            {"oveja"},
+		i18n.French:             {"mouton"},
+		i18n.Dutch:              {"schaap"},
+		i18n.Polish:             {"owca"},
+		i18n.Portuguese:         {"ovelha"},
+		i18n.Russian:            {"овца"},
+		i18n.ChineseSimplified:  {"羊"},
+		i18n.ChineseTraditional: {"羊"},
+	},
+	"ship": {
+		i18n.German:             {"schiff"},
+		i18n.Spanish:            {"buque"},
+		i18n.French:             {"navire"},
+		i18n.Dutch:              {"schip"},
+		i18n.Polish:             {"statek"},
+		i18n.Portuguese:         {"navio"},
+		i18n.Russian:            {"корабль"},
+		i18n.ChineseSimplified:  {"轮船"},
+		i18n.ChineseTraditional: {"輪船"},
+	},
+	"snow": {
+		i18n.English:            {"winter"},
+		i18n.German:             {"schnee"},
+		i18n.Spanish:            {"nieve"},
+		i18n.French:             {"neige"},
+		i18n.Dutch:              {"sneeuw"},
+		i18n.Polish:             {"śnieg"},
+		i18n.Portuguese:         {"neve"},
+		i18n.Russian:            {"снег"},
+		i18n.ChineseSimplified:  {"雪"},
+		i18n.ChineseTraditional: {"雪"},
+	},
+	"tower": {
+		i18n.German:             {"turm"},
+		i18n.Spanish:            {"torre"},
+		i18n.French:             {"tour"},
+		i18n.Dutch:              {"toren"},
+		i18n.Polish:             {"wieża"},
+		i18n.Portuguese:         {"torre"},
+		i18n.Russian:            {"башня"},
+		i18n.ChineseSimplified:  {"塔"},
+		i18n.ChineseTraditional: {"塔"},
+	},
+	"train": {
+		i18n.English:            {"railway", "railroad"},
+		i18n.German:             {"zug", "eisenbahn"},
+		i18n.Spanish:            {"tren"},
+		i18n.Dutch:              {"trein"},
+		i18n.Polish:             {"pociąg"},
+		i18n.Portuguese:         {"trem", "comboio"},
+		i18n.Russian:            {"поезд"},
+		i18n.ChineseSimplified:  {"火车"},
+		i18n.ChineseTraditional: {"火車"},
+	},
+	"truck": {
+		i18n.English:            {"lorry"},
+		i18n.German:             {"lastwagen", "lkw"},
+		i18n.Spanish:            {"camión"},
+		i18n.French:             {"camion"},
+		i18n.Dutch:              {"vrachtwagen"},
+		i18n.Polish:             {"ciężarówka"},
+		i18n.Portuguese:         {"caminhão", "camião"},
+		i18n.Russian:            {"грузовик"},
+		i18n.ChineseSimplified:  {"卡车"},
+		i18n.ChineseTraditional: {"卡車"},
+	},
+	"vegetables": {
+		i18n.English:            {"veggies"},
+		i18n.German:             {"gemüse"},
+		i18n.Spanish:            {"verduras"},
+		i18n.French:             {"légumes"},
+		i18n.Dutch:              {"groente", "groenten"},
+		i18n.Polish:             {"warzywa"},
+		i18n.Portuguese:         {"legumes"},
+		i18n.Russian:            {"овощи"},
+		i18n.ChineseSimplified:  {"蔬菜"},
+		i18n.ChineseTraditional: {"蔬菜"},
+	},
+	"water": {
+		i18n.German:             {"wasser"},
+		i18n.Spanish:            {"agua"},
+		i18n.French:             {"eau"},
+		i18n.Polish:             {"woda"},
+		i18n.Portuguese:         {"água"},
+		i18n.Russian:            {"вода"},
+		i18n.ChineseSimplified:  {"水"},
+		i18n.ChineseTraditional: {"水"},
+	},
+	"wildlife": {
+		i18n.German:             {"wildtiere"},
+		i18n.Spanish:            {"fauna"},
+		i18n.French:             {"faune"},
+		i18n.Dutch:              {"wilde dieren"},
+		i18n.Polish:             {"dzika przyroda"},
+		i18n.Portuguese:         {"vida selvagem"},
+		i18n.Russian:            {"дикая природа"},
+		i18n.ChineseSimplified:  {"野生动物"},
+		i18n.ChineseTraditional: {"野生動物"},
+	},
+	"wine": {
+		i18n.German:             {"wein"},
+		i18n.Spanish:            {"vino"},
+		i18n.French:             {"vin"},
+		i18n.Dutch:              {"wijn"},
+		i18n.Polish:             {"wino"},
+		i18n.Portuguese:         {"vinho"},
+		i18n.Russian:            {"вино"},
+		i18n.ChineseSimplified:  {"葡萄酒"},
+		i18n.ChineseTraditional: {"葡萄酒"},
+	},
+}
@@ -0,0 +1,42 @@
+package classify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSynonyms(t *testing.T) {
+	labels := make(map[string]bool)
+
+	for name, rule := range rules {
+		if rule.Label != "" {
+			labels[rule.Label] = true
+		} else if rule.Priority > -2 {
+			labels[name] = true
+		}
+
+		for _, category := range rule.Categories {
+			labels[category] = true
+		}
+	}
+
+	for label, synonyms := range LabelSynonyms {
+		assert.True(t, labels[label], "unknown label %s", label)
+
+		for locale, words := range synonyms {
+			assert.NotEmpty(t, words, "no %s synonyms for %s", locale, label)
+
+			for _, w := range words {
+				assert.Equal(t, strings.ToLower(w), w)
+				assert.NotEqual(t, label, w)
+			}
+		}
+	}
+
+	t.Run("dog", func(t *testing.T) {
+		assert.Contains(t, LabelSynonyms["dog"]["de"], "hund")
+		assert.Contains(t, LabelSynonyms["dog"]["en"], "puppy")
+	})
+}
@@ -35,6 +35,7 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "config-file", conf.ConfigFile())
 	fmt.Printf("%-25s %s\n", "config-path", conf.ConfigPath())
 	fmt.Printf("%-25s %s\n", "settings-file", conf.SettingsFile())
+	fmt.Printf("%-25s %s\n", "rights-file", conf.RightsFile())
 
 	// Main directories.
 	fmt.Printf("%-25s %s\n", "originals-path", conf.OriginalsPath())
@@ -42,7 +43,9 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "import-path", conf.ImportPath())
 	fmt.Printf("%-25s %s\n", "storage-path", conf.StoragePath())
 	fmt.Printf("%-25s %s\n", "sidecar-path", conf.SidecarPath())
+	fmt.Printf("%-25s %t\n", "write-xmp", conf.WriteXmp())
 	fmt.Printf("%-25s %s\n", "albums-path", conf.AlbumsPath())
+	fmt.Printf("%-25s %s\n", "tracks-path", conf.TracksPath())
 	fmt.Printf("%-25s %s\n", "cache-path", conf.CachePath())
 	fmt.Printf("%-25s %s\n", "temp-path", conf.TempPath())
 	fmt.Printf("%-25s %s\n", "backup-path", conf.BackupPath())
@@ -59,12 +62,16 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-index", conf.AutoIndex()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-import", conf.AutoImport()/time.Second)
+	fmt.Printf("%-25s %d\n", "geotag-offset", conf.GeotagOffset()/time.Second)
+	fmt.Printf("%-25s %d\n", "geotag-max-gap", conf.GeotagMaxGap()/time.Second)
+	fmt.Printf("%-25s %d\n", "estimate-window", conf.EstimateWindow()/time.Second)
 
 	// Disable features.
 	fmt.Printf("%-25s %t\n", "disable-backups", conf.DisableBackups())
 	fmt.Printf("%-25s %t\n", "disable-settings", conf.DisableSettings())
 	fmt.Printf("%-25s %t\n", "disable-places", conf.DisablePlaces())
 	fmt.Printf("%-25s %t\n", "disable-exiftool", conf.DisableExifTool())
+	fmt.Printf("%-25s %t\n", "disable-raw-preview", conf.DisableRawPreview())
 
 	// Everything related to TensorFlow.
 	fmt.Printf("%-25s %t\n", "disable-tensorflow", conf.DisableTensorFlow())
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// GeotagCommand registers the geotag cli command.
+var GeotagCommand = cli.Command{
+	Name:      "geotag",
+	Usage:     "Adds locations from GPX, KML and GeoJSON tracks to photos without GPS",
+	ArgsUsage: "[track files]",
+	Flags:     geotagFlags,
+	Action:    geotagAction,
+}
+
+var geotagFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, only show matching photos without changing them",
+	},
+	cli.IntFlag{
+		Name:  "offset",
+		Usage: "camera clock offset in `SECONDS`, overrides the configured default",
+	},
+	cli.IntFlag{
+		Name:  "max-gap",
+		Usage: "max time between track points in `SECONDS`, overrides the configured default",
+	},
+	cli.StringFlag{
+		Name:  "time-zone",
+		Usage: "camera time zone `NAME` for photos without time zone, e.g. Europe/Berlin",
+	},
+}
+
+// geotagAction adds locations from GPS tracks to photos without GPS.
+func geotagAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.GeotagOptions{
+		Files:    ctx.Args(),
+		Offset:   conf.GeotagOffset(),
+		MaxGap:   conf.GeotagMaxGap(),
+		TimeZone: ctx.String("time-zone"),
+		DryRun:   ctx.Bool("dry"),
+	}
+
+	if ctx.IsSet("offset") {
+		opt.Offset = time.Duration(ctx.Int("offset")) * time.Second
+	}
+
+	if ctx.IsSet("max-gap") {
+		opt.MaxGap = time.Duration(ctx.Int("max-gap")) * time.Second
+	}
+
+	results, err := service.Geotag().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	if opt.DryRun {
+		fmt.Printf("%-18s %-20s %-20s %-12s %-12s %s\n", "UID", "TAKEN", "TRACK TIME", "LAT", "LNG", "PREVIOUS")
+
+		for _, r := range results {
+			fmt.Printf("%-18s %-20s %-20s %-12f %-12f %f, %f (%s)\n", r.PhotoUID, r.TakenAt.Format("2006-01-02 15:04:05"),
+				r.TrackTime.Format("2006-01-02 15:04:05"), r.Lat, r.Lng, r.PrevLat, r.PrevLng, r.PrevPlaceSrc)
+		}
+
+		log.Infof("geotag: found %d matching photos in %s (dry run)", len(results), time.Since(start))
+	} else {
+		log.Infof("geotag: updated %d photos in %s", len(results), time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -30,6 +31,10 @@ var indexFlags = []cli.Flag{
 		Name:  "cleanup",
 		Usage: "removes orphaned thumbnails and index entries",
 	},
+	cli.BoolFlag{
+		Name:  "from-sidecars",
+		Usage: "rebuilds the index from originals and yaml sidecar files, e.g. after the database was lost",
+	},
 }
 
 // indexAction indexes all photos in originals directory (photo library)
@@ -61,17 +66,37 @@ func indexAction(ctx *cli.Context) error {
 		log.Infof("index: read-only mode enabled")
 	}
 
+	fromSidecars := ctx.Bool("from-sidecars")
+
+	if fromSidecars {
+		log.Infof("index: restoring photo metadata from yaml sidecar files")
+	}
+
 	ind := service.Index()
 
 	indOpt := photoprism.IndexOptions{
-		Path:    subPath,
-		Rescan:  ctx.Bool("all"),
-		Convert: conf.Settings().Index.Convert && conf.SidecarWritable(),
-		Stack:   true,
+		Path:         subPath,
+		Rescan:       ctx.Bool("all") || fromSidecars,
+		Convert:      conf.Settings().Index.Convert && conf.SidecarWritable(),
+		Stack:        true,
+		FromSidecars: fromSidecars,
 	}
 
 	indexed := ind.Start(indOpt)
 
+	if fromSidecars {
+		// Album memberships refer to the restored photo UIDs.
+		if count, err := photoprism.RestoreAlbums(true); err != nil {
+			log.Errorf("restore: %s", err)
+		} else if count > 0 {
+			log.Infof("%d albums restored from yaml files", count)
+		}
+
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("index: %s", err)
+		}
+	}
+
 	prg := service.Purge()
 
 	prgOpt := photoprism.PurgeOptions{
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// LightroomCommand registers the import-lightroom cli command.
+var LightroomCommand = cli.Command{
+	Name:      "import-lightroom",
+	Usage:     "Imports ratings, keywords and collections from a Lightroom Classic catalog",
+	ArgsUsage: "[catalog.lrcat]",
+	Flags:     lightroomFlags,
+	Action:    lightroomAction,
+}
+
+var lightroomFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, only show a report of matching photos and changes",
+	},
+	cli.StringFlag{
+		Name:  "path",
+		Usage: "originals sub `FOLDER` that contains the catalog root folders",
+	},
+}
+
+// lightroomAction imports metadata and collections from a Lightroom catalog.
+func lightroomAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("please specify the catalog file name, e.g. photoprism import-lightroom Lightroom.lrcat")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := photoprism.LightroomOptions{
+		Catalog: ctx.Args().First(),
+		Path:    strings.Trim(ctx.String("path"), "/"),
+		DryRun:  ctx.Bool("dry"),
+	}
+
+	results, err := service.Lightroom().Start(opt)
+
+	if err != nil {
+		return err
+	}
+
+	if opt.DryRun {
+		fmt.Printf("%-18s %-6s %-60s %s\n", "UID", "MATCH", "CATALOG FILE", "CHANGES")
+
+		for _, r := range results {
+			if !r.Matched() {
+				fmt.Printf("%-18s %-6s %-60s %s\n", "-", "-", r.FileName, "not found")
+			} else if len(r.Changes) == 0 {
+				fmt.Printf("%-18s %-6s %-60s %s\n", r.PhotoUID, r.MatchedBy, r.FileName, "none")
+			} else {
+				fmt.Printf("%-18s %-6s %-60s %s\n", r.PhotoUID, r.MatchedBy, r.FileName, strings.Join(r.Changes, ", "))
+			}
+		}
+
+		log.Infof("lightroom: matched %d of %d images in %s (dry run)", results.Matched(), len(results), time.Since(start))
+	} else {
+		log.Infof("lightroom: matched %d of %d images in %s", results.Matched(), len(results), time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// PlacesCommand registers the places cli command.
+var PlacesCommand = cli.Command{
+	Name:  "places",
+	Usage: "Location information subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "estimate",
+			Usage:  "Estimates the location of photos without GPS based on time-adjacent geotagged photos",
+			Flags:  estimateFlags,
+			Action: estimateAction,
+		},
+	},
+}
+
+var estimateFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "window",
+		Usage: "max time in `SECONDS` between photos, overrides the configured default",
+	},
+	cli.BoolFlag{
+		Name:  "folder",
+		Usage: "only use photos in the same folder",
+	},
+	cli.BoolFlag{
+		Name:  "album",
+		Usage: "only use photos in the same album",
+	},
+}
+
+// estimateAction estimates the location of photos without GPS.
+func estimateAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	opt := entity.EstimateOptions{
+		Window:     conf.EstimateWindow(),
+		SameFolder: ctx.Bool("folder"),
+		SameAlbum:  ctx.Bool("album"),
+	}
+
+	if ctx.IsSet("window") {
+		opt.Window = time.Duration(ctx.Int("window")) * time.Second
+	}
+
+	log.Infof("estimate: using photos taken within %s", opt.Window)
+
+	if updated, err := photoprism.NewEstimate(conf).Start(opt); err != nil {
+		return err
+	} else {
+		log.Infof("estimate: updated %d photos in %s", updated, time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
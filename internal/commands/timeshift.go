@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// TimeShiftCommand registers the timeshift cli command.
+var TimeShiftCommand = cli.Command{
+	Name:   "timeshift",
+	Usage:  "Shifts the time of multiple photos, e.g. to correct camera clock errors",
+	Flags:  timeShiftFlags,
+	Action: timeShiftAction,
+}
+
+var timeShiftFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "offset",
+		Usage: "time `DURATION` to add, e.g. 1h30m or -45s",
+	},
+	cli.StringFlag{
+		Name:  "ref",
+		Usage: "reference photo `UID` for setting the time relative to it",
+	},
+	cli.StringFlag{
+		Name:  "ref-time",
+		Usage: "correct local `TIME` of the reference photo, e.g. \"2020-05-01 12:30:00\"",
+	},
+	cli.StringSliceFlag{
+		Name:  "photo",
+		Usage: "photo `UID`, can be used multiple times",
+	},
+	cli.StringSliceFlag{
+		Name:  "album",
+		Usage: "album `UID`, can be used multiple times",
+	},
+	cli.StringFlag{
+		Name:  "serial",
+		Usage: "camera serial `NUMBER`",
+	},
+	cli.StringFlag{
+		Name:  "after",
+		Usage: "only photos taken after this local `DATE`",
+	},
+	cli.StringFlag{
+		Name:  "before",
+		Usage: "only photos taken before this local `DATE`",
+	},
+	cli.BoolFlag{
+		Name:  "dry",
+		Usage: "dry run, only show changes without saving them",
+	},
+}
+
+// timeShiftAction shifts the time of multiple photos.
+func timeShiftAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	f := form.TimeShift{
+		Selection: form.Selection{
+			Photos: ctx.StringSlice("photo"),
+			Albums: ctx.StringSlice("album"),
+		},
+		CameraSerial: ctx.String("serial"),
+		RefPhoto:     ctx.String("ref"),
+		DryRun:       ctx.Bool("dry"),
+	}
+
+	if s := ctx.String("offset"); s != "" {
+		if d, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("invalid offset: %s", err)
+		} else {
+			f.Offset = int(d / time.Second)
+		}
+	}
+
+	for name, t := range map[string]*time.Time{"ref-time": &f.RefTime, "after": &f.After, "before": &f.Before} {
+		if s := ctx.String(name); s == "" {
+			continue
+		} else if parsed, err := dateparse.ParseIn(s, time.UTC); err != nil {
+			return fmt.Errorf("invalid %s: %s", name, err)
+		} else {
+			*t = parsed
+		}
+	}
+
+	if f.Empty() {
+		return fmt.Errorf("no photos selected, use --photo, --album, --serial, --after or --before")
+	} else if f.Offset == 0 && !f.Relative() {
+		return fmt.Errorf("no offset specified, use --offset or --ref with --ref-time")
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	results, err := photoprism.NewTimeShift(conf).Start(f, "")
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-18s %-20s %s\n", "UID", "TAKEN (LOCAL)", "NEW TAKEN (LOCAL)")
+
+	for _, r := range results {
+		fmt.Printf("%-18s %-20s %s\n", r.PhotoUID, r.TakenAtLocal.Format("2006-01-02 15:04:05"), r.NewTakenAtLocal.Format("2006-01-02 15:04:05"))
+	}
+
+	if f.DryRun {
+		log.Infof("timeshift: found %d photos in %s (dry run)", len(results), time.Since(start))
+	} else {
+		log.Infof("timeshift: updated %d photos in %s", len(results), time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
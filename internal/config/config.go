@@ -43,6 +43,10 @@ type Config struct {
 	hub      *hub.Config
 	token    string
 	serial   string
+
+	rights        RightsRules
+	rightsModTime time.Time
+	rightsMutex   sync.Mutex
 }
 
 func init() {
@@ -397,6 +401,33 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// GeotagOffset returns the camera clock offset that is added to photo times when matching them with tracks.
+func (c *Config) GeotagOffset() time.Duration {
+	if c.options.GeotagOffset < -86400 || c.options.GeotagOffset > 86400 {
+		return time.Duration(0)
+	}
+
+	return time.Duration(c.options.GeotagOffset) * time.Second
+}
+
+// GeotagMaxGap returns the max time between track points for interpolating positions.
+func (c *Config) GeotagMaxGap() time.Duration {
+	if c.options.GeotagMaxGap <= 0 || c.options.GeotagMaxGap > 86400 {
+		return 5 * time.Minute
+	}
+
+	return time.Duration(c.options.GeotagMaxGap) * time.Second
+}
+
+// EstimateWindow returns the max time between photos for estimating locations.
+func (c *Config) EstimateWindow() time.Duration {
+	if c.options.EstimateWindow <= 0 || c.options.EstimateWindow > 86400*7 {
+		return 2 * time.Hour
+	}
+
+	return time.Duration(c.options.EstimateWindow) * time.Second
+}
+
 // GeoApi returns the preferred geo coding api (none or places).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
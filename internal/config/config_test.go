@@ -276,6 +276,32 @@ func TestConfig_AutoImport(t *testing.T) {
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
 }
 
+func TestConfig_GeotagOffset(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Duration(0), c.GeotagOffset())
+	c.options.GeotagOffset = -3600
+	assert.Equal(t, -1*time.Hour, c.GeotagOffset())
+	c.options.GeotagOffset = 90000
+	assert.Equal(t, time.Duration(0), c.GeotagOffset())
+}
+
+func TestConfig_GeotagMaxGap(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 5*time.Minute, c.GeotagMaxGap())
+	c.options.GeotagMaxGap = 60
+	assert.Equal(t, time.Minute, c.GeotagMaxGap())
+}
+
+func TestConfig_EstimateWindow(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 2*time.Hour, c.EstimateWindow())
+	c.options.EstimateWindow = 600
+	assert.Equal(t, 10*time.Minute, c.EstimateWindow())
+}
+
 func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
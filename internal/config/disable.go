@@ -37,6 +37,12 @@ func (c *Config) DisableExifTool() bool {
 	return c.options.DisableExifTool
 }
 
+// DisableRawPreview tests if RAW files should always be converted with external tools,
+// even if they contain a usable JPEG preview.
+func (c *Config) DisableRawPreview() bool {
+	return c.options.DisableRawPreview
+}
+
 // DisableTensorFlow tests if TensorFlow should not be used for image classification (or anything else).
 func (c *Config) DisableTensorFlow() bool {
 	return c.options.DisableTensorFlow
@@ -85,6 +85,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "cache storage `PATH` for sessions and thumbnails",
 		EnvVar: "PHOTOPRISM_CACHE_PATH",
 	},
+	cli.StringFlag{
+		Name:   "tracks-path",
+		Usage:  "storage `PATH` for GPX, KML and GeoJSON track files",
+		EnvVar: "PHOTOPRISM_TRACKS_PATH",
+	},
 	cli.StringFlag{
 		Name:   "temp-path",
 		Usage:  "temporary `PATH` for storing uploads and downloads",
@@ -121,6 +126,26 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "auto importing safety delay in `SECONDS` (WebDAV)",
 		EnvVar: "PHOTOPRISM_AUTO_IMPORT",
 	},
+	cli.BoolFlag{
+		Name:   "write-xmp",
+		Usage:  "write metadata changes to XMP sidecar files",
+		EnvVar: "PHOTOPRISM_WRITE_XMP",
+	},
+	cli.IntFlag{
+		Name:   "geotag-offset",
+		Usage:  "camera clock offset in `SECONDS` when matching photos with tracks",
+		EnvVar: "PHOTOPRISM_GEOTAG_OFFSET",
+	},
+	cli.IntFlag{
+		Name:   "geotag-max-gap",
+		Usage:  "max time between track points in `SECONDS` for interpolating positions",
+		EnvVar: "PHOTOPRISM_GEOTAG_MAX_GAP",
+	},
+	cli.IntFlag{
+		Name:   "estimate-window",
+		Usage:  "max time in `SECONDS` between photos for estimating locations",
+		EnvVar: "PHOTOPRISM_ESTIMATE_WINDOW",
+	},
 	cli.BoolFlag{
 		Name:   "disable-backups",
 		Usage:  "don't backup photo and album metadata to YAML files",
@@ -146,6 +171,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "don't create ExifTool JSON files for enhanced metadata extraction",
 		EnvVar: "PHOTOPRISM_DISABLE_EXIFTOOL",
 	},
+	cli.BoolFlag{
+		Name:   "disable-raw-preview",
+		Usage:  "always convert RAW files with external tools instead of using embedded JPEG previews",
+		EnvVar: "PHOTOPRISM_DISABLE_RAW_PREVIEW",
+	},
 	cli.BoolFlag{
 		Name:   "disable-tensorflow",
 		Usage:  "don't use TensorFlow for image classification",
@@ -101,6 +101,12 @@ func (c *Config) CreateDirectories() error {
 		return createError(c.ConfigPath(), err)
 	}
 
+	if c.TracksPath() == "" {
+		return notFoundError("tracks")
+	} else if err := os.MkdirAll(c.TracksPath(), os.ModePerm); err != nil {
+		return createError(c.TracksPath(), err)
+	}
+
 	if c.TempPath() == "" {
 		return notFoundError("temp")
 	} else if err := os.MkdirAll(c.TempPath(), os.ModePerm); err != nil {
@@ -167,6 +173,11 @@ func (c *Config) HubConfigFile() string {
 	return filepath.Join(c.ConfigPath(), "hub.yml")
 }
 
+// RightsFile returns the file name of the rules for default artist, copyright and license values.
+func (c *Config) RightsFile() string {
+	return filepath.Join(c.ConfigPath(), "rights.yml")
+}
+
 // SettingsFile returns the user settings file name.
 func (c *Config) SettingsFile() string {
 	return filepath.Join(c.ConfigPath(), "settings.yml")
@@ -250,6 +261,20 @@ func (c *Config) SidecarWritable() bool {
 	return !c.ReadOnly() || c.SidecarPathIsAbs()
 }
 
+// WriteXmp tests if metadata changes should be written to XMP sidecar files.
+func (c *Config) WriteXmp() bool {
+	return c.options.WriteXmp && c.SidecarWritable()
+}
+
+// TracksPath returns the storage path for GPX, KML and GeoJSON track files.
+func (c *Config) TracksPath() string {
+	if c.options.TracksPath == "" {
+		return filepath.Join(c.StoragePath(), "tracks")
+	}
+
+	return fs.Abs(c.options.TracksPath)
+}
+
 // FFmpegBin returns the ffmpeg executable file name.
 func (c *Config) FFmpegBin() string {
 	return findExecutable(c.options.FFmpegBin, "ffmpeg")
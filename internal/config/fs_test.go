@@ -57,6 +57,25 @@ func TestConfig_SidecarWritable(t *testing.T) {
 	assert.Equal(t, true, c.SidecarWritable())
 }
 
+func TestConfig_WriteXmp(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, false, c.WriteXmp())
+	c.options.WriteXmp = true
+	assert.Equal(t, true, c.WriteXmp())
+	c.options.ReadOnly = true
+	c.options.SidecarPath = ".photoprism"
+	assert.Equal(t, false, c.WriteXmp())
+}
+
+func TestConfig_TracksPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, c.StoragePath()+"/tracks", c.TracksPath())
+	c.options.TracksPath = "/tmp/tracks"
+	assert.Equal(t, "/tmp/tracks", c.TracksPath())
+}
+
 func TestConfig_FFmpegBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, "/usr/bin/ffmpeg", c.FFmpegBin())
@@ -51,15 +51,21 @@ type Options struct {
 	BackupPath        string `yaml:"BackupPath" json:"-" flag:"backup-path"`
 	AssetsPath        string `yaml:"AssetsPath" json:"-" flag:"assets-path"`
 	CachePath         string `yaml:"CachePath" json:"-" flag:"cache-path"`
+	TracksPath        string `yaml:"TracksPath" json:"-" flag:"tracks-path"`
 	Workers           int    `yaml:"Workers" json:"Workers" flag:"workers"`
 	WakeupInterval    int    `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex         int    `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport        int    `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	WriteXmp          bool   `yaml:"WriteXmp" json:"WriteXmp" flag:"write-xmp"`
+	GeotagOffset      int    `yaml:"GeotagOffset" json:"GeotagOffset" flag:"geotag-offset"`
+	GeotagMaxGap      int    `yaml:"GeotagMaxGap" json:"GeotagMaxGap" flag:"geotag-max-gap"`
+	EstimateWindow    int    `yaml:"EstimateWindow" json:"EstimateWindow" flag:"estimate-window"`
 	DisableBackups    bool   `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableWebDAV     bool   `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
 	DisableSettings   bool   `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
 	DisablePlaces     bool   `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
 	DisableExifTool   bool   `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
+	DisableRawPreview bool   `yaml:"DisableRawPreview" json:"DisableRawPreview" flag:"disable-raw-preview"`
 	DisableTensorFlow bool   `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
 	DetectNSFW        bool   `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW        bool   `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/yaml.v2"
+)
+
+// RightsSubject contains the photo properties rights rules can match.
+type RightsSubject struct {
+	Serial      string
+	CameraMake  string
+	CameraModel string
+	Path        string
+	Account     string
+}
+
+// RightsRule assigns default artist, copyright and license values to matching photos.
+// A rule without conditions matches all photos.
+type RightsRule struct {
+	Serial    string `json:"serial" yaml:"Serial,omitempty"`
+	Camera    string `json:"camera" yaml:"Camera,omitempty"`
+	Path      string `json:"path" yaml:"Path,omitempty"`
+	Account   string `json:"account" yaml:"Account,omitempty"`
+	Artist    string `json:"artist" yaml:"Artist,omitempty"`
+	Copyright string `json:"copyright" yaml:"Copyright,omitempty"`
+	License   string `json:"license" yaml:"License,omitempty"`
+}
+
+// Match tests if the rule matches the given subject.
+func (r RightsRule) Match(s RightsSubject) bool {
+	if r.Serial != "" && !strings.EqualFold(strings.TrimSpace(r.Serial), strings.TrimSpace(s.Serial)) {
+		return false
+	}
+
+	if r.Camera != "" {
+		camera := strings.TrimSpace(r.Camera)
+
+		if !strings.EqualFold(camera, s.CameraModel) && !strings.EqualFold(camera, strings.TrimSpace(s.CameraMake+" "+s.CameraModel)) {
+			return false
+		}
+	}
+
+	if prefix := strings.Trim(r.Path, "/"); prefix != "" {
+		path := strings.Trim(s.Path, "/")
+
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			return false
+		}
+	}
+
+	if r.Account != "" && !strings.EqualFold(strings.TrimSpace(r.Account), s.Account) {
+		return false
+	}
+
+	return true
+}
+
+// RightsRules represents a list of rights rules, ordered by precedence.
+type RightsRules []RightsRule
+
+// Find returns the artist, copyright and license of the first matching rule that sets the respective value.
+func (rules RightsRules) Find(s RightsSubject) (artist, copyright, license string) {
+	for _, r := range rules {
+		if !r.Match(s) {
+			continue
+		}
+
+		if artist == "" {
+			artist = r.Artist
+		}
+
+		if copyright == "" {
+			copyright = r.Copyright
+		}
+
+		if license == "" {
+			license = r.License
+		}
+	}
+
+	return artist, copyright, license
+}
+
+// Load rights rules from a file.
+func (rules *RightsRules) Load(fileName string) error {
+	if !fs.FileExists(fileName) {
+		return fmt.Errorf("rights file not found: %s", txt.Quote(fileName))
+	}
+
+	yamlConfig, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(yamlConfig, rules)
+}
+
+// Save rights rules to a file.
+func (rules RightsRules) Save(fileName string) error {
+	data, err := yaml.Marshal(rules)
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, data, os.ModePerm)
+}
+
+// Rights returns the rules for assigning default artist, copyright and license values during indexing.
+// The rules file is reloaded when it has been modified.
+func (c *Config) Rights() RightsRules {
+	c.rightsMutex.Lock()
+	defer c.rightsMutex.Unlock()
+
+	fileName := c.RightsFile()
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		c.rights = nil
+		return nil
+	} else if c.rights != nil && info.ModTime().Equal(c.rightsModTime) {
+		return c.rights
+	}
+
+	rules := RightsRules{}
+
+	if err := rules.Load(fileName); err != nil {
+		log.Errorf("config: %s (load rights)", err)
+		return c.rights
+	}
+
+	log.Debugf("config: loaded %d rights rules from %s", len(rules), txt.Quote(fileName))
+
+	c.rights = rules
+	c.rightsModTime = info.ModTime()
+
+	return c.rights
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRightsRule_Match(t *testing.T) {
+	t.Run("serial", func(t *testing.T) {
+		r := RightsRule{Serial: "12345"}
+
+		assert.True(t, r.Match(RightsSubject{Serial: "12345"}))
+		assert.False(t, r.Match(RightsSubject{Serial: "123456"}))
+		assert.False(t, r.Match(RightsSubject{}))
+	})
+
+	t.Run("camera", func(t *testing.T) {
+		r := RightsRule{Camera: "canon eos 6d"}
+
+		assert.True(t, r.Match(RightsSubject{CameraMake: "Canon", CameraModel: "EOS 6D"}))
+		assert.True(t, r.Match(RightsSubject{CameraModel: "Canon EOS 6D"}))
+		assert.False(t, r.Match(RightsSubject{CameraMake: "Canon", CameraModel: "EOS 5D"}))
+	})
+
+	t.Run("path", func(t *testing.T) {
+		r := RightsRule{Path: "/2020/Wedding/"}
+
+		assert.True(t, r.Match(RightsSubject{Path: "2020/Wedding"}))
+		assert.True(t, r.Match(RightsSubject{Path: "2020/Wedding/Party"}))
+		assert.False(t, r.Match(RightsSubject{Path: "2020/WeddingParty"}))
+		assert.False(t, r.Match(RightsSubject{Path: "2021"}))
+	})
+
+	t.Run("account", func(t *testing.T) {
+		r := RightsRule{Account: "Family NAS"}
+
+		assert.True(t, r.Match(RightsSubject{Account: "Family NAS"}))
+		assert.True(t, r.Match(RightsSubject{Account: "family nas"}))
+		assert.False(t, r.Match(RightsSubject{}))
+	})
+
+	t.Run("all conditions", func(t *testing.T) {
+		r := RightsRule{Serial: "12345", Path: "2020"}
+
+		assert.True(t, r.Match(RightsSubject{Serial: "12345", Path: "2020/01"}))
+		assert.False(t, r.Match(RightsSubject{Serial: "12345", Path: "2019/01"}))
+	})
+
+	t.Run("no conditions", func(t *testing.T) {
+		assert.True(t, RightsRule{Artist: "Jane Doe"}.Match(RightsSubject{}))
+	})
+}
+
+func TestRightsRules_Find(t *testing.T) {
+	rules := RightsRules{}
+
+	if err := rules.Load("testdata/rights.yml"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, rules, 4)
+
+	t.Run("serial", func(t *testing.T) {
+		artist, copyright, license := rules.Find(RightsSubject{Serial: "12345"})
+
+		assert.Equal(t, "Jane Doe", artist)
+		assert.Equal(t, "", copyright)
+		assert.Equal(t, "CC BY-NC 4.0", license)
+	})
+
+	t.Run("camera and path", func(t *testing.T) {
+		artist, copyright, license := rules.Find(RightsSubject{CameraMake: "Canon", CameraModel: "EOS 6D", Path: "2020/Wedding"})
+
+		assert.Equal(t, "Unknown Photographer", artist)
+		assert.Equal(t, "Studio Example", copyright)
+		assert.Equal(t, "", license)
+	})
+
+	t.Run("account", func(t *testing.T) {
+		_, copyright, _ := rules.Find(RightsSubject{Account: "Family NAS"})
+
+		assert.Equal(t, "Example Agency", copyright)
+	})
+}
+
+func TestRightsRules_Load(t *testing.T) {
+	rules := RightsRules{}
+
+	assert.Error(t, rules.Load("testdata/rights_123.yml"))
+}
+
+func TestConfig_Rights(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rights")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	c := NewTestConfig()
+	c.options.ConfigPath = dir
+
+	assert.Equal(t, filepath.Join(dir, "rights.yml"), c.RightsFile())
+	assert.Empty(t, c.Rights())
+
+	if err := (RightsRules{{Serial: "12345", Artist: "Jane Doe"}}).Save(c.RightsFile()); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := c.Rights()
+
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, "Jane Doe", rules[0].Artist)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
 
@@ -57,8 +58,9 @@ type FeatureSettings struct {
 
 // ImportSettings represents import settings.
 type ImportSettings struct {
-	Path string `json:"path" yaml:"Path"`
-	Move bool   `json:"move" yaml:"Move"`
+	Path     string `json:"path" yaml:"Path"`
+	Move     bool   `json:"move" yaml:"Move"`
+	Template string `json:"template" yaml:"Template"` // Destination path template, see fs.PathTokens.
 }
 
 // IndexSettings represents indexing settings.
@@ -70,9 +72,11 @@ type IndexSettings struct {
 
 // StackSettings represents settings for files that belong to the same photo.
 type StackSettings struct {
-	UUID bool `json:"uuid" yaml:"UUID"`
-	Meta bool `json:"meta" yaml:"Meta"`
-	Name bool `json:"name" yaml:"Name"`
+	UUID     bool `json:"uuid" yaml:"UUID"`
+	Meta     bool `json:"meta" yaml:"Meta"`
+	Name     bool `json:"name" yaml:"Name"`
+	Burst    bool `json:"burst" yaml:"Burst"`
+	Interval int  `json:"interval" yaml:"Interval"` // Max time between burst frames in milliseconds.
 }
 
 // ShareSettings represents content sharing settings.
@@ -135,8 +139,9 @@ func NewSettings() *Settings {
 			Logs:      true,
 		},
 		Import: ImportSettings{
-			Path: entity.RootPath,
-			Move: false,
+			Path:     entity.RootPath,
+			Move:     false,
+			Template: fs.DefaultPathTemplate,
 		},
 		Index: IndexSettings{
 			Path:    entity.RootPath,
@@ -144,9 +149,11 @@ func NewSettings() *Settings {
 			Convert: true,
 		},
 		Stack: StackSettings{
-			UUID: true,
-			Meta: true,
-			Name: false,
+			UUID:     true,
+			Meta:     true,
+			Name:     false,
+			Burst:    true,
+			Interval: 1000,
 		},
 		Share: ShareSettings{
 			Title: "",
@@ -177,6 +184,20 @@ func (s Settings) StackMeta() bool {
 	return s.Stack.Meta
 }
 
+// StackBursts tests if photos taken in burst or continuous shooting mode should be grouped.
+func (s Settings) StackBursts() bool {
+	return s.Stack.Burst
+}
+
+// BurstInterval returns the max time between frames of the same burst.
+func (s Settings) BurstInterval() time.Duration {
+	if s.Stack.Interval <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(s.Stack.Interval) * time.Millisecond
+}
+
 // Load user settings from file.
 func (s *Settings) Load(fileName string) error {
 	if !fs.FileExists(fileName) {
@@ -401,6 +401,13 @@ func (m *Album) AddPhotos(UIDs []string) (added PhotoAlbums) {
 	return added
 }
 
+// AddPhoto adds a photo to an existing album at the given position.
+func (m *Album) AddPhoto(uid string, order int) error {
+	entry := PhotoAlbum{AlbumUID: m.AlbumUID, PhotoUID: uid, Order: order, Hidden: false}
+
+	return entry.Save()
+}
+
 // RemovePhotos removes photos from an album.
 func (m *Album) RemovePhotos(UIDs []string) (removed PhotoAlbums) {
 	for _, uid := range UIDs {
@@ -423,6 +423,30 @@ func TestAlbum_AddPhotos(t *testing.T) {
 	})
 }
 
+func TestAlbum_AddPhoto(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		album := Album{
+			AlbumUID:   "abc123",
+			AlbumSlug:  "test-slug",
+			AlbumType:  AlbumDefault,
+			AlbumTitle: "Test Title",
+		}
+
+		if err := album.AddPhoto("ef", 3); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := PhotoAlbum{}
+
+		if err := Db().Where("album_uid = ? AND photo_uid = ?", "abc123", "ef").First(&entry).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 3, entry.Order)
+		assert.False(t, entry.Hidden)
+	})
+}
+
 func TestAlbum_RemovePhotos(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		album := Album{
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+type Bursts []Burst
+
+// Burst represents a sequence of photos taken in quick succession, e.g. in continuous shooting mode.
+type Burst struct {
+	ID           uint      `gorm:"primary_key" json:"-" yaml:"-"`
+	BurstUID     string    `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	BurstUUID    string    `gorm:"type:VARBINARY(64);index;" json:"UUID" yaml:"UUID,omitempty"`
+	CameraSerial string    `gorm:"type:VARBINARY(255);index;" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
+	PhotoUID     string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	BestSrc      string    `gorm:"type:VARBINARY(8);" json:"BestSrc" yaml:"BestSrc,omitempty"`
+	PhotoCount   int       `json:"PhotoCount" yaml:"-"`
+	TakenFrom    int64     `json:"TakenFrom" yaml:"-"`  // Unix time in milliseconds.
+	TakenUntil   int64     `json:"TakenUntil" yaml:"-"` // Unix time in milliseconds.
+	CreatedAt    time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt    time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// NewBurst returns a new burst entity.
+func NewBurst(burstUUID, cameraSerial string, takenAt time.Time) *Burst {
+	result := &Burst{
+		BurstUUID:    burstUUID,
+		CameraSerial: cameraSerial,
+	}
+
+	result.AddTime(takenAt)
+
+	return result
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Burst) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.BurstUID, 'b') {
+		return nil
+	}
+
+	return scope.SetColumn("BurstUID", rnd.PPID('b'))
+}
+
+// Create inserts a new row to the database.
+func (m *Burst) Create() error {
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new row.
+func (m *Burst) Save() error {
+	return Db().Save(m).Error
+}
+
+// FindBurst returns a burst by UID or nil if it doesn't exist.
+func FindBurst(uid string) *Burst {
+	result := Burst{}
+
+	if err := Db().Where("burst_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindBurstByUUID returns a burst by the unique ID its camera assigned, or nil if it doesn't exist.
+func FindBurstByUUID(burstUUID string) *Burst {
+	result := Burst{}
+
+	if burstUUID == "" {
+		return nil
+	} else if err := Db().Where("burst_uuid = ?", burstUUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindBurstByTime returns a burst taken with the same camera that ended or started within the interval, or nil if it doesn't exist.
+func FindBurstByTime(cameraSerial string, takenAt time.Time, interval time.Duration) *Burst {
+	result := Burst{}
+
+	if cameraSerial == "" || takenAt.IsZero() {
+		return nil
+	}
+
+	ms := unixMilli(takenAt)
+	d := interval.Milliseconds()
+
+	if err := Db().Where("camera_serial = ? AND burst_uuid = '' AND taken_from - ? <= ? AND taken_until + ? >= ?", cameraSerial, d, ms, d, ms).
+		Order("taken_until DESC").First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// AddTime extends the time range of the burst if needed.
+func (m *Burst) AddTime(takenAt time.Time) {
+	if takenAt.IsZero() {
+		return
+	}
+
+	ms := unixMilli(takenAt)
+
+	if m.TakenFrom == 0 || ms < m.TakenFrom {
+		m.TakenFrom = ms
+	}
+
+	if ms > m.TakenUntil {
+		m.TakenUntil = ms
+	}
+}
+
+// SetBest chooses the best frame of the burst. Automatic choices don't replace a manual choice.
+func (m *Burst) SetBest(photoUID, src string) error {
+	if !rnd.IsPPID(photoUID, 'p') {
+		return fmt.Errorf("burst: invalid photo uid %s", photoUID)
+	}
+
+	if src == SrcAuto && m.BestSrc == SrcManual && m.PhotoUID != "" {
+		return nil
+	}
+
+	m.PhotoUID = photoUID
+	m.BestSrc = src
+
+	return Db().Model(m).Updates(map[string]interface{}{"PhotoUID": m.PhotoUID, "BestSrc": m.BestSrc}).Error
+}
+
+// UpdateBest updates the photo count and chooses the sharpest frame as best photo, unless
+// it was chosen manually and is still part of the burst. Private frames are only chosen
+// if all frames are private, since the best frame represents the burst in the library.
+// Likewise, frames with a quality score below 3 are only chosen if there are no others,
+// as they are hidden from the library until reviewed.
+func (m *Burst) UpdateBest() error {
+	var frames []struct {
+		PhotoUID      string
+		PhotoPrivate  bool
+		PhotoQuality  int
+		FileSharpness uint32
+	}
+
+	if err := Db().Table("photos").
+		Select("photos.photo_uid, photos.photo_private, photos.photo_quality, files.file_sharpness").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("photos.burst_uid = ? AND photos.deleted_at IS NULL", m.BurstUID).
+		Order("photos.photo_private, photos.photo_quality < 3, files.file_sharpness DESC, photos.taken_at, photos.photo_uid").
+		Scan(&frames).Error; err != nil {
+		return err
+	}
+
+	m.PhotoCount = len(frames)
+
+	if err := Db().Model(m).UpdateColumn("PhotoCount", m.PhotoCount).Error; err != nil {
+		return err
+	} else if len(frames) == 0 {
+		return nil
+	}
+
+	if m.BestSrc == SrcManual {
+		for _, f := range frames {
+			if f.PhotoUID == m.PhotoUID && f.PhotoPrivate == frames[0].PhotoPrivate &&
+				(f.PhotoQuality < 3) == (frames[0].PhotoQuality < 3) {
+				return nil
+			}
+		}
+	}
+
+	if m.PhotoUID == frames[0].PhotoUID && m.BestSrc == SrcAuto {
+		return nil
+	}
+
+	m.BestSrc = SrcAuto
+
+	return m.SetBest(frames[0].PhotoUID, SrcAuto)
+}
+
+// UpdatePhotoBursts chooses the best frame of all bursts the given photos belong to again,
+// e.g. after they were archived, restored or made private.
+func UpdatePhotoBursts(photoUIDs []string) error {
+	var burstUIDs []string
+
+	if len(photoUIDs) == 0 {
+		return nil
+	} else if err := UnscopedDb().Model(&Photo{}).
+		Where("photo_uid IN (?) AND burst_uid <> ''", photoUIDs).
+		Pluck("DISTINCT burst_uid", &burstUIDs).Error; err != nil {
+		return err
+	}
+
+	for _, uid := range burstUIDs {
+		if burst := FindBurst(uid); burst == nil {
+			continue
+		} else if err := burst.UpdateBest(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unixMilli returns the time as Unix time in milliseconds.
+func unixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
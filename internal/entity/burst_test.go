@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBurst(t *testing.T) {
+	taken := time.Date(2021, 2, 13, 15, 24, 7, 364000000, time.UTC)
+	burst := NewBurst("", "123456", taken)
+
+	assert.Equal(t, "123456", burst.CameraSerial)
+	assert.Equal(t, int64(1613229847364), burst.TakenFrom)
+	assert.Equal(t, int64(1613229847364), burst.TakenUntil)
+
+	burst.AddTime(taken.Add(250 * time.Millisecond))
+	burst.AddTime(taken.Add(-100 * time.Millisecond))
+	burst.AddTime(time.Time{})
+
+	assert.Equal(t, int64(1613229847264), burst.TakenFrom)
+	assert.Equal(t, int64(1613229847614), burst.TakenUntil)
+}
+
+func TestFindBurst(t *testing.T) {
+	taken := time.Date(2021, 2, 13, 15, 24, 7, 364000000, time.UTC)
+
+	withUUID := NewBurst("7C2D5A39-9E4F-4A11-8D6C-1F5B0E2A8C44", "", taken)
+
+	if err := withUUID.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	bySerial := NewBurst("", "TestFindBurst", taken)
+
+	if err := bySerial.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("uid", func(t *testing.T) {
+		if result := FindBurst(bySerial.BurstUID); result == nil {
+			t.Fatal("burst should not be nil")
+		} else {
+			assert.Equal(t, bySerial.ID, result.ID)
+		}
+
+		assert.Nil(t, FindBurst("bt9jtdre2lvl0yxx"))
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		if result := FindBurstByUUID("7C2D5A39-9E4F-4A11-8D6C-1F5B0E2A8C44"); result == nil {
+			t.Fatal("burst should not be nil")
+		} else {
+			assert.Equal(t, withUUID.BurstUID, result.BurstUID)
+		}
+
+		assert.Nil(t, FindBurstByUUID(""))
+	})
+
+	t.Run("time", func(t *testing.T) {
+		if result := FindBurstByTime("TestFindBurst", taken.Add(800*time.Millisecond), time.Second); result == nil {
+			t.Fatal("burst should not be nil")
+		} else {
+			assert.Equal(t, bySerial.BurstUID, result.BurstUID)
+		}
+
+		assert.Nil(t, FindBurstByTime("TestFindBurst", taken.Add(2*time.Second), time.Second))
+		assert.Nil(t, FindBurstByTime("xxx", taken, time.Second))
+		assert.Nil(t, FindBurstByTime("", taken, time.Second))
+	})
+}
+
+func TestBurst_UpdateBest(t *testing.T) {
+	burst := NewBurst("", "TestBurst_UpdateBest", time.Now())
+
+	if err := burst.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	photo04 := PhotoFixtures.Get("Photo04")
+	photo10 := PhotoFixtures.Get("Photo10")
+
+	// Frames with a low quality score are only chosen if there are no others.
+	quality := photo10.PhotoQuality
+
+	if err := UnscopedDb().Model(&photo10).UpdateColumn("photo_quality", 3).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", photo10.PhotoUID).UpdateColumn("photo_quality", quality)
+
+	for _, p := range []Photo{photo04, photo10} {
+		if err := p.Update("BurstUID", burst.BurstUID); err != nil {
+			t.Fatal(err)
+		}
+
+		defer p.Update("BurstUID", "")
+	}
+
+	if err := burst.UpdateBest(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, burst.PhotoCount)
+	assert.Contains(t, []string{photo04.PhotoUID, photo10.PhotoUID}, burst.PhotoUID)
+	assert.Equal(t, SrcAuto, burst.BestSrc)
+
+	other := photo04.PhotoUID
+
+	if burst.PhotoUID == other {
+		other = photo10.PhotoUID
+	}
+
+	t.Run("manual", func(t *testing.T) {
+		if err := burst.SetBest(other, SrcManual); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := burst.UpdateBest(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, other, burst.PhotoUID)
+		assert.Equal(t, SrcManual, burst.BestSrc)
+		assert.Equal(t, other, FindBurst(burst.BurstUID).PhotoUID)
+	})
+
+	t.Run("private", func(t *testing.T) {
+		best := burst.PhotoUID
+		public := photo04.PhotoUID
+
+		if best == public {
+			public = photo10.PhotoUID
+		}
+
+		if err := UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", best).UpdateColumn("photo_private", true).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", best).UpdateColumn("photo_private", false)
+
+		if err := UpdatePhotoBursts([]string{best}); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindBurst(burst.BurstUID)
+
+		assert.Equal(t, public, result.PhotoUID)
+		assert.Equal(t, SrcAuto, result.BestSrc)
+		assert.Equal(t, 2, result.PhotoCount)
+	})
+
+	t.Run("low quality", func(t *testing.T) {
+		best := FindBurst(burst.BurstUID).PhotoUID
+		other := photo04.PhotoUID
+
+		if best == other {
+			other = photo10.PhotoUID
+		}
+
+		if err := UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", best).UpdateColumn("photo_quality", 1).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer UnscopedDb().Model(&Photo{}).Where("photo_uid = ?", best).UpdateColumn("photo_quality", 3)
+
+		if err := UpdatePhotoBursts([]string{best}); err != nil {
+			t.Fatal(err)
+		}
+
+		result := FindBurst(burst.BurstUID)
+
+		assert.Equal(t, other, result.PhotoUID)
+		assert.Equal(t, SrcAuto, result.BestSrc)
+	})
+
+	t.Run("invalid uid", func(t *testing.T) {
+		assert.Error(t, burst.SetBest("xxx", SrcManual))
+	})
+}
+
+func TestUpdatePhotoBursts(t *testing.T) {
+	assert.NoError(t, UpdatePhotoBursts(nil))
+	assert.NoError(t, UpdatePhotoBursts([]string{PhotoFixtures.Get("Photo01").PhotoUID}))
+}
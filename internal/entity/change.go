@@ -0,0 +1,351 @@
+package entity
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Change sources.
+const (
+	ChangeManual = SrcManual
+	ChangeBatch  = "batch"
+	ChangeUndo   = "undo"
+)
+
+// Change groups revisions that were made together, so that they can be undone as one unit.
+//
+// Archiving, restoring and approving photos is not recorded, since these actions can be
+// reverted from the user interface by restoring, archiving or editing the photos again.
+type Change struct {
+	ChangeUID string
+	UserUID   string
+	Source    string
+	Revisions Revisions
+}
+
+// NewChange creates a new change set for the given user and source.
+func NewChange(userUID, source string) *Change {
+	return &Change{
+		ChangeUID: rnd.PPID('c'),
+		UserUID:   userUID,
+		Source:    source,
+	}
+}
+
+// Empty tests if the change doesn't contain any revisions.
+func (c *Change) Empty() bool {
+	return len(c.Revisions) == 0
+}
+
+// PhotoUIDs returns the unique photo uids affected by this change.
+func (c *Change) PhotoUIDs() (result []string) {
+	done := make(map[string]bool)
+
+	for _, r := range c.Revisions {
+		if done[r.PhotoUID] {
+			continue
+		}
+
+		done[r.PhotoUID] = true
+		result = append(result, r.PhotoUID)
+	}
+
+	return result
+}
+
+// add appends a new revision to the change.
+func (c *Change) add(photoUID, kind, field, oldValue, newValue string) {
+	c.Revisions = append(c.Revisions, Revision{
+		ChangeUID:    c.ChangeUID,
+		PhotoUID:     photoUID,
+		RevisionKind: kind,
+		Field:        field,
+		OldValue:     oldValue,
+		NewValue:     newValue,
+		RevisionSrc:  c.Source,
+		UserUID:      c.UserUID,
+	})
+}
+
+// Photo compares two versions of a photo and adds a revision for each changed property, detail and label.
+func (c *Change) Photo(old, new *Photo) {
+	if old == nil || new == nil {
+		return
+	}
+
+	oldValue := reflect.ValueOf(old).Elem()
+	newValue := reflect.ValueOf(new).Elem()
+
+	for _, name := range RevisionPhotoFields {
+		if o, n := revisionValue(oldValue.FieldByName(name)), revisionValue(newValue.FieldByName(name)); o != n {
+			c.add(new.PhotoUID, RevisionPhoto, name, o, n)
+		}
+	}
+
+	oldDetails, newDetails := Details{}, Details{}
+
+	if old.Details != nil {
+		oldDetails = *old.Details
+	}
+
+	if new.Details != nil {
+		newDetails = *new.Details
+	}
+
+	oldValue = reflect.ValueOf(&oldDetails).Elem()
+	newValue = reflect.ValueOf(&newDetails).Elem()
+
+	for _, name := range RevisionDetailsFields {
+		if o, n := revisionValue(oldValue.FieldByName(name)), revisionValue(newValue.FieldByName(name)); o != n {
+			c.add(new.PhotoUID, RevisionDetails, name, o, n)
+		}
+	}
+
+	oldLabels := make(map[uint]string, len(old.Labels))
+	newLabels := make(map[uint]string, len(new.Labels))
+
+	for _, l := range old.Labels {
+		oldLabels[l.LabelID] = labelRevisionValue(l)
+	}
+
+	for _, l := range new.Labels {
+		newLabels[l.LabelID] = labelRevisionValue(l)
+
+		if o := oldLabels[l.LabelID]; o != newLabels[l.LabelID] {
+			c.add(new.PhotoUID, RevisionLabel, strconv.FormatUint(uint64(l.LabelID), 10), o, newLabels[l.LabelID])
+		}
+	}
+
+	for _, l := range old.Labels {
+		if _, ok := newLabels[l.LabelID]; !ok {
+			c.add(new.PhotoUID, RevisionLabel, strconv.FormatUint(uint64(l.LabelID), 10), oldLabels[l.LabelID], "")
+		}
+	}
+}
+
+// Album adds a revision for each photo whose album membership will change.
+// It must be called before the album is updated.
+func (c *Change) Album(albumUID string, photoUIDs []string, member bool) {
+	c.album(Db(), albumUID, photoUIDs, member)
+}
+
+// album adds album revisions using the given database connection, e.g. a transaction.
+func (c *Change) album(db *gorm.DB, albumUID string, photoUIDs []string, member bool) {
+	var current PhotoAlbums
+
+	if err := db.Where("album_uid = ? AND photo_uid IN (?) AND hidden = 0", albumUID, photoUIDs).Find(&current).Error; err != nil {
+		log.Errorf("change: %s", err)
+		return
+	}
+
+	members := make(map[string]bool, len(current))
+
+	for _, m := range current {
+		members[m.PhotoUID] = true
+	}
+
+	for _, uid := range photoUIDs {
+		if members[uid] != member {
+			c.add(uid, RevisionAlbum, albumUID, strconv.FormatBool(members[uid]), strconv.FormatBool(member))
+		}
+	}
+}
+
+// Save stores all revisions in the database.
+func (c *Change) Save() error {
+	return c.create(Db())
+}
+
+// create stores all revisions using the given database connection, e.g. a transaction.
+func (c *Change) create(db *gorm.DB) error {
+	for i := range c.Revisions {
+		if err := db.Create(&c.Revisions[i]).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevertPhoto reverts the given revision and all later revisions of the same photo.
+func RevertPhoto(photoUID string, revisionID uint, userUID string) (*Change, error) {
+	var revisions Revisions
+
+	if err := Db().Where("photo_uid = ? AND id >= ?", photoUID, revisionID).Order("id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	} else if len(revisions) == 0 {
+		return nil, fmt.Errorf("change: no revisions found for %s", txt.Quote(photoUID))
+	}
+
+	return RevertRevisions(revisions, userUID)
+}
+
+// UndoChange reverts all revisions of a change, e.g. a batch edit.
+func UndoChange(changeUID, userUID string) (*Change, error) {
+	var revisions Revisions
+
+	if err := Db().Where("change_uid = ?", changeUID).Order("id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	} else if len(revisions) == 0 {
+		return nil, fmt.Errorf("change: %s not found", txt.Quote(changeUID))
+	}
+
+	return RevertRevisions(revisions, userUID)
+}
+
+// RevertRevisions restores the old values of the given revisions, which must be sorted from newest to oldest.
+// All photos are updated in a single transaction, so that either all or none of the revisions are restored.
+// The result is recorded as a new change, so that it can be undone as well.
+func RevertRevisions(revisions Revisions, userUID string) (*Change, error) {
+	change := NewChange(userUID, ChangeUndo)
+
+	byPhoto := make(map[string]Revisions)
+	var photoUIDs []string
+
+	for _, r := range revisions {
+		if _, ok := byPhoto[r.PhotoUID]; !ok {
+			photoUIDs = append(photoUIDs, r.PhotoUID)
+		}
+
+		byPhoto[r.PhotoUID] = append(byPhoto[r.PhotoUID], r)
+	}
+
+	// Load all photos before the transaction starts.
+	photos := make(Photos, 0, len(photoUIDs))
+
+	for _, uid := range photoUIDs {
+		m := Photo{PhotoUID: uid}
+
+		if err := m.Find(); err != nil {
+			return change, fmt.Errorf("change: %s (find photo %s)", err, txt.Quote(uid))
+		} else if err := m.loadBatchDetails(); err != nil {
+			return change, err
+		}
+
+		photos = append(photos, m)
+	}
+
+	tx := UnscopedDb().Begin()
+
+	for i := range photos {
+		m := &photos[i]
+		old := m.Clone()
+
+		if err := m.revert(tx, byPhoto[m.PhotoUID], change); err != nil {
+			tx.Rollback()
+			return change, err
+		}
+
+		change.Photo(&old, m)
+	}
+
+	if err := change.create(tx); err != nil {
+		tx.Rollback()
+		return change, err
+	} else if err := tx.Commit().Error; err != nil {
+		return change, err
+	}
+
+	// Update primary files and search index after successful commit.
+	for i := range photos {
+		m := &photos[i]
+
+		if err := m.ResolvePrimary(); err != nil {
+			log.Errorf("change: %s", err)
+		}
+
+		if err := m.IndexKeywords(); err != nil {
+			log.Errorf("change: %s", err)
+		}
+	}
+
+	if err := UpdatePhotoCounts(); err != nil {
+		log.Errorf("change: %s", err)
+	}
+
+	return change, nil
+}
+
+// revert restores the old values of the given photo revisions as part of a transaction.
+func (m *Photo) revert(tx *gorm.DB, revisions Revisions, change *Change) error {
+	photoValue := reflect.ValueOf(m).Elem()
+	detailsValue := reflect.ValueOf(m.Details).Elem()
+
+	for _, r := range revisions {
+		switch r.RevisionKind {
+		case RevisionPhoto:
+			if f := photoValue.FieldByName(r.Field); !f.IsValid() {
+				return fmt.Errorf("change: unknown photo field %s", r.Field)
+			} else if err := setRevisionValue(f, r.OldValue); err != nil {
+				return fmt.Errorf("change: %s (revert %s)", err, r.Field)
+			}
+		case RevisionDetails:
+			if f := detailsValue.FieldByName(r.Field); !f.IsValid() {
+				return fmt.Errorf("change: unknown details field %s", r.Field)
+			} else if err := setRevisionValue(f, r.OldValue); err != nil {
+				return fmt.Errorf("change: %s (revert %s)", err, r.Field)
+			}
+		case RevisionLabel:
+			if err := m.revertLabel(tx, r); err != nil {
+				return err
+			}
+		case RevisionAlbum:
+			member := r.OldValue == "true"
+			change.album(tx, r.Field, []string{m.PhotoUID}, member)
+
+			entry := PhotoAlbum{AlbumUID: r.Field, PhotoUID: m.PhotoUID, Hidden: !member}
+
+			if err := tx.Save(&entry).Error; err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("change: unknown revision kind %s", txt.Quote(r.RevisionKind))
+		}
+	}
+
+	m.Labels = nil
+	m.Cell = nil
+	m.Place = nil
+	m.Camera = nil
+	m.Lens = nil
+
+	edited := Timestamp()
+	m.EditedAt = &edited
+	m.PhotoQuality = m.QualityScore()
+
+	if err := tx.Save(m).Error; err != nil {
+		return err
+	} else if err := tx.Save(m.Details).Error; err != nil {
+		return err
+	}
+
+	return tx.Where("photo_id = ?", m.ID).Find(&m.Labels).Error
+}
+
+// revertLabel restores the uncertainty and source of a photo label, or removes it if it didn't exist.
+func (m *Photo) revertLabel(tx *gorm.DB, r Revision) error {
+	labelID, err := strconv.ParseUint(r.Field, 10, 64)
+
+	if err != nil {
+		return fmt.Errorf("change: invalid label id %s", txt.Quote(r.Field))
+	}
+
+	if r.OldValue == "" {
+		return tx.Where("photo_id = ? AND label_id = ?", m.ID, labelID).Delete(&PhotoLabel{}).Error
+	}
+
+	uncertainty, src, err := parseLabelRevision(r.OldValue)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Where(PhotoLabel{PhotoID: m.ID, LabelID: uint(labelID)}).
+		Assign(map[string]interface{}{"label_src": src, "uncertainty": uncertainty}).
+		FirstOrCreate(&PhotoLabel{}).Error
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChange_Photo(t *testing.T) {
+	old := Photo{
+		PhotoUID:   "pt9jtdre2lvl0y30",
+		PhotoTitle: "Old",
+		Details:    &Details{Keywords: "foo"},
+		Labels:     []PhotoLabel{{LabelID: 1, Uncertainty: 20, LabelSrc: "image"}, {LabelID: 2, Uncertainty: 0, LabelSrc: "manual"}},
+	}
+
+	updated := old.Clone()
+	updated.PhotoTitle = "New"
+	updated.Details.Keywords = "foo, bar"
+	updated.Labels = []PhotoLabel{{LabelID: 1, Uncertainty: 100, LabelSrc: "manual"}, {LabelID: 3, Uncertainty: 0, LabelSrc: "manual"}}
+
+	c := NewChange("uqxetse3cy5eo9z2", ChangeManual)
+	c.Photo(&old, &updated)
+
+	assert.Equal(t, "Old", old.PhotoTitle)
+	assert.Equal(t, "foo", old.Details.Keywords)
+	assert.Len(t, c.Revisions, 5)
+	assert.Equal(t, []string{"pt9jtdre2lvl0y30"}, c.PhotoUIDs())
+
+	fields := make(map[string]Revision)
+
+	for _, r := range c.Revisions {
+		assert.Equal(t, c.ChangeUID, r.ChangeUID)
+		assert.Equal(t, "uqxetse3cy5eo9z2", r.UserUID)
+		assert.Equal(t, ChangeManual, r.RevisionSrc)
+		fields[r.RevisionKind+":"+r.Field] = r
+	}
+
+	assert.Equal(t, "New", fields["photo:PhotoTitle"].NewValue)
+	assert.Equal(t, "foo, bar", fields["details:Keywords"].NewValue)
+	assert.Equal(t, "20 image", fields["label:1"].OldValue)
+	assert.Equal(t, "100 manual", fields["label:1"].NewValue)
+	assert.Equal(t, "", fields["label:2"].NewValue)
+	assert.Equal(t, "", fields["label:3"].OldValue)
+}
+
+func TestChange_Album(t *testing.T) {
+	album := AlbumFixtures.Get("christmas2030")
+	c := NewChange("", ChangeManual)
+
+	c.Album(album.AlbumUID, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0y30"}, true)
+
+	for _, r := range c.Revisions {
+		assert.Equal(t, RevisionAlbum, r.RevisionKind)
+		assert.Equal(t, album.AlbumUID, r.Field)
+		assert.Equal(t, "false", r.OldValue)
+		assert.Equal(t, "true", r.NewValue)
+	}
+
+	assert.Contains(t, c.PhotoUIDs(), "pt9jtdre2lvl0y30")
+}
+
+func TestUndoChange(t *testing.T) {
+	p := NewPhoto(false)
+	p.TakenAt = time.Date(1996, 5, 1, 10, 0, 0, 0, time.UTC)
+	p.TakenAtLocal = p.TakenAt
+	p.PhotoTitle = "Before"
+	p.TitleSrc = SrcAuto
+	p.PhotoPath = "history"
+	p.PhotoName = "IMG_0001"
+
+	if err := p.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(&p)
+
+	results, err := SavePhotoBatch(Photos{p}, form.BatchEdit{
+		Title:  form.BatchText{Value: "After", Action: form.BatchSet},
+		Labels: form.BatchLabels{Names: []string{"History Label"}, Action: form.BatchAppend},
+	}, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Len(t, results, 1) || !assert.NotEmpty(t, results[0].ChangeUID) {
+		return
+	}
+
+	edited := Photo{ID: p.ID}
+
+	if err := edited.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "After", edited.PhotoTitle)
+	assert.Len(t, edited.Labels, 1)
+
+	t.Run("undo batch", func(t *testing.T) {
+		change, err := UndoChange(results[0].ChangeUID, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ChangeUndo, change.Source)
+		assert.False(t, change.Empty())
+
+		m := Photo{ID: p.ID}
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Before", m.PhotoTitle)
+		assert.Equal(t, SrcAuto, m.TitleSrc)
+		assert.Empty(t, m.Labels)
+
+		// Undo the undo.
+		if _, err := UndoChange(change.ChangeUID, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "After", m.PhotoTitle)
+		assert.Len(t, m.Labels, 1)
+	})
+
+	t.Run("revert photo", func(t *testing.T) {
+		var first Revision
+
+		if err := Db().Where("photo_uid = ?", p.PhotoUID).Order("id ASC").First(&first).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := RevertPhoto(p.PhotoUID, first.ID, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		m := Photo{ID: p.ID}
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Before", m.PhotoTitle)
+		assert.Empty(t, m.Labels)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		revisions := Revisions{
+			{PhotoUID: p.PhotoUID, RevisionKind: RevisionPhoto, Field: "PhotoTitle", OldValue: "Rollback"},
+			{PhotoUID: PhotoFixtures.Get("19800101_000002_D640C559").PhotoUID, RevisionKind: RevisionPhoto, Field: "Unknown"},
+		}
+
+		_, err := RevertRevisions(revisions, "")
+
+		assert.Error(t, err)
+
+		m := Photo{ID: p.ID}
+
+		if err := m.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Before", m.PhotoTitle)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := UndoChange("cxxxxxxxxxxxxxx1", "")
+		assert.Error(t, err)
+
+		_, err = RevertPhoto("pt9jtdre2lvl0xxx", 1, "")
+		assert.Error(t, err)
+	})
+}
@@ -9,6 +9,8 @@ const (
 	SortOrderSimilar   = "similar"
 	SortOrderRelevance = "relevance"
 	SortOrderEdited    = "edited"
+	SortOrderRating    = "rating"
+	SortOrderAlbum     = "album"
 
 	// Unknown values:
 	YearUnknown  = -1
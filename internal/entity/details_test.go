@@ -155,3 +155,67 @@ func TestDetails_Save(t *testing.T) {
 		assert.Error(t, details.Save())
 	})
 }
+
+func TestDetails_SetArtist(t *testing.T) {
+	t.Run("rule", func(t *testing.T) {
+		m := Details{}
+		m.SetArtist("Jane Doe", SrcRule)
+		assert.Equal(t, "Jane Doe", m.Artist)
+		assert.Equal(t, SrcRule, m.ArtistSrc)
+	})
+
+	t.Run("rule does not overwrite meta", func(t *testing.T) {
+		m := Details{Artist: "John Doe", ArtistSrc: SrcMeta}
+		m.SetArtist("Jane Doe", SrcRule)
+		assert.Equal(t, "John Doe", m.Artist)
+		assert.Equal(t, SrcMeta, m.ArtistSrc)
+	})
+
+	t.Run("rule does not overwrite yaml", func(t *testing.T) {
+		m := Details{Artist: "John Doe", ArtistSrc: SrcYaml}
+		m.SetArtist("Jane Doe", SrcRule)
+		assert.Equal(t, "John Doe", m.Artist)
+		assert.Equal(t, SrcYaml, m.ArtistSrc)
+	})
+
+	t.Run("yaml overwrites rule", func(t *testing.T) {
+		m := Details{Artist: "Jane Doe", ArtistSrc: SrcRule}
+		m.SetArtist("John Doe", SrcYaml)
+		assert.Equal(t, "John Doe", m.Artist)
+		assert.Equal(t, SrcYaml, m.ArtistSrc)
+	})
+
+	t.Run("rule does not overwrite manual", func(t *testing.T) {
+		m := Details{Artist: "John Doe", ArtistSrc: SrcManual}
+		m.SetArtist("Jane Doe", SrcRule)
+		assert.Equal(t, "John Doe", m.Artist)
+	})
+
+	t.Run("meta overwrites rule", func(t *testing.T) {
+		m := Details{Artist: "Jane Doe", ArtistSrc: SrcRule}
+		m.SetArtist("John Doe", SrcMeta)
+		assert.Equal(t, "John Doe", m.Artist)
+		assert.Equal(t, SrcMeta, m.ArtistSrc)
+	})
+}
+
+func TestDetails_SetLicense(t *testing.T) {
+	t.Run("rule", func(t *testing.T) {
+		m := Details{}
+		m.SetLicense("CC BY-NC 4.0", SrcRule)
+		assert.Equal(t, "CC BY-NC 4.0", m.License)
+		assert.Equal(t, SrcRule, m.LicenseSrc)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		m := Details{License: "CC0", LicenseSrc: SrcRule}
+		m.SetLicense("", SrcRule)
+		assert.Equal(t, "CC0", m.License)
+	})
+
+	t.Run("rule does not overwrite xmp", func(t *testing.T) {
+		m := Details{License: "All rights reserved", LicenseSrc: SrcXmp}
+		m.SetLicense("CC0", SrcRule)
+		assert.Equal(t, "All rights reserved", m.License)
+	})
+}
@@ -49,12 +49,16 @@ var Entities = Types{
 	"albums":          &Album{},
 	"photos_albums":   &PhotoAlbum{},
 	"labels":          &Label{},
+	"labels_synonyms": &LabelSynonym{},
 	"categories":      &Category{},
+	"labels_parents":  &LabelParent{},
 	"photos_labels":   &PhotoLabel{},
 	"keywords":        &Keyword{},
 	"photos_keywords": &PhotoKeyword{},
 	"passwords":       &Password{},
 	"links":           &Link{},
+	"revisions":       &Revision{},
+	"bursts":          &Burst{},
 }
 
 type RowCount struct {
@@ -136,6 +140,7 @@ func MigrateDb() {
 	Entities.WaitForMigration()
 
 	CreateDefaultFixtures()
+	CreateDefaultSynonyms()
 }
 
 // ResetTestFixtures drops database tables for all known entities and re-creates them with fixtures.
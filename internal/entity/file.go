@@ -58,6 +58,9 @@ type File struct {
 	FileLuminance   string        `gorm:"type:VARBINARY(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff        uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma      uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePhash       string        `gorm:"type:VARBINARY(16);index;" json:"Phash,omitempty" yaml:"Phash,omitempty"`
+	FileSharpness   uint32        `json:"Sharpness" yaml:"Sharpness,omitempty"`
+	FileEdits       string        `gorm:"type:VARBINARY(2048)" json:"Edits,omitempty" yaml:"Edits,omitempty"`
 	FileError       string        `gorm:"type:VARBINARY(512)" json:"Error" yaml:"Error,omitempty"`
 	ModTime         int64         `json:"ModTime" yaml:"-"`
 	CreatedAt       time.Time     `json:"CreatedAt" yaml:"-"`
@@ -79,6 +82,7 @@ type FileInfos struct {
 	FileLuminance   string
 	FileDiff        uint32
 	FileChroma      uint8
+	FilePhash       string
 }
 
 // FirstFileByHash gets a file in db from its hash
@@ -33,6 +33,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "8836BD496",
 		FileDiff:        968,
 		FileChroma:      25,
+		FilePhash:       "f0e4c2d7c6c2c0e0",
 		FileError:       "",
 		Share: []FileShare{
 			FileShareFixtures.Get("FileShare1", 0, 0, ""),
@@ -147,6 +148,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "1c3c7cfcf8f0e0c0",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -406,6 +408,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "1c3c7cfcf8f0e0c1",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -443,6 +446,7 @@ var FileFixtures = map[string]File{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePhash:       "1c3c7cfcf8f0e0c3",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -7,6 +7,7 @@ func CreateTestFixtures() {
 	}
 
 	CreateLabelFixtures()
+	CreateLabelSynonymFixtures()
 	CreateCameraFixtures()
 	CreateCountryFixtures()
 	CreatePhotoFixtures()
@@ -19,6 +20,7 @@ func CreateTestFixtures() {
 	CreateKeywordFixtures()
 	CreatePhotoKeywordFixtures()
 	CreateCategoryFixtures()
+	CreateLabelParentFixtures()
 	CreateCellFixtures()
 	CreatePlaceFixtures()
 	CreateFileShareFixtures()
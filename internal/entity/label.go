@@ -29,6 +29,7 @@ type Label struct {
 	LabelNotes       string     `gorm:"type:TEXT;" json:"Notes" yaml:"Notes,omitempty"`
 	LabelCategories  []*Label   `gorm:"many2many:categories;association_jointable_foreignkey:category_id" json:"-" yaml:"-"`
 	PhotoCount       int        `gorm:"default:1" json:"PhotoCount" yaml:"-"`
+	SynonymsVersion  int        `json:"-" yaml:"-"`
 	CreatedAt        time.Time  `json:"CreatedAt" yaml:"-"`
 	UpdatedAt        time.Time  `json:"UpdatedAt" yaml:"-"`
 	DeletedAt        *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
@@ -85,6 +86,7 @@ func (m *Label) Create() error {
 // Delete removes the label from the database.
 func (m *Label) Delete() error {
 	Db().Where("label_id = ? OR category_id = ?", m.ID, m.ID).Delete(&Category{})
+	Db().Where("label_id = ? OR parent_id = ?", m.ID, m.ID).Delete(&LabelParent{})
 	Db().Where("label_id = ?", m.ID).Delete(&PhotoLabel{})
 	return Db().Delete(m).Error
 }
@@ -115,6 +117,8 @@ func FirstOrCreateLabel(m *Label) *Label {
 	if err := UnscopedDb().Where("label_slug = ? OR custom_slug = ?", m.LabelSlug, m.CustomSlug).First(&result).Error; err == nil {
 		return &result
 	} else if createErr := m.Create(); createErr == nil {
+		m.AddDefaultSynonyms()
+
 		if m.LabelPriority >= 0 {
 			event.EntitiesCreated("labels", []*Label{m})
 
@@ -215,6 +215,57 @@ var LabelFixtures = LabelMap{
 		DeletedAt:        nil,
 		New:              false,
 	},
+	"places": {
+		ID:               1000011,
+		LabelUID:         "lt9k3pw1wowuy313",
+		LabelSlug:        "places",
+		CustomSlug:       "places",
+		LabelName:        "Places",
+		LabelPriority:    0,
+		LabelFavorite:    false,
+		LabelDescription: "",
+		LabelNotes:       "",
+		PhotoCount:       1,
+		LabelCategories:  []*Label{},
+		CreatedAt:        Timestamp(),
+		UpdatedAt:        Timestamp(),
+		DeletedAt:        nil,
+		New:              false,
+	},
+	"europe": {
+		ID:               1000012,
+		LabelUID:         "lt9k3pw1wowuy314",
+		LabelSlug:        "europe",
+		CustomSlug:       "europe",
+		LabelName:        "Europe",
+		LabelPriority:    0,
+		LabelFavorite:    false,
+		LabelDescription: "",
+		LabelNotes:       "",
+		PhotoCount:       1,
+		LabelCategories:  []*Label{},
+		CreatedAt:        Timestamp(),
+		UpdatedAt:        Timestamp(),
+		DeletedAt:        nil,
+		New:              false,
+	},
+	"berlin": {
+		ID:               1000013,
+		LabelUID:         "lt9k3pw1wowuy315",
+		LabelSlug:        "berlin",
+		CustomSlug:       "berlin",
+		LabelName:        "Berlin",
+		LabelPriority:    0,
+		LabelFavorite:    false,
+		LabelDescription: "",
+		LabelNotes:       "",
+		PhotoCount:       1,
+		LabelCategories:  []*Label{},
+		CreatedAt:        Timestamp(),
+		UpdatedAt:        Timestamp(),
+		DeletedAt:        nil,
+		New:              false,
+	},
 }
 
 // CreateLabelFixtures inserts known entities into the database for testing.
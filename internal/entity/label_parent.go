@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+)
+
+// LabelParent represents a relation between a label and one of its ancestors, e.g. from hierarchical keywords.
+type LabelParent struct {
+	LabelID   uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	ParentID  uint      `gorm:"primary_key;auto_increment:false;index" json:"-" yaml:"-"`
+	Depth     int       `json:"Depth" yaml:"Depth"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns LabelParent table identifier "labels_parents"
+func (LabelParent) TableName() string {
+	return "labels_parents"
+}
+
+// NewLabelParent returns a new label parent relation, depth 1 stands for a direct parent.
+func NewLabelParent(labelID, parentID uint, depth int) *LabelParent {
+	result := &LabelParent{
+		LabelID:  labelID,
+		ParentID: parentID,
+		Depth:    depth,
+	}
+
+	return result
+}
+
+// Create inserts the relation to the database.
+func (m *LabelParent) Create() error {
+	return Db().Create(m).Error
+}
+
+// FirstOrCreateLabelParent returns the existing row, inserts a new row or nil in case of errors.
+func FirstOrCreateLabelParent(m *LabelParent) *LabelParent {
+	if m.LabelID == 0 || m.ParentID == 0 || m.LabelID == m.ParentID {
+		return nil
+	}
+
+	result := LabelParent{}
+
+	if err := Db().Where("label_id = ? AND parent_id = ?", m.LabelID, m.ParentID).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("label_id = ? AND parent_id = ?", m.LabelID, m.ParentID).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("label: %s (find or create parent %d)", createErr, m.ParentID)
+	}
+
+	return nil
+}
+
+// FirstOrCreateLabelPath returns the last label of a keyword path like ["Places", "Europe", "Berlin"]
+// and relates it to all of its ancestors, or nil in case of errors.
+func FirstOrCreateLabelPath(path []string) *Label {
+	var ancestors Labels
+
+	for _, name := range path {
+		label := FirstOrCreateLabel(NewLabel(name, 0))
+
+		if label == nil {
+			return nil
+		} else if label.Deleted() {
+			log.Debugf("label: skipping deleted label %s in path", label.LabelSlug)
+			return nil
+		}
+
+		for i, parent := range ancestors {
+			FirstOrCreateLabelParent(NewLabelParent(label.ID, parent.ID, len(ancestors)-i))
+		}
+
+		ancestors = append(ancestors, *label)
+	}
+
+	if len(ancestors) == 0 {
+		return nil
+	}
+
+	return &ancestors[len(ancestors)-1]
+}
+
+// Parents returns the direct parents of the label.
+func (m *Label) Parents() (result Labels) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("id IN (SELECT parent_id FROM labels_parents WHERE label_id = ? AND depth = 1)", m.ID).
+		Order("custom_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find parents)", err)
+	}
+
+	return result
+}
+
+// Children returns the direct children of the label.
+func (m *Label) Children() (result Labels) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("id IN (SELECT label_id FROM labels_parents WHERE parent_id = ? AND depth = 1)", m.ID).
+		Order("custom_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find children)", err)
+	}
+
+	return result
+}
@@ -0,0 +1,28 @@
+package entity
+
+type LabelParentMap map[string]LabelParent
+
+var LabelParentFixtures = LabelParentMap{
+	"europe_places": {
+		LabelID:  LabelFixtures.Pointer("europe").ID,
+		ParentID: LabelFixtures.Pointer("places").ID,
+		Depth:    1,
+	},
+	"berlin_europe": {
+		LabelID:  LabelFixtures.Pointer("berlin").ID,
+		ParentID: LabelFixtures.Pointer("europe").ID,
+		Depth:    1,
+	},
+	"berlin_places": {
+		LabelID:  LabelFixtures.Pointer("berlin").ID,
+		ParentID: LabelFixtures.Pointer("places").ID,
+		Depth:    2,
+	},
+}
+
+// CreateLabelParentFixtures inserts known entities into the database for testing.
+func CreateLabelParentFixtures() {
+	for _, entity := range LabelParentFixtures {
+		Db().Create(&entity)
+	}
+}
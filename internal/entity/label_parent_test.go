@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelParent_TableName(t *testing.T) {
+	assert.Equal(t, "labels_parents", LabelParent{}.TableName())
+}
+
+func TestNewLabelParent(t *testing.T) {
+	p := NewLabelParent(2, 1, 1)
+	assert.Equal(t, uint(2), p.LabelID)
+	assert.Equal(t, uint(1), p.ParentID)
+	assert.Equal(t, 1, p.Depth)
+}
+
+func TestFirstOrCreateLabelParent(t *testing.T) {
+	t.Run("existing", func(t *testing.T) {
+		p := FirstOrCreateLabelParent(NewLabelParent(LabelFixtures.Pointer("berlin").ID, LabelFixtures.Pointer("places").ID, 2))
+
+		if p == nil {
+			t.Fatal("relation must not be nil")
+		}
+
+		assert.Equal(t, 2, p.Depth)
+	})
+	t.Run("new", func(t *testing.T) {
+		p := FirstOrCreateLabelParent(NewLabelParent(LabelFixtures.Pointer("cow").ID, LabelFixtures.Pointer("landscape").ID, 1))
+
+		if p == nil {
+			t.Fatal("relation must not be nil")
+		}
+
+		assert.Equal(t, LabelFixtures.Pointer("cow").ID, p.LabelID)
+	})
+	t.Run("self", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateLabelParent(NewLabelParent(1, 1, 1)))
+	})
+}
+
+func TestFirstOrCreateLabelPath(t *testing.T) {
+	t.Run("new", func(t *testing.T) {
+		label := FirstOrCreateLabelPath([]string{"Animals", "Birds", "Eagle"})
+
+		if label == nil {
+			t.Fatal("label must not be nil")
+		}
+
+		assert.Equal(t, "eagle", label.LabelSlug)
+
+		if parents := label.Parents(); assert.Len(t, parents, 1) {
+			assert.Equal(t, "birds", parents[0].LabelSlug)
+		}
+
+		var ancestors []LabelParent
+
+		if err := Db().Where("label_id = ?", label.ID).Order("depth").Find(&ancestors).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, ancestors, 2)
+	})
+	t.Run("existing", func(t *testing.T) {
+		label := FirstOrCreateLabelPath([]string{"Places", "Europe", "Berlin"})
+
+		if label == nil {
+			t.Fatal("label must not be nil")
+		}
+
+		assert.Equal(t, LabelFixtures.Pointer("berlin").ID, label.ID)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateLabelPath([]string{}))
+	})
+}
+
+func TestLabel_Parents(t *testing.T) {
+	t.Run("berlin", func(t *testing.T) {
+		label := LabelFixtures.Get("berlin")
+
+		if parents := label.Parents(); assert.Len(t, parents, 1) {
+			assert.Equal(t, "europe", parents[0].LabelSlug)
+		}
+	})
+	t.Run("no id", func(t *testing.T) {
+		label := NewLabel("Unsaved", 0)
+		assert.Empty(t, label.Parents())
+	})
+}
+
+func TestLabel_Children(t *testing.T) {
+	t.Run("places", func(t *testing.T) {
+		label := LabelFixtures.Get("places")
+
+		if children := label.Children(); assert.Len(t, children, 1) {
+			assert.Equal(t, "europe", children[0].LabelSlug)
+		}
+	})
+	t.Run("no id", func(t *testing.T) {
+		label := NewLabel("Unsaved", 0)
+		assert.Empty(t, label.Children())
+	})
+}
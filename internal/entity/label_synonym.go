@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LabelSynonym represents an alternative name or translation of a label that is used for search.
+type LabelSynonym struct {
+	LabelID       uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	SynonymSlug   string    `gorm:"type:VARBINARY(255);primary_key;auto_increment:false;index;" json:"Slug" yaml:"-"`
+	SynonymName   string    `gorm:"type:VARCHAR(255);" json:"Name" yaml:"Name"`
+	SynonymLocale string    `gorm:"type:VARBINARY(8);" json:"Locale" yaml:"Locale,omitempty"`
+	CreatedAt     time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// LabelSynonyms represents a list of label synonyms.
+type LabelSynonyms []LabelSynonym
+
+// TableName returns LabelSynonym table identifier "labels_synonyms"
+func (LabelSynonym) TableName() string {
+	return "labels_synonyms"
+}
+
+// NewLabelSynonym returns a new label synonym.
+func NewLabelSynonym(labelID uint, name, locale string) *LabelSynonym {
+	name = txt.Clip(name, txt.ClipDefault)
+
+	result := &LabelSynonym{
+		LabelID:       labelID,
+		SynonymSlug:   slug.Make(txt.Clip(name, txt.ClipSlug)),
+		SynonymName:   name,
+		SynonymLocale: txt.Clip(locale, 8),
+	}
+
+	return result
+}
+
+// Create inserts the synonym to the database.
+func (m *LabelSynonym) Create() error {
+	return Db().Create(m).Error
+}
+
+// FirstOrCreateLabelSynonym returns the existing row, inserts a new row or nil in case of errors.
+func FirstOrCreateLabelSynonym(m *LabelSynonym) *LabelSynonym {
+	if m.SynonymSlug == "" {
+		return nil
+	}
+
+	result := LabelSynonym{}
+
+	if err := Db().Where("label_id = ? AND synonym_slug = ?", m.LabelID, m.SynonymSlug).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("label_id = ? AND synonym_slug = ?", m.LabelID, m.SynonymSlug).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("label: %s (find or create synonym %s)", createErr, m.SynonymSlug)
+	}
+
+	return nil
+}
+
+// Synonyms returns all synonyms of the label.
+func (m *Label) Synonyms() (result LabelSynonyms) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("label_id = ?", m.ID).Order("synonym_locale, synonym_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find synonyms)", err)
+	}
+
+	return result
+}
+
+// SetSynonyms replaces all synonyms of the label.
+func (m *Label) SetSynonyms(synonyms LabelSynonyms) error {
+	if m.ID == 0 {
+		return nil
+	}
+
+	tx := UnscopedDb().Begin()
+
+	if err := tx.Where("label_id = ?", m.ID).Delete(&LabelSynonym{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	added := make(map[string]bool, len(synonyms))
+
+	for _, s := range synonyms {
+		synonym := NewLabelSynonym(m.ID, s.SynonymName, s.SynonymLocale)
+
+		// Skip empty names, duplicates and the label itself.
+		if synonym.SynonymSlug == "" || added[synonym.SynonymSlug] || synonym.SynonymSlug == m.LabelSlug || synonym.SynonymSlug == m.CustomSlug {
+			continue
+		}
+
+		if err := tx.Create(synonym).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		added[synonym.SynonymSlug] = true
+	}
+
+	return tx.Commit().Error
+}
+
+// AddDefaultSynonyms adds the translations and alternative names shipped with the app.
+func (m *Label) AddDefaultSynonyms() {
+	if m.ID == 0 || m.SynonymsVersion >= classify.LabelSynonymsVersion {
+		return
+	}
+
+	if synonyms, ok := classify.LabelSynonyms[m.LabelSlug]; ok {
+		for locale, names := range synonyms {
+			for _, name := range names {
+				FirstOrCreateLabelSynonym(NewLabelSynonym(m.ID, name, string(locale)))
+			}
+		}
+	}
+
+	if err := m.Update("SynonymsVersion", classify.LabelSynonymsVersion); err != nil {
+		log.Errorf("label: %s (update synonyms version)", err)
+	} else {
+		m.SynonymsVersion = classify.LabelSynonymsVersion
+	}
+}
+
+// CreateDefaultSynonyms adds default synonyms to existing labels that don't have the current version yet,
+// so that synonyms which were removed by users are only added again when the defaults have changed.
+func CreateDefaultSynonyms() {
+	slugs := make([]string, 0, len(classify.LabelSynonyms))
+
+	for labelSlug := range classify.LabelSynonyms {
+		slugs = append(slugs, labelSlug)
+	}
+
+	var labels Labels
+
+	if err := Db().Where("label_slug IN (?) AND synonyms_version < ?", slugs, classify.LabelSynonymsVersion).
+		Find(&labels).Error; err != nil {
+		log.Errorf("label: %s (create default synonyms)", err)
+		return
+	}
+
+	for _, l := range labels {
+		l.AddDefaultSynonyms()
+	}
+}
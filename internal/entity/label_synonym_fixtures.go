@@ -0,0 +1,31 @@
+package entity
+
+type LabelSynonymMap map[string]LabelSynonym
+
+var LabelSynonymFixtures = LabelSynonymMap{
+	"landschaft": {
+		LabelID:       LabelFixtures.Pointer("landscape").ID,
+		SynonymSlug:   "landschaft",
+		SynonymName:   "Landschaft",
+		SynonymLocale: "de",
+	},
+	"paisaje": {
+		LabelID:       LabelFixtures.Pointer("landscape").ID,
+		SynonymSlug:   "paisaje",
+		SynonymName:   "paisaje",
+		SynonymLocale: "es",
+	},
+	"blume": {
+		LabelID:       LabelFixtures.Pointer("flower").ID,
+		SynonymSlug:   "blume",
+		SynonymName:   "Blume",
+		SynonymLocale: "de",
+	},
+}
+
+// CreateLabelSynonymFixtures inserts known entities into the database for testing.
+func CreateLabelSynonymFixtures() {
+	for _, entity := range LabelSynonymFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabelSynonym(t *testing.T) {
+	t.Run("german", func(t *testing.T) {
+		s := NewLabelSynonym(1, "Kätzchen", "de")
+		assert.Equal(t, uint(1), s.LabelID)
+		assert.Equal(t, "katzchen", s.SynonymSlug)
+		assert.Equal(t, "Kätzchen", s.SynonymName)
+		assert.Equal(t, "de", s.SynonymLocale)
+	})
+	t.Run("empty", func(t *testing.T) {
+		s := NewLabelSynonym(1, "", "")
+		assert.Equal(t, "", s.SynonymSlug)
+		assert.Nil(t, FirstOrCreateLabelSynonym(s))
+	})
+}
+
+func TestFirstOrCreateLabelSynonym(t *testing.T) {
+	label := LabelFixtures.Get("flower")
+
+	t.Run("existing", func(t *testing.T) {
+		s := FirstOrCreateLabelSynonym(NewLabelSynonym(label.ID, "Blume", "de"))
+
+		if s == nil {
+			t.Fatal("synonym must not be nil")
+		}
+
+		assert.Equal(t, "blume", s.SynonymSlug)
+	})
+	t.Run("new", func(t *testing.T) {
+		s := FirstOrCreateLabelSynonym(NewLabelSynonym(label.ID, "Fleur", "fr"))
+
+		if s == nil {
+			t.Fatal("synonym must not be nil")
+		}
+
+		assert.Equal(t, "fleur", s.SynonymSlug)
+	})
+}
+
+func TestLabel_Synonyms(t *testing.T) {
+	label := LabelFixtures.Get("landscape")
+
+	synonyms := label.Synonyms()
+
+	if assert.Len(t, synonyms, 2) {
+		assert.Equal(t, "landschaft", synonyms[0].SynonymSlug)
+		assert.Equal(t, "paisaje", synonyms[1].SynonymSlug)
+	}
+}
+
+func TestLabel_SetSynonyms(t *testing.T) {
+	label := NewLabel("Synonym Test", 0)
+
+	if err := label.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := label.SetSynonyms(LabelSynonyms{
+		{SynonymName: "Prueba", SynonymLocale: "es"},
+		{SynonymName: "Synonym Test"},
+		{SynonymName: ""},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if synonyms := label.Synonyms(); assert.Len(t, synonyms, 1) {
+		assert.Equal(t, "prueba", synonyms[0].SynonymSlug)
+	}
+
+	if err := label.SetSynonyms(LabelSynonyms{}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, label.Synonyms())
+}
+
+func TestLabel_AddDefaultSynonyms(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Dog", 0))
+
+	if label == nil {
+		t.Fatal("label must not be nil")
+	}
+
+	var slugs []string
+
+	for _, s := range label.Synonyms() {
+		slugs = append(slugs, s.SynonymSlug)
+	}
+
+	assert.Contains(t, slugs, "hund")
+	assert.Contains(t, slugs, "puppy")
+	assert.Contains(t, slugs, "sobaka")
+}
+
+func TestCreateDefaultSynonyms(t *testing.T) {
+	label := FirstOrCreateLabel(NewLabel("Cat", 0))
+
+	if label == nil {
+		t.Fatal("label must not be nil")
+	}
+
+	assert.NotEmpty(t, label.Synonyms())
+
+	if err := label.SetSynonyms(LabelSynonyms{}); err != nil {
+		t.Fatal(err)
+	}
+
+	CreateDefaultSynonyms()
+
+	// Synonyms removed by users must not be added again.
+	assert.Empty(t, label.Synonyms())
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -45,7 +46,7 @@ type Photo struct {
 	ID               uint         `gorm:"primary_key" yaml:"-"`
 	UUID             string       `gorm:"type:VARBINARY(42);index;" json:"DocumentID,omitempty" yaml:"DocumentID,omitempty"`
 	TakenAt          time.Time    `gorm:"type:datetime;index:idx_photos_taken_uid;" json:"TakenAt" yaml:"TakenAt"`
-	TakenAtLocal     time.Time    `gorm:"type:datetime;" yaml:"-"`
+	TakenAtLocal     time.Time    `gorm:"type:datetime;" yaml:"TakenAtLocal,omitempty"`
 	TakenSrc         string       `gorm:"type:VARBINARY(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
 	PhotoUID         string       `gorm:"type:VARBINARY(42);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
 	PhotoType        string       `gorm:"type:VARBINARY(8);default:'image';" json:"Type" yaml:"Type"`
@@ -58,11 +59,16 @@ type Photo struct {
 	PhotoName        string       `gorm:"type:VARBINARY(255);index:idx_photos_path_name;" json:"Name" yaml:"-"`
 	OriginalName     string       `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
 	PhotoStack       int8         `json:"Stack" yaml:"Stack,omitempty"`
+	BurstUID         string       `gorm:"type:VARBINARY(42);index;" json:"BurstUID" yaml:"-"`
 	PhotoFavorite    bool         `json:"Favorite" yaml:"Favorite,omitempty"`
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool         `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool         `json:"Panorama" yaml:"Panorama,omitempty"`
-	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"-"`
+	PhotoRating      int          `gorm:"type:SMALLINT;index;" json:"Rating" yaml:"Rating,omitempty"`
+	RatingSrc        string       `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
+	PhotoColorLabel  string       `gorm:"type:VARBINARY(16);index;" json:"ColorLabel" yaml:"ColorLabel,omitempty"`
+	ColorLabelSrc    string       `gorm:"type:VARBINARY(8);" json:"ColorLabelSrc" yaml:"ColorLabelSrc,omitempty"`
+	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
 	PlaceID          string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"PlaceID" yaml:"-"`
 	PlaceSrc         string       `gorm:"type:VARBINARY(8);" json:"PlaceSrc" yaml:"PlaceSrc,omitempty"`
 	CellID           string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"CellID" yaml:"-"`
@@ -93,7 +99,7 @@ type Photo struct {
 	Keywords         []Keyword    `json:"-" yaml:"-"`
 	Albums           []Album      `json:"-" yaml:"-"`
 	Files            []File       `yaml:"-"`
-	Labels           []PhotoLabel `yaml:"-"`
+	Labels           []PhotoLabel `yaml:"Labels,omitempty"`
 	CreatedAt        time.Time    `yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time    `yaml:"UpdatedAt,omitempty"`
 	EditedAt         *time.Time   `yaml:"EditedAt,omitempty"`
@@ -140,6 +146,10 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 
 	model.UpdateDateFields()
 
+	// Reset invalid ratings and color labels.
+	model.PhotoRating = meta.SanitizeRating(model.PhotoRating)
+	model.PhotoColorLabel = meta.SanitizeColorLabel(model.PhotoColorLabel)
+
 	details := model.GetDetails()
 
 	if form.Details.PhotoID == model.ID {
@@ -454,6 +464,15 @@ func (m *Photo) PreloadFiles() {
 	logError(q.Scan(&m.Files))
 }
 
+// LoadLabels loads the photo labels including label details, e.g. before creating a YAML backup.
+func (m *Photo) LoadLabels() error {
+	if !m.HasID() {
+		return nil
+	}
+
+	return Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels).Error
+}
+
 /* func (m *Photo) PreloadLabels() {
 	q := Db().NewScope(nil).DB().
 		Table("labels").
@@ -634,6 +653,20 @@ func (m *Photo) HasDescription() bool {
 	return m.PhotoDescription != ""
 }
 
+// Clone returns a copy of the photo including details and labels, e.g. to compare it with a changed version.
+func (m *Photo) Clone() Photo {
+	result := *m
+
+	if m.Details != nil {
+		details := *m.Details
+		result.Details = &details
+	}
+
+	result.Labels = append([]PhotoLabel{}, m.Labels...)
+
+	return result
+}
+
 // GetDetails returns the photo description details.
 func (m *Photo) GetDetails() *Details {
 	if m.Details != nil {
@@ -815,6 +848,40 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 	Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels)
 }
 
+// AddLabelHierarchy adds labels from hierarchical keywords like "Places|Europe|Berlin" to the photo.
+func (m *Photo) AddLabelHierarchy(hierarchy []string) {
+	if len(hierarchy) == 0 {
+		return
+	}
+
+	for _, h := range hierarchy {
+		labelEntity := FirstOrCreateLabelPath(strings.Split(h, meta.HierarchySep))
+
+		if labelEntity == nil {
+			log.Debugf("index: skipping label hierarchy %s (%s)", txt.Quote(h), m)
+			continue
+		}
+
+		photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, labelEntity.ID, 10, classify.SrcMeta))
+
+		if photoLabel == nil {
+			log.Errorf("index: photo-label %d should not be nil - bug? (%s)", labelEntity.ID, m)
+			continue
+		}
+
+		if photoLabel.Uncertainty > 10 && photoLabel.Uncertainty < 100 {
+			if err := photoLabel.Updates(map[string]interface{}{
+				"Uncertainty": 10,
+				"LabelSrc":    classify.SrcMeta,
+			}); err != nil {
+				log.Errorf("index: %s", err)
+			}
+		}
+	}
+
+	Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels)
+}
+
 // SetTitle changes the photo title and clips it to 300 characters.
 func (m *Photo) SetTitle(title, source string) {
 	newTitle := txt.Clip(title, txt.ClipDefault)
@@ -847,6 +914,38 @@ func (m *Photo) SetDescription(desc, source string) {
 	m.DescriptionSrc = source
 }
 
+// SetRating changes the star rating if not unrated and from the same or a higher priority source.
+func (m *Photo) SetRating(rating int, source string) {
+	rating = meta.SanitizeRating(rating)
+
+	if rating == 0 {
+		return
+	}
+
+	if (SrcPriority[source] < SrcPriority[m.RatingSrc]) && m.PhotoRating != 0 {
+		return
+	}
+
+	m.PhotoRating = rating
+	m.RatingSrc = source
+}
+
+// SetColorLabel changes the color label if supported and from the same or a higher priority source.
+func (m *Photo) SetColorLabel(label, source string) {
+	label = meta.SanitizeColorLabel(label)
+
+	if label == "" {
+		return
+	}
+
+	if (SrcPriority[source] < SrcPriority[m.ColorLabelSrc]) && m.PhotoColorLabel != "" {
+		return
+	}
+
+	m.PhotoColorLabel = label
+	m.ColorLabelSrc = source
+}
+
 // SetTakenAt changes the photo date if not empty and from the same source.
 func (m *Photo) SetTakenAt(taken, local time.Time, zone, source string) {
 	if taken.IsZero() || taken.Year() < 1000 || taken.Year() > txt.YearMax {
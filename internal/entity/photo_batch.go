@@ -0,0 +1,375 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PhotoBatchResult reports the changes a batch edit applied to a single photo.
+type PhotoBatchResult struct {
+	PhotoUID   string   `json:"UID"`
+	PhotoTitle string   `json:"Title"`
+	Changed    []string `json:"Changed"`
+	ChangeUID  string   `json:"ChangeUID,omitempty"`
+	Error      string   `json:"Error,omitempty"`
+}
+
+// PhotoBatchResults represents a list of batch edit results.
+type PhotoBatchResults []PhotoBatchResult
+
+// photoBatchItem holds a photo with its pending changes until they are committed.
+type photoBatchItem struct {
+	old       Photo
+	photo     Photo
+	changed   []string
+	locLabels classify.Labels
+}
+
+// SavePhotoBatch applies a batch edit form to all given photos in a single database transaction,
+// so that either all or none of the changes are saved. All revisions are recorded as one change.
+//
+// Shared reference data like labels, locations, places and countries is looked up or created
+// before the transaction starts and remains in the database if it is rolled back.
+func SavePhotoBatch(photos Photos, f form.BatchEdit, userUID string) (results PhotoBatchResults, err error) {
+	if f.NoChanges() {
+		return results, fmt.Errorf("photo: batch edit contains no changes")
+	}
+
+	if f.HasLatLng() && (*f.Lat < -90 || *f.Lat > 90 || *f.Lng < -180 || *f.Lng > 180) {
+		return results, fmt.Errorf("photo: invalid coordinates %f, %f", *f.Lat, *f.Lng)
+	}
+
+	var place *Place
+
+	if f.PlaceID != "" {
+		if place = FindPlace(f.PlaceID, ""); place == nil {
+			return results, fmt.Errorf("photo: place %s not found", txt.Quote(f.PlaceID))
+		}
+	}
+
+	if f.CameraID > 0 {
+		if err := Db().First(&Camera{}, "id = ?", f.CameraID).Error; err != nil {
+			return results, fmt.Errorf("photo: camera %d not found", f.CameraID)
+		}
+	}
+
+	if f.LensID > 0 {
+		if err := Db().First(&Lens{}, "id = ?", f.LensID).Error; err != nil {
+			return results, fmt.Errorf("photo: lens %d not found", f.LensID)
+		}
+	}
+
+	var labels []*Label
+
+	if f.Labels.Changed() {
+		for _, name := range txt.UniqueWords(f.Labels.Names) {
+			label := FirstOrCreateLabel(NewLabel(name, 0))
+
+			if label == nil {
+				return results, fmt.Errorf("photo: failed creating label %s", txt.Quote(name))
+			} else if err := label.Restore(); err != nil {
+				return results, err
+			}
+
+			labels = append(labels, label)
+		}
+	}
+
+	// Apply changes in memory first.
+	items := make([]photoBatchItem, 0, len(photos))
+
+	for _, p := range photos {
+		if err := p.loadBatchDetails(); err != nil {
+			return results, err
+		}
+
+		if f.Labels.Changed() {
+			if err := Db().Where("photo_id = ?", p.ID).Find(&p.Labels).Error; err != nil {
+				return results, err
+			}
+		}
+
+		item := photoBatchItem{old: p.Clone(), photo: p.Clone()}
+		item.photo.Labels = nil
+		item.changed, item.locLabels = item.photo.applyBatch(f, place)
+
+		if len(labels) > 0 || f.Labels.Action == form.BatchSet {
+			item.changed = append(item.changed, "Labels")
+		}
+
+		items = append(items, item)
+	}
+
+	// Write all changes in a single transaction.
+	change := NewChange(userUID, ChangeBatch)
+	failed := -1
+	tx := UnscopedDb().Begin()
+
+	for i, item := range items {
+		m := item.photo
+
+		if len(item.changed) == 0 {
+			continue
+		}
+
+		if err = m.saveBatch(tx, f.Labels, labels); err != nil {
+			failed = i
+			break
+		}
+
+		change.Photo(&item.old, &m)
+	}
+
+	if err == nil {
+		err = change.create(tx)
+	}
+
+	if err != nil {
+		tx.Rollback()
+
+		// Report the error for the photo that failed, or for all photos if the change could not be saved.
+		for i, item := range items {
+			r := PhotoBatchResult{PhotoUID: item.photo.PhotoUID, PhotoTitle: item.photo.PhotoTitle}
+
+			if failed < 0 || failed == i {
+				r.Error = err.Error()
+			}
+
+			results = append(results, r)
+		}
+
+		return results, err
+	} else if err = tx.Commit().Error; err != nil {
+		return results, err
+	}
+
+	// Update search index after successful commit.
+	for _, item := range items {
+		m := item.photo
+
+		results = append(results, PhotoBatchResult{PhotoUID: m.PhotoUID, PhotoTitle: m.PhotoTitle, Changed: item.changed, ChangeUID: change.ChangeUID})
+
+		if len(item.changed) == 0 {
+			continue
+		}
+
+		if len(item.locLabels) > 0 {
+			m.AddLabels(item.locLabels)
+		}
+
+		if err := m.SyncKeywordLabels(); err != nil {
+			log.Errorf("photo: %s", err)
+		}
+
+		if err := m.IndexKeywords(); err != nil {
+			log.Errorf("photo: %s", err)
+		}
+	}
+
+	if f.Private != nil {
+		if err := UpdatePhotoBursts(photos.UIDs()); err != nil {
+			log.Errorf("photo: %s", err)
+		}
+	}
+
+	if err := UpdatePhotoCounts(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	return results, nil
+}
+
+// applyBatch updates the photo and its details with the batch edit values and returns the changed fields.
+func (m *Photo) applyBatch(f form.BatchEdit, place *Place) (changed []string, labels classify.Labels) {
+	details := m.GetDetails()
+
+	if f.Title.Changed() {
+		if title := f.Title.Apply(m.PhotoTitle, " "); title != m.PhotoTitle {
+			m.PhotoTitle = title
+			m.TitleSrc = SrcManual
+			changed = append(changed, "Title")
+		}
+	}
+
+	if f.Description.Changed() {
+		if desc := f.Description.Apply(m.PhotoDescription, "\n"); desc != m.PhotoDescription {
+			m.PhotoDescription = desc
+			m.DescriptionSrc = SrcManual
+			changed = append(changed, "Description")
+		}
+	}
+
+	if f.Keywords.Changed() {
+		if keywords := strings.Join(txt.UniqueWords(txt.Words(f.Keywords.Apply(details.Keywords, ", "))), ", "); keywords != details.Keywords {
+			details.Keywords = keywords
+			details.KeywordsSrc = SrcManual
+			changed = append(changed, "Keywords")
+		}
+	}
+
+	if f.Artist.Changed() {
+		if artist := f.Artist.Apply(details.Artist, ", "); artist != details.Artist {
+			details.Artist = artist
+			details.ArtistSrc = SrcManual
+			changed = append(changed, "Artist")
+		}
+	}
+
+	if f.Copyright.Changed() {
+		if copyright := f.Copyright.Apply(details.Copyright, ", "); copyright != details.Copyright {
+			details.Copyright = copyright
+			details.CopyrightSrc = SrcManual
+			changed = append(changed, "Copyright")
+		}
+	}
+
+	if f.License.Changed() {
+		if license := f.License.Apply(details.License, ", "); license != details.License {
+			details.License = license
+			details.LicenseSrc = SrcManual
+			changed = append(changed, "License")
+		}
+	}
+
+	if f.HasLatLng() && (*f.Lat != m.PhotoLat || *f.Lng != m.PhotoLng) {
+		m.PhotoLat = *f.Lat
+		m.PhotoLng = *f.Lng
+		m.PlaceSrc = SrcManual
+
+		var keywords []string
+
+		keywords, labels = m.UpdateLocation()
+
+		w := txt.UniqueWords(txt.Words(details.Keywords))
+		w = append(w, keywords...)
+		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+
+		changed = append(changed, "Location")
+	} else if place != nil && place.ID != m.PlaceID {
+		m.PhotoLat = 0
+		m.PhotoLng = 0
+		m.Cell = &UnknownLocation
+		m.CellID = UnknownLocation.ID
+		m.Place = place
+		m.PlaceID = place.ID
+		m.PhotoCountry = place.CountryCode()
+		m.PlaceSrc = SrcManual
+
+		changed = append(changed, "Location")
+	}
+
+	if f.CameraID > 0 && f.CameraID != m.CameraID {
+		m.CameraID = f.CameraID
+		m.Camera = nil
+		m.CameraSrc = SrcManual
+		changed = append(changed, "Camera")
+	}
+
+	if f.LensID > 0 && f.LensID != m.LensID {
+		m.LensID = f.LensID
+		m.Lens = nil
+		changed = append(changed, "Lens")
+	}
+
+	if f.Favorite != nil && *f.Favorite != m.PhotoFavorite {
+		m.PhotoFavorite = *f.Favorite
+		changed = append(changed, "Favorite")
+	}
+
+	if f.Private != nil && *f.Private != m.PhotoPrivate {
+		m.PhotoPrivate = *f.Private
+		changed = append(changed, "Private")
+	}
+
+	if len(changed) > 0 {
+		edited := Timestamp()
+		m.EditedAt = &edited
+		m.PhotoQuality = m.QualityScore()
+	}
+
+	return changed, labels
+}
+
+// loadBatchDetails loads the photo details without creating missing rows, so that they are
+// only inserted by the batch edit transaction.
+func (m *Photo) loadBatchDetails() error {
+	if m.Details != nil {
+		m.Details.PhotoID = m.ID
+		return nil
+	}
+
+	details := Details{}
+
+	if err := Db().Where("photo_id = ?", m.ID).First(&details).Error; err == nil {
+		m.Details = &details
+	} else if gorm.IsRecordNotFoundError(err) {
+		m.Details = &Details{PhotoID: m.ID}
+	} else {
+		return err
+	}
+
+	return nil
+}
+
+// saveBatch saves the photo, its details and labels as part of the batch edit transaction.
+func (m *Photo) saveBatch(tx *gorm.DB, f form.BatchLabels, labels []*Label) error {
+	if err := tx.Save(m).Error; err != nil {
+		return err
+	} else if err := tx.Save(m.Details).Error; err != nil {
+		return err
+	} else if !f.Changed() {
+		return nil
+	} else if err := m.saveBatchLabels(tx, labels, f.Action); err != nil {
+		return err
+	}
+
+	return tx.Where("photo_id = ?", m.ID).Find(&m.Labels).Error
+}
+
+// saveBatchLabels adds the given labels to the photo and removes all others if the action is "set".
+func (m *Photo) saveBatchLabels(tx *gorm.DB, labels []*Label, action string) error {
+	var labelIds []uint
+
+	for _, label := range labels {
+		labelIds = append(labelIds, label.ID)
+
+		if err := tx.Where(PhotoLabel{PhotoID: m.ID, LabelID: label.ID}).
+			Assign(map[string]interface{}{"label_src": SrcManual, "uncertainty": 0}).
+			FirstOrCreate(&PhotoLabel{}).Error; err != nil {
+			return err
+		}
+	}
+
+	if action != form.BatchSet {
+		return nil
+	}
+
+	var remove PhotoLabels
+
+	q := tx.Where("photo_id = ? AND uncertainty < 100", m.ID)
+
+	if len(labelIds) > 0 {
+		q = q.Where("label_id NOT IN (?)", labelIds)
+	}
+
+	if err := q.Find(&remove).Error; err != nil {
+		return err
+	}
+
+	for _, pl := range remove {
+		if pl.LabelSrc == SrcManual || pl.LabelSrc == classify.SrcKeyword {
+			if err := tx.Delete(&pl).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Model(&pl).UpdateColumns(PhotoLabel{LabelSrc: SrcManual, Uncertainty: 100}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
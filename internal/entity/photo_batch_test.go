@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavePhotoBatch(t *testing.T) {
+	newPhotos := func(t *testing.T) Photos {
+		result := Photos{}
+
+		for i := 0; i < 2; i++ {
+			p := NewPhoto(false)
+			p.TakenAt = time.Date(1995, 5, 1, 10, i, 0, 0, time.UTC)
+			p.TakenAtLocal = p.TakenAt
+			p.PhotoTitle = "Batch"
+			p.TitleSrc = SrcAuto
+			p.PhotoPath = "batch"
+			p.PhotoName = "IMG_000" + string(rune('1'+i))
+
+			if err := p.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			result = append(result, p)
+		}
+
+		return result
+	}
+
+	t.Run("set and append", func(t *testing.T) {
+		photos := newPhotos(t)
+
+		fav := true
+
+		f := form.BatchEdit{
+			Title:     form.BatchText{Value: "Trip", Action: form.BatchAppend},
+			Keywords:  form.BatchText{Value: "beach, sunset", Action: form.BatchSet},
+			Labels:    form.BatchLabels{Names: []string{"Batch Edit Label"}, Action: form.BatchAppend},
+			PlaceID:   PlaceFixtures.Get("mexico").ID,
+			CameraID:  CameraFixtures.Get("canon-eos-5d").ID,
+			Copyright: form.BatchText{Value: "Jane Doe", Action: form.BatchSet},
+			Favorite:  &fav,
+		}
+
+		results, err := SavePhotoBatch(photos, f, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+		assert.Contains(t, results[0].Changed, "Title")
+		assert.Contains(t, results[0].Changed, "Labels")
+		assert.Contains(t, results[0].Changed, "Location")
+		assert.Empty(t, results[0].Error)
+
+		for _, p := range photos {
+			m := Photo{ID: p.ID}
+
+			if err := m.Find(); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "Batch Trip", m.PhotoTitle)
+			assert.Equal(t, SrcManual, m.TitleSrc)
+			assert.Equal(t, "beach, sunset", m.Details.Keywords)
+			assert.Equal(t, "Jane Doe", m.Details.Copyright)
+			assert.Equal(t, SrcManual, m.Details.CopyrightSrc)
+			assert.Equal(t, PlaceFixtures.Get("mexico").ID, m.PlaceID)
+			assert.Equal(t, "mx", m.PhotoCountry)
+			assert.Equal(t, SrcManual, m.CameraSrc)
+			assert.True(t, m.PhotoFavorite)
+
+			if assert.Len(t, m.Labels, 1) {
+				assert.Equal(t, "Batch Edit Label", m.Labels[0].Label.LabelName)
+				assert.Equal(t, 0, m.Labels[0].Uncertainty)
+			}
+
+			UnscopedDb().Delete(&m)
+		}
+	})
+
+	t.Run("set labels", func(t *testing.T) {
+		photos := newPhotos(t)
+
+		label := FirstOrCreateLabel(NewLabel("Batch Auto Label", 0))
+
+		for _, p := range photos {
+			FirstOrCreatePhotoLabel(NewPhotoLabel(p.ID, label.ID, 20, "image"))
+		}
+
+		results, err := SavePhotoBatch(photos, form.BatchEdit{Labels: form.BatchLabels{Action: form.BatchSet}}, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+
+		for _, p := range photos {
+			pl := PhotoLabel{}
+
+			if err := Db().Where("photo_id = ? AND label_id = ?", p.ID, label.ID).First(&pl).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, 100, pl.Uncertainty)
+			assert.Equal(t, SrcManual, pl.LabelSrc)
+
+			UnscopedDb().Delete(&p)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		photos := newPhotos(t)
+
+		// Saving the second photo fails because its UID isn't unique.
+		uid := photos[1].PhotoUID
+		photos[1].PhotoUID = photos[0].PhotoUID
+
+		results, err := SavePhotoBatch(photos, form.BatchEdit{Title: form.BatchText{Value: "Rollback", Action: form.BatchSet}}, "")
+
+		assert.Error(t, err)
+
+		if assert.Len(t, results, 2) {
+			assert.Empty(t, results[0].Error)
+			assert.NotEmpty(t, results[1].Error)
+		}
+
+		photos[1].PhotoUID = uid
+
+		for _, p := range photos {
+			m := Photo{ID: p.ID}
+
+			if err := m.Find(); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "Batch", m.PhotoTitle)
+
+			UnscopedDb().Delete(&m)
+		}
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		lat, lng := float32(91), float32(13.4)
+
+		_, err := SavePhotoBatch(Photos{}, form.BatchEdit{Lat: &lat, Lng: &lng}, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		_, err := SavePhotoBatch(Photos{}, form.BatchEdit{}, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown place", func(t *testing.T) {
+		_, err := SavePhotoBatch(Photos{}, form.BatchEdit{PlaceID: "xx:foobar"}, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown camera", func(t *testing.T) {
+		_, err := SavePhotoBatch(Photos{}, form.BatchEdit{CameraID: 999999999}, "")
+
+		assert.Error(t, err)
+	})
+}
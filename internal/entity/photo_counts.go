@@ -33,6 +33,15 @@ func LabelCounts() LabelPhotoCounts {
 		WHERE pl.uncertainty < 100
 		AND ph.photo_quality >= 0
 		AND ph.photo_private = 0
+		AND ph.deleted_at IS NULL GROUP BY l.id
+		UNION ALL
+		SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
+		JOIN labels_parents lp ON lp.parent_id = l.id
+		JOIN photos_labels pl ON pl.label_id = lp.label_id
+		JOIN photos ph ON pl.photo_id = ph.id
+		WHERE pl.uncertainty < 100
+		AND ph.photo_quality >= 0
+		AND ph.photo_private = 0
 		AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id
 		`).Scan(&result).Error; err != nil {
 		log.Errorf("label-count: %s", err.Error())
@@ -69,6 +78,15 @@ func UpdatePhotoCounts() error {
 	(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
 	            JOIN categories c ON c.category_id = l.id
 	            JOIN photos_labels pl ON pl.label_id = c.label_id
+	            JOIN photos ph ON pl.photo_id = ph.id
+				WHERE pl.uncertainty < 100
+				AND ph.photo_quality >= 0
+				AND ph.photo_private = 0
+				AND ph.deleted_at IS NULL GROUP BY l.id)
+	UNION ALL
+	(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
+	            JOIN labels_parents lp ON lp.parent_id = l.id
+	            JOIN photos_labels pl ON pl.label_id = lp.label_id
 	            JOIN photos ph ON pl.photo_id = ph.id
 				WHERE pl.uncertainty < 100
 				AND ph.photo_quality >= 0
@@ -102,6 +120,15 @@ func UpdatePhotoCounts() error {
 			(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
 			            JOIN categories c ON c.category_id = l.id
 			            JOIN photos_labels pl ON pl.label_id = c.label_id
+			            JOIN photos ph ON pl.photo_id = ph.id
+						WHERE pl.uncertainty < 100
+						AND ph.photo_quality >= 0
+						AND ph.photo_private = 0
+						AND ph.deleted_at IS NULL GROUP BY l.id)
+			UNION ALL
+			(SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
+			            JOIN labels_parents lp ON lp.parent_id = l.id
+			            JOIN photos_labels pl ON pl.label_id = lp.label_id
 			            JOIN photos ph ON pl.photo_id = ph.id
 						WHERE pl.uncertainty < 100
 						AND ph.photo_quality >= 0
@@ -130,6 +157,15 @@ func UpdatePhotoCounts() error {
 					WHERE pl.uncertainty < 100
 					AND ph.photo_quality >= 0
 					AND ph.photo_private = 0
+					AND ph.deleted_at IS NULL GROUP BY l.id
+					UNION ALL
+					SELECT l.id AS label_id, COUNT(*) AS photo_count FROM labels l
+					JOIN labels_parents lp ON lp.parent_id = l.id
+					JOIN photos_labels pl ON pl.label_id = lp.label_id
+					JOIN photos ph ON pl.photo_id = ph.id
+					WHERE pl.uncertainty < 100
+					AND ph.photo_quality >= 0
+					AND ph.photo_private = 0
 					AND ph.deleted_at IS NULL GROUP BY l.id) counts GROUP BY label_id) label_counts WHERE label_id = labels.id)`)).Error; err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"math"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -91,3 +92,129 @@ func (m *Photo) EstimatePlace() {
 		}
 	}
 }
+
+// EstimateOptions represents options for estimating photo positions from time-adjacent photos.
+type EstimateOptions struct {
+	Window     time.Duration
+	SameFolder bool
+	SameAlbum  bool
+}
+
+// Estimated positions are assumed to be accurate within the distance you can walk between two photos.
+const (
+	EstimateSpeed       = 5000 // Meters per hour.
+	EstimateMinAccuracy = 10   // Meters.
+)
+
+// EstimatePosition updates the photo with coordinates and place borrowed from the nearest geotagged
+// photos taken within the time window, and returns true if the position was estimated.
+func (m *Photo) EstimatePosition(opt EstimateOptions) bool {
+	if m.HasLatLng() && m.PlaceSrc != SrcAuto && m.PlaceSrc != SrcEstimate {
+		// Do nothing.
+		return false
+	} else if m.TakenSrc == SrcAuto || m.TakenAt.IsZero() || opt.Window <= 0 {
+		return false
+	}
+
+	before, hasBefore := m.estimateNeighbour(opt, true)
+	after, hasAfter := m.estimateNeighbour(opt, false)
+
+	var nearest Photo
+	var lat, lng float64
+	var altitude, accuracy int
+
+	switch {
+	case hasBefore && hasAfter:
+		f := 0.0
+
+		if d := after.TakenAt.Sub(before.TakenAt); d > 0 {
+			f = float64(m.TakenAt.Sub(before.TakenAt)) / float64(d)
+		}
+
+		lat = float64(before.PhotoLat) + float64(after.PhotoLat-before.PhotoLat)*f
+		lng = float64(before.PhotoLng) + float64(after.PhotoLng-before.PhotoLng)*f
+		altitude = before.PhotoAltitude + int(float64(after.PhotoAltitude-before.PhotoAltitude)*f)
+		accuracy = int(math.Max(
+			estimateDistance(lat, lng, float64(before.PhotoLat), float64(before.PhotoLng)),
+			estimateDistance(lat, lng, float64(after.PhotoLat), float64(after.PhotoLng))))
+
+		if f < 0.5 {
+			nearest = before
+		} else {
+			nearest = after
+		}
+	case hasBefore || hasAfter:
+		if hasBefore {
+			nearest = before
+		} else {
+			nearest = after
+		}
+
+		lat, lng, altitude = float64(nearest.PhotoLat), float64(nearest.PhotoLng), nearest.PhotoAltitude
+		accuracy = int(math.Abs(m.TakenAt.Sub(nearest.TakenAt).Hours()) * EstimateSpeed)
+	default:
+		log.Debugf("photo: can't estimate position of %s, no geotagged photos within %s", m, opt.Window)
+		return false
+	}
+
+	if accuracy < EstimateMinAccuracy {
+		accuracy = EstimateMinAccuracy
+	}
+
+	m.PhotoLat = float32(lat)
+	m.PhotoLng = float32(lng)
+	m.PhotoAltitude = altitude
+	m.CellAccuracy = accuracy
+	m.CellID = nearest.CellID
+	m.PlaceID = nearest.PlaceID
+	m.Place = nearest.Place
+	m.Cell = nil
+	m.PhotoCountry = nearest.PhotoCountry
+	m.PlaceSrc = SrcEstimate
+	m.UpdateTimeZone(nearest.TimeZone)
+
+	log.Debugf("photo: estimated position of %s is %f, %f (%d m)", m, m.PhotoLat, m.PhotoLng, accuracy)
+
+	return true
+}
+
+// estimateNeighbour finds the nearest geotagged photo taken before or after this photo within the time window.
+func (m *Photo) estimateNeighbour(opt EstimateOptions, before bool) (result Photo, found bool) {
+	q := UnscopedDb().
+		Where("deleted_at IS NULL AND id <> ? AND photo_lat <> 0 AND photo_lng <> 0", m.ID).
+		Where("place_src <> '' AND place_src <> ?", SrcEstimate)
+
+	if before {
+		q = q.Where("taken_at <= ? AND taken_at >= ?", m.TakenAt, m.TakenAt.Add(-opt.Window)).Order("taken_at DESC")
+	} else {
+		q = q.Where("taken_at > ? AND taken_at <= ?", m.TakenAt, m.TakenAt.Add(opt.Window)).Order("taken_at ASC")
+	}
+
+	if opt.SameFolder {
+		q = q.Where("photo_path = ?", m.PhotoPath)
+	}
+
+	if opt.SameAlbum {
+		q = q.Where("photo_uid IN (SELECT b.photo_uid FROM photos_albums a JOIN photos_albums b ON b.album_uid = a.album_uid "+
+			"WHERE a.photo_uid = ? AND a.hidden = 0 AND b.hidden = 0)", m.PhotoUID)
+	}
+
+	if err := q.Preload("Place").First(&result).Error; err != nil {
+		return result, false
+	}
+
+	return result, true
+}
+
+// estimateDistance returns the great-circle distance between two points in meters.
+func estimateDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000
+
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
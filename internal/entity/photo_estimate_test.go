@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -49,3 +50,135 @@ func TestPhoto_EstimateCountry(t *testing.T) {
 		assert.Equal(t, "Unknown", m.CountryName())
 	})
 }
+
+func TestPhoto_EstimatePosition(t *testing.T) {
+	before := Photo{
+		TakenAt:       time.Date(1990, 4, 1, 10, 0, 0, 0, time.UTC),
+		TakenAtLocal:  time.Date(1990, 4, 1, 12, 0, 0, 0, time.UTC),
+		TakenSrc:      SrcMeta,
+		TimeZone:      "Europe/Berlin",
+		PhotoPath:     "estimate/a",
+		PhotoLat:      50,
+		PhotoLng:      10,
+		PhotoAltitude: 100,
+		PhotoCountry:  "de",
+		PlaceSrc:      SrcMeta,
+	}
+
+	after := Photo{
+		TakenAt:       time.Date(1990, 4, 1, 11, 0, 0, 0, time.UTC),
+		TakenAtLocal:  time.Date(1990, 4, 1, 13, 0, 0, 0, time.UTC),
+		TakenSrc:      SrcMeta,
+		TimeZone:      "Europe/Berlin",
+		PhotoPath:     "estimate/b",
+		PhotoLat:      52,
+		PhotoLng:      12,
+		PhotoAltitude: 200,
+		PhotoCountry:  "de",
+		PlaceSrc:      SrcMeta,
+	}
+
+	if err := before.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(&before)
+
+	if err := after.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(&after)
+
+	t.Run("interpolated", func(t *testing.T) {
+		m := Photo{
+			TakenAt:      time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(1990, 4, 1, 12, 15, 0, 0, time.UTC),
+			TakenSrc:     SrcMeta,
+		}
+
+		assert.True(t, m.EstimatePosition(EstimateOptions{Window: time.Hour}))
+		assert.Equal(t, float32(50.5), m.PhotoLat)
+		assert.Equal(t, float32(10.5), m.PhotoLng)
+		assert.Equal(t, 125, m.PhotoAltitude)
+		assert.Equal(t, "de", m.PhotoCountry)
+		assert.Equal(t, SrcEstimate, m.PlaceSrc)
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC), m.TakenAt)
+		assert.InDelta(t, 198000, m.CellAccuracy, 2000)
+	})
+
+	t.Run("nearest", func(t *testing.T) {
+		m := Photo{
+			TakenAt:      time.Date(1990, 4, 1, 11, 30, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(1990, 4, 1, 13, 30, 0, 0, time.UTC),
+			TakenSrc:     SrcMeta,
+		}
+
+		assert.True(t, m.EstimatePosition(EstimateOptions{Window: time.Hour}))
+		assert.Equal(t, float32(52), m.PhotoLat)
+		assert.Equal(t, float32(12), m.PhotoLng)
+		assert.Equal(t, 2500, m.CellAccuracy)
+	})
+
+	t.Run("same folder", func(t *testing.T) {
+		m := Photo{
+			TakenAt:      time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(1990, 4, 1, 12, 15, 0, 0, time.UTC),
+			TakenSrc:     SrcMeta,
+			PhotoPath:    "estimate/a",
+		}
+
+		assert.True(t, m.EstimatePosition(EstimateOptions{Window: time.Hour, SameFolder: true}))
+		assert.Equal(t, float32(50), m.PhotoLat)
+		assert.Equal(t, 1250, m.CellAccuracy)
+	})
+
+	t.Run("same album", func(t *testing.T) {
+		m := Photo{
+			PhotoUID: "pt9jtdre2lvl0zzz",
+			TakenAt:  time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC),
+			TakenSrc: SrcMeta,
+		}
+
+		assert.False(t, m.EstimatePosition(EstimateOptions{Window: time.Hour, SameAlbum: true}))
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		m := Photo{
+			TakenAt:  time.Date(1990, 4, 1, 14, 0, 0, 0, time.UTC),
+			TakenSrc: SrcMeta,
+		}
+
+		assert.False(t, m.EstimatePosition(EstimateOptions{Window: time.Hour}))
+		assert.False(t, m.HasLatLng())
+	})
+
+	t.Run("unknown date", func(t *testing.T) {
+		m := Photo{
+			TakenAt:  time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC),
+			TakenSrc: SrcAuto,
+		}
+
+		assert.False(t, m.EstimatePosition(EstimateOptions{Window: time.Hour}))
+	})
+
+	t.Run("has position", func(t *testing.T) {
+		m := Photo{
+			TakenAt:  time.Date(1990, 4, 1, 10, 15, 0, 0, time.UTC),
+			TakenSrc: SrcMeta,
+			PhotoLat: 1,
+			PhotoLng: 1,
+			PlaceSrc: SrcManual,
+		}
+
+		assert.False(t, m.EstimatePosition(EstimateOptions{Window: time.Hour}))
+		assert.Equal(t, float32(1), m.PhotoLat)
+	})
+}
+
+func TestEstimateDistance(t *testing.T) {
+	// Berlin to Paris.
+	assert.InDelta(t, 878000, estimateDistance(52.5200, 13.4050, 48.8566, 2.3522), 5000)
+	assert.Equal(t, float64(0), estimateDistance(52.5200, 13.4050, 52.5200, 13.4050))
+}
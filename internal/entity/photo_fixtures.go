@@ -192,6 +192,10 @@ var PhotoFixtures = PhotoMap{
 		PhotoResolution:  2,
 		PhotoFavorite:    false,
 		PhotoPrivate:     false,
+		PhotoRating:      3,
+		RatingSrc:        SrcMeta,
+		PhotoColorLabel:  "green",
+		ColorLabelSrc:    SrcMeta,
 		PhotoType:        "image",
 		PhotoLat:         48.519234,
 		PhotoLng:         9.057997,
@@ -224,6 +228,7 @@ var PhotoFixtures = PhotoMap{
 			LabelFixtures.PhotoLabel(1000003, "cow", 20, "image"),
 			LabelFixtures.PhotoLabel(1000003, "updatePhotoLabel", 20, "manual"),
 			LabelFixtures.PhotoLabel(1000000, "landscape", 10, "location"),
+			LabelFixtures.PhotoLabel(1000003, "berlin", 30, "meta"),
 		},
 		CreatedAt: time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC),
 		UpdatedAt: time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -244,6 +249,10 @@ var PhotoFixtures = PhotoMap{
 		PhotoResolution:  2,
 		PhotoFavorite:    false,
 		PhotoPrivate:     false,
+		PhotoRating:      5,
+		RatingSrc:        SrcXmp,
+		PhotoColorLabel:  "red",
+		ColorLabelSrc:    SrcXmp,
 		PhotoType:        "image",
 		PhotoLat:         48.519234,
 		PhotoLng:         9.057997,
@@ -9,12 +9,12 @@ type PhotoLabels []PhotoLabel
 // PhotoLabel represents the many-to-many relation between Photo and label.
 // Labels are weighted by uncertainty (100 - confidence)
 type PhotoLabel struct {
-	PhotoID     uint   `gorm:"primary_key;auto_increment:false"`
-	LabelID     uint   `gorm:"primary_key;auto_increment:false;index"`
-	LabelSrc    string `gorm:"type:VARBINARY(8);"`
-	Uncertainty int    `gorm:"type:SMALLINT"`
-	Photo       *Photo `gorm:"PRELOAD:false"`
-	Label       *Label `gorm:"PRELOAD:true"`
+	PhotoID     uint   `gorm:"primary_key;auto_increment:false" yaml:"-"`
+	LabelID     uint   `gorm:"primary_key;auto_increment:false;index" yaml:"-"`
+	LabelSrc    string `gorm:"type:VARBINARY(8);" yaml:"LabelSrc"`
+	Uncertainty int    `gorm:"type:SMALLINT" yaml:"Uncertainty"`
+	Photo       *Photo `gorm:"PRELOAD:false" yaml:"-"`
+	Label       *Label `gorm:"PRELOAD:true" yaml:"Label"`
 }
 
 // TableName returns PhotoLabel table identifier "photos_labels"
@@ -497,6 +497,39 @@ func TestPhoto_AddLabels(t *testing.T) {
 	})
 }
 
+func TestPhoto_AddLabelHierarchy(t *testing.T) {
+	t.Run("add hierarchy", func(t *testing.T) {
+		m := PhotoFixtures.Get("19800101_000002_D640C559")
+		m.AddLabelHierarchy([]string{"People|Family|Anna", "Places|Europe|Berlin"})
+
+		var slugs []string
+
+		for _, l := range m.Labels {
+			slugs = append(slugs, l.Label.LabelSlug)
+		}
+
+		assert.Contains(t, slugs, "anna")
+		assert.Contains(t, slugs, "berlin")
+		assert.NotContains(t, slugs, "family")
+
+		anna := FindLabel("anna")
+
+		if anna == nil {
+			t.Fatal("label must not be nil")
+		}
+
+		if parents := anna.Parents(); assert.Len(t, parents, 1) {
+			assert.Equal(t, "family", parents[0].LabelSlug)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		len1 := len(m.Labels)
+		m.AddLabelHierarchy([]string{})
+		assert.Equal(t, len1, len(m.Labels))
+	})
+}
+
 func TestPhoto_SetTitle(t *testing.T) {
 	t.Run("empty title", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo15")
@@ -539,6 +572,50 @@ func TestPhoto_SetDescription(t *testing.T) {
 	})
 }
 
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("unrated", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetRating(0, SrcManual)
+		assert.Equal(t, 5, m.PhotoRating)
+	})
+	t.Run("rating not from the same source", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetRating(2, SrcMeta)
+		assert.Equal(t, 5, m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo15")
+		m.SetRating(9, SrcMeta)
+		assert.Equal(t, 0, m.PhotoRating)
+	})
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetRating(-1, SrcManual)
+		assert.Equal(t, -1, m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+}
+
+func TestPhoto_SetColorLabel(t *testing.T) {
+	t.Run("unsupported label", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo03")
+		m.SetColorLabel("Approved", SrcXmp)
+		assert.Equal(t, "green", m.PhotoColorLabel)
+	})
+	t.Run("label not from the same source", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo04")
+		m.SetColorLabel("blue", SrcMeta)
+		assert.Equal(t, "red", m.PhotoColorLabel)
+	})
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo03")
+		m.SetColorLabel("Purple", SrcXmp)
+		assert.Equal(t, "purple", m.PhotoColorLabel)
+		assert.Equal(t, SrcXmp, m.ColorLabelSrc)
+	})
+}
+
 func TestPhoto_SetTakenAt(t *testing.T) {
 	t.Run("empty taken", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo15")
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+)
+
+// ShiftTime adds an offset to the photo's UTC and local time, e.g. to correct camera clock errors.
+// Known date fields are updated and the time source is set to manual.
+func (m *Photo) ShiftTime(offset time.Duration) {
+	if offset == 0 || m.TakenAt.IsZero() {
+		return
+	}
+
+	if m.TakenAtLocal.IsZero() {
+		m.TakenAtLocal = m.TakenAt
+	}
+
+	m.TakenAt = m.TakenAt.Add(offset).Round(time.Second).UTC()
+	m.TakenAtLocal = m.TakenAtLocal.Add(offset).Round(time.Second)
+	m.TakenSrc = SrcManual
+
+	// Keep unknown date fields unknown.
+	if m.PhotoYear != YearUnknown {
+		m.PhotoYear = m.TakenAtLocal.Year()
+	}
+
+	if m.PhotoMonth != MonthUnknown {
+		m.PhotoMonth = int(m.TakenAtLocal.Month())
+	}
+
+	if m.PhotoDay != DayUnknown {
+		m.PhotoDay = m.TakenAtLocal.Day()
+	}
+}
+
+// SaveTimeShift saves photos with shifted times together with the change in a single transaction,
+// so that either all photos are shifted and the change can be undone, or none. It returns the
+// index of the photo that could not be saved, or -1.
+func SaveTimeShift(photos Photos, change *Change) (failed int, err error) {
+	failed = -1
+	tx := UnscopedDb().Begin()
+
+	for i := range photos {
+		if err = tx.Save(&photos[i]).Error; err != nil {
+			failed = i
+			break
+		}
+	}
+
+	if err == nil {
+		err = change.create(tx)
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return failed, err
+	}
+
+	return failed, tx.Commit().Error
+}
@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_ShiftTime(t *testing.T) {
+	t.Run("forward", func(t *testing.T) {
+		m := Photo{
+			TakenAt:      time.Date(2019, 12, 31, 22, 30, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC),
+			TakenSrc:     SrcMeta,
+			PhotoYear:    2019,
+			PhotoMonth:   12,
+			PhotoDay:     31,
+		}
+
+		m.ShiftTime(time.Hour)
+
+		assert.Equal(t, time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC), m.TakenAt)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, 2020, m.PhotoYear)
+		assert.Equal(t, 1, m.PhotoMonth)
+		assert.Equal(t, 1, m.PhotoDay)
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		m := Photo{
+			TakenAt:      time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+			TakenSrc:     SrcMeta,
+			PhotoYear:    2020,
+			PhotoMonth:   1,
+			PhotoDay:     DayUnknown,
+		}
+
+		m.ShiftTime(-24 * time.Hour)
+
+		assert.Equal(t, time.Date(2019, 12, 31, 0, 30, 0, 0, time.UTC), m.TakenAtLocal)
+		assert.Equal(t, 2019, m.PhotoYear)
+		assert.Equal(t, 12, m.PhotoMonth)
+		assert.Equal(t, DayUnknown, m.PhotoDay)
+	})
+
+	t.Run("zero offset", func(t *testing.T) {
+		m := Photo{
+			TakenAt:  time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+			TakenSrc: SrcMeta,
+		}
+
+		m.ShiftTime(0)
+
+		assert.Equal(t, SrcMeta, m.TakenSrc)
+	})
+}
+
+func TestSaveTimeShift(t *testing.T) {
+	newPhotos := func(t *testing.T) Photos {
+		result := Photos{}
+
+		for i := 0; i < 2; i++ {
+			p := NewPhoto(false)
+			p.TakenAt = time.Date(1996, 5, 1, 10, i, 0, 0, time.UTC)
+			p.TakenAtLocal = p.TakenAt
+			p.PhotoPath = "timeshift"
+			p.PhotoName = "IMG_000" + string(rune('1'+i))
+
+			if err := p.Create(); err != nil {
+				t.Fatal(err)
+			}
+
+			result = append(result, p)
+		}
+
+		return result
+	}
+
+	t.Run("success", func(t *testing.T) {
+		photos := newPhotos(t)
+		change := NewChange("", ChangeBatch)
+
+		for i := range photos {
+			old := photos[i].Clone()
+			photos[i].ShiftTime(time.Hour)
+			change.Photo(&old, &photos[i])
+		}
+
+		failed, err := SaveTimeShift(photos, change)
+
+		assert.NoError(t, err)
+		assert.Equal(t, -1, failed)
+
+		for _, p := range photos {
+			m := Photo{ID: p.ID}
+
+			if err := m.Find(); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, 11, m.TakenAt.Hour())
+
+			UnscopedDb().Delete(&m)
+		}
+	})
+	t.Run("rollback", func(t *testing.T) {
+		photos := newPhotos(t)
+		change := NewChange("", ChangeBatch)
+
+		for i := range photos {
+			old := photos[i].Clone()
+			photos[i].ShiftTime(time.Hour)
+			change.Photo(&old, &photos[i])
+		}
+
+		// Saving the second photo fails because its UID isn't unique.
+		uid := photos[1].PhotoUID
+		photos[1].PhotoUID = photos[0].PhotoUID
+
+		failed, err := SaveTimeShift(photos, change)
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, failed)
+
+		photos[1].PhotoUID = uid
+
+		for _, p := range photos {
+			m := Photo{ID: p.ID}
+
+			if err := m.Find(); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, 10, m.TakenAt.Hour())
+
+			UnscopedDb().Delete(&m)
+		}
+	})
+}
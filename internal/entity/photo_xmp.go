@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var photoXmpMutex = sync.Mutex{}
+
+// XmpSrc tests if values from the data source are written to XMP sidecar files. Generated
+// values are skipped so that they don't take precedence when the file is indexed again.
+func XmpSrc(src string) bool {
+	return src == SrcMeta || src == SrcXmp || src == SrcManual
+}
+
+// XmpData returns the photo metadata that can be written to an XMP sidecar file.
+func (m *Photo) XmpData() meta.Data {
+	data := meta.Data{}
+
+	if XmpSrc(m.TitleSrc) {
+		data.Title = m.PhotoTitle
+	}
+
+	if XmpSrc(m.DescriptionSrc) {
+		data.Description = m.PhotoDescription
+	}
+
+	if XmpSrc(m.TakenSrc) {
+		data.TakenAt = m.TakenAt
+		data.TakenAtLocal = m.TakenAtLocal
+		data.TimeZone = m.TimeZone
+	}
+
+	if XmpSrc(m.PlaceSrc) {
+		data.Lat = m.PhotoLat
+		data.Lng = m.PhotoLng
+		data.Altitude = m.PhotoAltitude
+	}
+
+	// Favorites without rating get five stars, existing ratings are kept otherwise.
+	if XmpSrc(m.RatingSrc) && m.PhotoRating != 0 {
+		data.Rating = m.PhotoRating
+	} else if m.PhotoFavorite {
+		data.Rating = meta.RatingMax
+	}
+
+	if XmpSrc(m.ColorLabelSrc) {
+		data.ColorLabel = m.PhotoColorLabel
+	}
+
+	var keywords []string
+
+	if m.Details != nil {
+		if XmpSrc(m.Details.KeywordsSrc) && m.Details.Keywords != "" {
+			keywords = append(keywords, m.Details.Keywords)
+		}
+
+		if XmpSrc(m.Details.CopyrightSrc) {
+			data.Copyright = m.Details.Copyright
+		}
+	}
+
+	// Labels that were not generated, e.g. added by users, are written as keywords.
+	for _, l := range m.Labels {
+		if l.Label == nil || l.Uncertainty >= 100 || !XmpSrc(l.LabelSrc) {
+			continue
+		}
+
+		keywords = append(keywords, l.Label.LabelName)
+	}
+
+	data.Keywords = strings.Join(keywords, ", ")
+
+	return data
+}
+
+// SaveAsXmp writes photo metadata to an XMP sidecar file, other existing values are preserved.
+func (m *Photo) SaveAsXmp(fileName string) error {
+	photoXmpMutex.Lock()
+	defer photoXmpMutex.Unlock()
+
+	// Labels must be complete, as missing labels would be removed from the keywords.
+	p := *m
+	p.Labels = nil
+
+	if m.ID > 0 {
+		if err := Db().Where("photo_id = ?", m.ID).Preload("Label").Find(&p.Labels).Error; err != nil {
+			return err
+		}
+	}
+
+	return p.XmpData().SaveXMP(fileName)
+}
+
+// XmpFileName returns the file name for a new XMP sidecar file.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string) string {
+	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.XmpExt)
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXmpSrc(t *testing.T) {
+	assert.True(t, XmpSrc(SrcManual))
+	assert.True(t, XmpSrc(SrcXmp))
+	assert.True(t, XmpSrc(SrcMeta))
+	assert.False(t, XmpSrc(SrcAuto))
+	assert.False(t, XmpSrc(SrcName))
+	assert.False(t, XmpSrc(SrcEstimate))
+}
+
+func TestPhoto_XmpData(t *testing.T) {
+	t.Run("manual", func(t *testing.T) {
+		m := Photo{
+			PhotoTitle:       "Fernsehturm",
+			TitleSrc:         SrcManual,
+			PhotoDescription: "Generated",
+			DescriptionSrc:   SrcAuto,
+			TakenAt:          time.Date(2020, 1, 1, 16, 28, 23, 0, time.UTC),
+			TakenAtLocal:     time.Date(2020, 1, 1, 17, 28, 23, 0, time.UTC),
+			TimeZone:         "Europe/Berlin",
+			TakenSrc:         SrcMeta,
+			PhotoLat:         52.520645,
+			PhotoLng:         13.409779,
+			PlaceSrc:         SrcEstimate,
+			PhotoFavorite:    true,
+			Details: &Details{
+				Keywords:     "berlin, tower",
+				KeywordsSrc:  SrcManual,
+				Copyright:    "Jane Doe",
+				CopyrightSrc: SrcAuto,
+			},
+		}
+
+		data := m.XmpData()
+
+		assert.Equal(t, "Fernsehturm", data.Title)
+		assert.Equal(t, "", data.Description)
+		assert.Equal(t, m.TakenAt, data.TakenAt)
+		assert.Equal(t, "Europe/Berlin", data.TimeZone)
+		assert.Equal(t, float32(0), data.Lat)
+		assert.Equal(t, 5, data.Rating)
+		assert.Equal(t, "berlin, tower", data.Keywords)
+		assert.Equal(t, "", data.Copyright)
+	})
+	t.Run("rating", func(t *testing.T) {
+		m := Photo{PhotoFavorite: true, PhotoRating: 3, RatingSrc: SrcXmp, PhotoColorLabel: "blue", ColorLabelSrc: SrcManual}
+		data := m.XmpData()
+
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, "blue", data.ColorLabel)
+	})
+	t.Run("labels", func(t *testing.T) {
+		m := Photo{
+			Details: &Details{Keywords: "tower", KeywordsSrc: SrcMeta},
+			Labels: []PhotoLabel{
+				{LabelSrc: SrcManual, Label: &Label{LabelName: "Berlin"}},
+				{LabelSrc: SrcImage, Label: &Label{LabelName: "Building"}},
+				{LabelSrc: SrcManual, Uncertainty: 100, Label: &Label{LabelName: "Removed"}},
+			},
+		}
+
+		assert.Equal(t, "tower, Berlin", m.XmpData().Keywords)
+	})
+	t.Run("no details", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Generated"}
+
+		assert.Equal(t, meta.Data{}, m.XmpData())
+	})
+}
+
+func TestPhoto_SaveAsXmp(t *testing.T) {
+	m := Photo{PhotoTitle: "Fernsehturm", TitleSrc: SrcManual, PhotoLat: 52.520645, PhotoLng: 13.409779, PlaceSrc: SrcManual}
+	fileName := filepath.Join(os.TempDir(), ".photoprism_test.xmp")
+
+	if err := m.SaveAsXmp(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	data, err := meta.XMP(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Fernsehturm", data.Title)
+	assert.InDelta(t, 52.520645, data.Lat, 0.00001)
+	assert.InDelta(t, 13.409779, data.Lng, 0.00001)
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	m := PhotoFixtures.Get("Photo01")
+	assert.Equal(t, "xxx/2790/02/yyy/Photo01.xmp", m.XmpFileName("xxx", "yyy"))
+	assert.Equal(t, "xxx/2790/02/Photo01.xmp", m.XmpFileName("xxx", ""))
+
+	if err := os.RemoveAll("xxx"); err != nil {
+		t.Fatal(err)
+	}
+}
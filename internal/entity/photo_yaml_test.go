@@ -43,6 +43,52 @@ func TestPhoto_SaveAsYaml(t *testing.T) {
 	})
 }
 
+func TestPhoto_LoadFromYaml(t *testing.T) {
+	t.Run("labels and local time", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		if err := m.LoadLabels(); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test_labels.yml")
+
+		if err := m.SaveAsYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		restored := Photo{}
+
+		if err := restored.LoadFromYaml(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoUID, restored.PhotoUID)
+		assert.Equal(t, m.TakenAtLocal, restored.TakenAtLocal)
+
+		if assert.Len(t, restored.Labels, 1) {
+			l := restored.Labels[0]
+
+			assert.Equal(t, uint(0), l.PhotoID)
+			assert.Equal(t, "image", l.LabelSrc)
+			assert.Equal(t, 20, l.Uncertainty)
+
+			if assert.NotNil(t, l.Label) {
+				assert.Equal(t, LabelFixtures.Get("no-jpeg").LabelName, l.Label.LabelName)
+			}
+		}
+
+		labels := restored.ClassifyLabels()
+
+		if assert.Len(t, labels, 1) {
+			assert.Equal(t, "image", labels[0].Source)
+			assert.Equal(t, 20, labels[0].Uncertainty)
+		}
+	})
+}
+
 func TestPhoto_YamlFileName(t *testing.T) {
 	t.Run("create from fixture", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo01")
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Revision kinds.
+const (
+	RevisionPhoto   = "photo"
+	RevisionDetails = "details"
+	RevisionLabel   = "label"
+	RevisionAlbum   = "album"
+)
+
+// RevisionPhotoFields lists the photo properties that are tracked in the edit history.
+var RevisionPhotoFields = []string{
+	"PhotoType", "TypeSrc", "TakenAt", "TakenAtLocal", "TakenSrc", "TimeZone",
+	"PhotoTitle", "TitleSrc", "PhotoDescription", "DescriptionSrc",
+	"PhotoFavorite", "PhotoPrivate", "PhotoScan", "PhotoPanorama",
+	"PhotoRating", "RatingSrc", "PhotoColorLabel", "ColorLabelSrc",
+	"PhotoLat", "PhotoLng", "PhotoAltitude", "CellID", "CellAccuracy", "PlaceID", "PlaceSrc", "PhotoCountry",
+	"PhotoYear", "PhotoMonth", "PhotoDay",
+	"PhotoIso", "PhotoExposure", "PhotoFNumber", "PhotoFocalLength",
+	"CameraID", "CameraSerial", "CameraSrc", "LensID",
+}
+
+// RevisionDetailsFields lists the photo details that are tracked in the edit history.
+var RevisionDetailsFields = []string{
+	"Keywords", "KeywordsSrc", "Notes", "NotesSrc", "Subject", "SubjectSrc",
+	"Artist", "ArtistSrc", "Copyright", "CopyrightSrc", "License", "LicenseSrc",
+}
+
+type Revisions []Revision
+
+// Revision represents a single manual change of a photo property, label or album membership.
+type Revision struct {
+	ID           uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	ChangeUID    string    `gorm:"type:VARBINARY(42);index;" json:"ChangeUID" yaml:"ChangeUID"`
+	PhotoUID     string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	RevisionKind string    `gorm:"type:VARBINARY(16);" json:"Kind" yaml:"Kind"`
+	Field        string    `gorm:"type:VARBINARY(64);" json:"Field" yaml:"Field"`
+	OldValue     string    `gorm:"type:TEXT;" json:"OldValue" yaml:"OldValue,omitempty"`
+	NewValue     string    `gorm:"type:TEXT;" json:"NewValue" yaml:"NewValue,omitempty"`
+	RevisionSrc  string    `gorm:"type:VARBINARY(8);" json:"Source" yaml:"Source,omitempty"`
+	UserUID      string    `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID,omitempty"`
+	CreatedAt    time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// Create inserts a new row to the database.
+func (m *Revision) Create() error {
+	return Db().Create(m).Error
+}
+
+// FindRevision returns a revision by id or nil if it doesn't exist.
+func FindRevision(id uint) *Revision {
+	result := Revision{}
+
+	if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// revisionValue returns the value of a tracked field as string.
+func revisionValue(v reflect.Value) string {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// setRevisionValue sets a tracked field to the value of a revision.
+func setRevisionValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case reflect.Struct:
+		if _, ok := v.Interface().(time.Time); !ok {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, s)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// labelRevisionValue returns the uncertainty and source of a photo label as string.
+func labelRevisionValue(m PhotoLabel) string {
+	return fmt.Sprintf("%d %s", m.Uncertainty, m.LabelSrc)
+}
+
+// parseLabelRevision returns the uncertainty and source of a label revision value.
+func parseLabelRevision(s string) (uncertainty int, src string, err error) {
+	values := strings.SplitN(s, " ", 2)
+
+	if uncertainty, err = strconv.Atoi(values[0]); err != nil {
+		return uncertainty, src, err
+	}
+
+	if len(values) > 1 {
+		src = values[1]
+	}
+
+	return uncertainty, src, nil
+}
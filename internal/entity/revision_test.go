@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevisionValue(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		m := Photo{
+			TakenAt:       time.Date(2020, 5, 1, 10, 30, 0, 0, time.UTC),
+			PhotoLat:      48.519234,
+			PhotoFavorite: true,
+			CameraID:      1000001,
+			PhotoRating:   -1,
+			PhotoTitle:    "Lake",
+		}
+
+		result := Photo{}
+		src := reflect.ValueOf(&m).Elem()
+		dst := reflect.ValueOf(&result).Elem()
+
+		for _, name := range []string{"TakenAt", "PhotoLat", "PhotoFavorite", "CameraID", "PhotoRating", "PhotoTitle"} {
+			s := revisionValue(src.FieldByName(name))
+
+			if err := setRevisionValue(dst.FieldByName(name), s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assert.Equal(t, m.TakenAt, result.TakenAt)
+		assert.Equal(t, m.PhotoLat, result.PhotoLat)
+		assert.Equal(t, m.PhotoFavorite, result.PhotoFavorite)
+		assert.Equal(t, m.CameraID, result.CameraID)
+		assert.Equal(t, m.PhotoRating, result.PhotoRating)
+		assert.Equal(t, m.PhotoTitle, result.PhotoTitle)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		m := Photo{}
+
+		assert.Error(t, setRevisionValue(reflect.ValueOf(&m).Elem().FieldByName("PhotoFavorite"), "maybe"))
+		assert.Error(t, setRevisionValue(reflect.ValueOf(&m).Elem().FieldByName("TakenAt"), "yesterday"))
+	})
+}
+
+func TestParseLabelRevision(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		uncertainty, src, err := parseLabelRevision(labelRevisionValue(PhotoLabel{Uncertainty: 20, LabelSrc: "image"}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20, uncertainty)
+		assert.Equal(t, "image", src)
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		uncertainty, src, err := parseLabelRevision("100")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 100, uncertainty)
+		assert.Equal(t, "", src)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := parseLabelRevision("foo bar")
+
+		assert.Error(t, err)
+	})
+}
@@ -10,8 +10,11 @@ const (
 	SrcManual   = "manual"
 	SrcEstimate = "estimate"
 	SrcName     = "name"
+	SrcTrack    = "track"
+	SrcRule     = "rule"
 	SrcMeta     = "meta"
 	SrcXmp      = "xmp"
+	SrcLrcat    = "lrcat"
 	SrcYaml     = "yaml"
 	SrcImage    = classify.SrcImage
 	SrcKeyword  = classify.SrcKeyword
@@ -23,11 +26,14 @@ var SrcPriority = Priorities{
 	SrcAuto:     1,
 	SrcEstimate: 2,
 	SrcName:     4,
+	SrcRule:     6,
+	SrcTrack:    8,
 	SrcYaml:     8,
 	SrcLocation: 8,
 	SrcImage:    8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
 	SrcXmp:      32,
+	SrcLrcat:    32,
 	SrcManual:   64,
 }
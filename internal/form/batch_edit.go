@@ -0,0 +1,92 @@
+package form
+
+import "strings"
+
+// Batch edit actions.
+const (
+	BatchKeep   = ""
+	BatchSet    = "set"
+	BatchAppend = "append"
+)
+
+// BatchText represents a text value in a batch edit form and whether it replaces or extends existing values.
+type BatchText struct {
+	Value  string `json:"value"`
+	Action string `json:"action"`
+}
+
+// Changed tests if the value should be changed.
+func (t BatchText) Changed() bool {
+	return t.Action == BatchSet || t.Action == BatchAppend && strings.TrimSpace(t.Value) != ""
+}
+
+// Apply returns the new value based on the current value, using sep to append.
+func (t BatchText) Apply(current, sep string) string {
+	value := strings.TrimSpace(t.Value)
+
+	switch t.Action {
+	case BatchSet:
+		return value
+	case BatchAppend:
+		if value == "" {
+			return current
+		} else if current == "" {
+			return value
+		}
+
+		return current + sep + value
+	default:
+		return current
+	}
+}
+
+// BatchLabels represents label names in a batch edit form.
+type BatchLabels struct {
+	Names  []string `json:"names"`
+	Action string   `json:"action"`
+}
+
+// Changed tests if labels should be changed.
+func (l BatchLabels) Changed() bool {
+	return l.Action == BatchSet || l.Action == BatchAppend && len(l.Names) > 0
+}
+
+// BatchEdit represents a form to edit the metadata of multiple photos at once.
+type BatchEdit struct {
+	Selection
+	Title       BatchText   `json:"title"`
+	Description BatchText   `json:"description"`
+	Keywords    BatchText   `json:"keywords"`
+	Labels      BatchLabels `json:"labels"`
+	Lat         *float32    `json:"lat"`
+	Lng         *float32    `json:"lng"`
+	PlaceID     string      `json:"placeId"`
+	CameraID    uint        `json:"cameraId"`
+	LensID      uint        `json:"lensId"`
+	Artist      BatchText   `json:"artist"`
+	Copyright   BatchText   `json:"copyright"`
+	License     BatchText   `json:"license"`
+	Favorite    *bool       `json:"favorite"`
+	Private     *bool       `json:"private"`
+}
+
+// HasLatLng tests if new coordinates were submitted.
+func (f BatchEdit) HasLatLng() bool {
+	return f.Lat != nil && f.Lng != nil
+}
+
+// NoChanges tests if the form doesn't contain any changes.
+func (f BatchEdit) NoChanges() bool {
+	switch {
+	case f.Title.Changed(), f.Description.Changed(), f.Keywords.Changed(), f.Labels.Changed():
+		return false
+	case f.Artist.Changed(), f.Copyright.Changed(), f.License.Changed():
+		return false
+	case f.HasLatLng(), f.PlaceID != "", f.CameraID > 0, f.LensID > 0:
+		return false
+	case f.Favorite != nil, f.Private != nil:
+		return false
+	}
+
+	return true
+}
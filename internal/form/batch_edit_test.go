@@ -0,0 +1,54 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchText_Apply(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		assert.Equal(t, "New", BatchText{Value: " New ", Action: BatchSet}.Apply("Old", " "))
+		assert.Equal(t, "", BatchText{Action: BatchSet}.Apply("Old", " "))
+	})
+
+	t.Run("append", func(t *testing.T) {
+		assert.Equal(t, "Old, New", BatchText{Value: "New", Action: BatchAppend}.Apply("Old", ", "))
+		assert.Equal(t, "New", BatchText{Value: "New", Action: BatchAppend}.Apply("", ", "))
+		assert.Equal(t, "Old", BatchText{Action: BatchAppend}.Apply("Old", ", "))
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		assert.Equal(t, "Old", BatchText{Value: "New"}.Apply("Old", " "))
+	})
+}
+
+func TestBatchEdit_NoChanges(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		f := BatchEdit{Selection: Selection{Photos: []string{"pt9jtdre2lvl0yh7"}}}
+		assert.True(t, f.NoChanges())
+	})
+
+	t.Run("append empty value", func(t *testing.T) {
+		f := BatchEdit{Title: BatchText{Action: BatchAppend}, Labels: BatchLabels{Action: BatchAppend}}
+		assert.True(t, f.NoChanges())
+	})
+
+	t.Run("title", func(t *testing.T) {
+		f := BatchEdit{Title: BatchText{Value: "Holiday", Action: BatchSet}}
+		assert.False(t, f.NoChanges())
+	})
+
+	t.Run("favorite", func(t *testing.T) {
+		fav := false
+		f := BatchEdit{Favorite: &fav}
+		assert.False(t, f.NoChanges())
+	})
+
+	t.Run("lat only", func(t *testing.T) {
+		lat := float32(48.5)
+		f := BatchEdit{Lat: &lat}
+		assert.True(t, f.NoChanges())
+		assert.False(t, f.HasLatLng())
+	})
+}
@@ -0,0 +1,9 @@
+package form
+
+type GeotagOptions struct {
+	Files    []string `json:"files"`
+	Offset   *int     `json:"offset"`
+	MaxGap   *int     `json:"maxGap"`
+	TimeZone string   `json:"timeZone"`
+	DryRun   bool     `json:"dryRun"`
+}
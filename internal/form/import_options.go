@@ -1,7 +1,15 @@
 package form
 
 type ImportOptions struct {
-	Albums []string `json:"albums"`
-	Path   string   `json:"path"`
-	Move   bool     `json:"move"`
+	Albums   []string `json:"albums"`
+	Path     string   `json:"path"`
+	Move     bool     `json:"move"`
+	Template string   `json:"template"`
+}
+
+// ImportTemplate represents a destination path template and sample files to preview it with.
+type ImportTemplate struct {
+	Template string   `json:"template"`
+	Files    []string `json:"files"`
+	Albums   []string `json:"albums"`
 }
@@ -6,3 +6,9 @@ type Label struct {
 	Uncertainty   int    `json:"Uncertainty"`
 	LabelPriority int    `json:"Priority"`
 }
+
+// LabelSynonym represents a label synonym edit form.
+type LabelSynonym struct {
+	Name   string `json:"Name"`
+	Locale string `json:"Locale"`
+}
@@ -8,6 +8,8 @@ type LabelSearch struct {
 	Name     string `form:"name"`
 	All      bool   `form:"all"`
 	Favorite bool   `form:"favorite"`
+	Parent   string `form:"parent"`
+	Root     bool   `form:"root"`
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
@@ -44,6 +44,10 @@ type Photo struct {
 	PhotoPrivate     bool      `json:"Private"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
+	PhotoRating      int       `json:"Rating"`
+	RatingSrc        string    `json:"RatingSrc"`
+	PhotoColorLabel  string    `json:"ColorLabel"`
+	ColorLabelSrc    string    `json:"ColorLabelSrc"`
 	PhotoAltitude    int       `json:"Altitude"`
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
@@ -28,6 +28,19 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, "123abc/,EFG", form.Path)
 	})
 
+	t.Run("color label", func(t *testing.T) {
+		form := &PhotoSearch{Query: "color-label:red rating:>=4"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "red", form.ColorLabel)
+		assert.Equal(t, ">=4", form.Rating)
+	})
+
 	t.Run("folder", func(t *testing.T) {
 		form := &PhotoSearch{Query: "folder:123abc/,EFG"}
 
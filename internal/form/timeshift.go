@@ -0,0 +1,25 @@
+package form
+
+import "time"
+
+// TimeShift represents a request to shift the time of multiple photos, e.g. to correct camera clock errors.
+type TimeShift struct {
+	Selection
+	CameraSerial string    `json:"cameraSerial"`
+	After        time.Time `json:"after"`
+	Before       time.Time `json:"before"`
+	Offset       int       `json:"offset"`
+	RefPhoto     string    `json:"refPhoto"`
+	RefTime      time.Time `json:"refTime"`
+	DryRun       bool      `json:"dryRun"`
+}
+
+// Empty tests if no photos are selected and no filters are set.
+func (f TimeShift) Empty() bool {
+	return f.Selection.Empty() && f.CameraSerial == "" && f.After.IsZero() && f.Before.IsZero()
+}
+
+// Relative tests if the time is set from a reference photo instead of a fixed offset.
+func (f TimeShift) Relative() bool {
+	return f.RefPhoto != "" && !f.RefTime.IsZero()
+}
@@ -0,0 +1,20 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Empty(t *testing.T) {
+	assert.True(t, TimeShift{Offset: 3600}.Empty())
+	assert.False(t, TimeShift{Selection: Selection{Albums: []string{"at9lxuqxpogaaba7"}}}.Empty())
+	assert.False(t, TimeShift{CameraSerial: "123"}.Empty())
+	assert.False(t, TimeShift{After: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}.Empty())
+}
+
+func TestTimeShift_Relative(t *testing.T) {
+	assert.False(t, TimeShift{RefPhoto: "pt9jtdre2lvl0yh7"}.Relative())
+	assert.True(t, TimeShift{RefPhoto: "pt9jtdre2lvl0yh7", RefTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}.Relative())
+}
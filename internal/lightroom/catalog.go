@@ -0,0 +1,298 @@
+package lightroom
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// Extension is the file extension of Lightroom catalogs.
+const Extension = ".lrcat"
+
+// Collection types as stored in the creationId column.
+const (
+	CollectionDefault = "com.adobe.ag.library.collection"
+	CollectionSet     = "com.adobe.ag.library.group"
+)
+
+const imagesQuery = `SELECT i.id_local, r.absolutePath, r.name, fo.pathFromRoot, f.baseName, f.extension,
+	CAST(COALESCE(i.rating, 0) AS INTEGER), CAST(COALESCE(i.pick, 0) AS INTEGER), COALESCE(i.colorLabels, ''),
+	CASE WHEN e.hasGPS = 1 THEN e.gpsLatitude ELSE 0 END, CASE WHEN e.hasGPS = 1 THEN e.gpsLongitude ELSE 0 END,
+	COALESCE(c.caption, ''), m.xmp
+	FROM Adobe_images i
+	JOIN AgLibraryFile f ON f.id_local = i.rootFile
+	JOIN AgLibraryFolder fo ON fo.id_local = f.folder
+	JOIN AgLibraryRootFolder r ON r.id_local = fo.rootFolder
+	LEFT JOIN AgHarvestedExifMetadata e ON e.image = i.id_local
+	LEFT JOIN AgLibraryIPTC c ON c.image = i.id_local
+	LEFT JOIN Adobe_AdditionalMetadata m ON m.image = i.id_local
+	WHERE i.masterImage IS NULL
+	ORDER BY i.id_local`
+
+// Open reads the images and collections of a Lightroom catalog. The catalog is opened read-only.
+func Open(fileName string) (result Catalog, err error) {
+	if _, err := os.Stat(fileName); err != nil {
+		return result, fmt.Errorf("lightroom: %s not found", filepath.Base(fileName))
+	}
+
+	db, err := gorm.Open("sqlite3", "file:"+fileName+"?mode=ro")
+
+	if err != nil {
+		return result, fmt.Errorf("lightroom: %s in %s", err, filepath.Base(fileName))
+	}
+
+	defer db.Close()
+
+	result.FileName = fileName
+
+	if result.Images, err = readImages(db); err != nil {
+		return result, fmt.Errorf("lightroom: %s in %s (images)", err, filepath.Base(fileName))
+	}
+
+	if result.Collections, err = readCollections(db); err != nil {
+		return result, fmt.Errorf("lightroom: %s in %s (collections)", err, filepath.Base(fileName))
+	}
+
+	return result, nil
+}
+
+// Image returns the image with the given ID, or nil if it doesn't exist.
+func (c Catalog) Image(id int64) *Image {
+	for i := range c.Images {
+		if c.Images[i].ID == id {
+			return &c.Images[i]
+		}
+	}
+
+	return nil
+}
+
+// readImages returns all master images including their keywords.
+func readImages(db *gorm.DB) (result Images, err error) {
+	rows, err := db.Raw(imagesQuery).Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var img Image
+		var rootPath, folderPath, baseName, ext string
+		var xmp []byte
+
+		if err := rows.Scan(&img.ID, &rootPath, &img.RootName, &folderPath, &baseName, &ext,
+			&img.Rating, &img.Pick, &img.ColorLabel, &img.Lat, &img.Lng, &img.Caption, &xmp); err != nil {
+			return result, err
+		}
+
+		img.RelName = folderPath + baseName
+
+		if ext != "" {
+			img.RelName += "." + ext
+		}
+
+		img.FileName = filepath.Join(rootPath, img.RelName)
+
+		if doc, err := xmpDocument(xmp); err == nil {
+			img.Title = doc.Title()
+
+			if img.Caption == "" {
+				img.Caption = doc.Description()
+			}
+		}
+
+		result = append(result, img)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	keywords, err := readKeywords(db)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i := range result {
+		result[i].Keywords = keywords[result[i].ID]
+	}
+
+	return result, nil
+}
+
+// readKeywords returns the keyword paths like "Places|Europe|Berlin" assigned to each image.
+func readKeywords(db *gorm.DB) (result map[int64][]string, err error) {
+	result = make(map[int64][]string)
+	names := make(map[int64]string)
+	parents := make(map[int64]int64)
+
+	rows, err := db.Raw("SELECT id_local, COALESCE(name, ''), COALESCE(parent, 0) FROM AgLibraryKeyword").Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	for rows.Next() {
+		var id, parent int64
+		var name string
+
+		if err := rows.Scan(&id, &name, &parent); err != nil {
+			rows.Close()
+			return result, err
+		}
+
+		names[id] = strings.TrimSpace(name)
+		parents[id] = parent
+	}
+
+	rows.Close()
+
+	rows, err = db.Raw("SELECT image, tag FROM AgLibraryKeywordImage ORDER BY image, tag").Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var image, tag int64
+
+		if err := rows.Scan(&image, &tag); err != nil {
+			return result, err
+		}
+
+		if path := keywordPath(tag, names, parents); path != "" {
+			result[image] = append(result[image], path)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+// keywordPath returns the keyword name including the names of its parents. The root keyword has no name.
+func keywordPath(id int64, names map[int64]string, parents map[int64]int64) string {
+	var path []string
+
+	for seen := make(map[int64]bool); id != 0 && !seen[id]; id = parents[id] {
+		seen[id] = true
+
+		if name := names[id]; name != "" {
+			path = append([]string{name}, path...)
+		}
+	}
+
+	return strings.Join(path, meta.HierarchySep)
+}
+
+// readCollections returns regular collections with their images. Smart collections and system collections
+// like the quick collection are skipped.
+func readCollections(db *gorm.DB) (result Collections, err error) {
+	type collection struct {
+		Name   string
+		Type   string
+		Parent int64
+		System bool
+	}
+
+	collections := make(map[int64]collection)
+	var ids []int64
+
+	rows, err := db.Raw("SELECT id_local, COALESCE(name, ''), COALESCE(creationId, ''), COALESCE(parent, 0), CAST(COALESCE(systemOnly, 0) AS INTEGER) FROM AgLibraryCollection ORDER BY id_local").Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	for rows.Next() {
+		var id int64
+		var c collection
+		var system int
+
+		if err := rows.Scan(&id, &c.Name, &c.Type, &c.Parent, &system); err != nil {
+			rows.Close()
+			return result, err
+		}
+
+		c.Name = strings.TrimSpace(c.Name)
+		c.System = system != 0
+		collections[id] = c
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+
+	index := make(map[int64]int)
+
+	for _, id := range ids {
+		c := collections[id]
+
+		if c.Type != CollectionDefault || c.System || c.Name == "" {
+			continue
+		}
+
+		result = append(result, Collection{ID: id, Name: c.Name, Set: collections[c.Parent].Name})
+		index[id] = len(result) - 1
+	}
+
+	rows, err = db.Raw("SELECT collection, image FROM AgLibraryCollectionImage ORDER BY collection, positionInCollection, id_local").Rows()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var collectionID, image int64
+
+		if err := rows.Scan(&collectionID, &image); err != nil {
+			return result, err
+		}
+
+		if i, ok := index[collectionID]; ok {
+			result[i].Images = append(result[i].Images, image)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+// xmpDocument parses the XMP metadata stored in the catalog. Recent catalog versions store it compressed,
+// prefixed with the uncompressed length.
+func xmpDocument(data []byte) (doc meta.XmpDocument, err error) {
+	if len(data) == 0 {
+		return doc, fmt.Errorf("no xmp data")
+	}
+
+	if data[0] != '<' && len(data) > 4 {
+		r, err := zlib.NewReader(bytes.NewReader(data[4:]))
+
+		if err != nil {
+			return doc, err
+		}
+
+		defer r.Close()
+
+		if data, err = ioutil.ReadAll(r); err != nil {
+			return doc, err
+		}
+	}
+
+	err = xml.Unmarshal(data, &doc)
+
+	return doc, err
+}
@@ -0,0 +1,153 @@
+package lightroom
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCatalog creates a catalog from testdata/catalog.sql in a temporary directory.
+func testCatalog(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "lightroom")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := filepath.Join(dir, "catalog"+Extension)
+
+	sql, err := ioutil.ReadFile("testdata/catalog.sql")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if err := db.Exec(string(sql)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
+
+func TestOpen(t *testing.T) {
+	fileName := testCatalog(t)
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	catalog, err := Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("images", func(t *testing.T) {
+		assert.Len(t, catalog.Images, 3)
+
+		img := catalog.Image(1)
+
+		if img == nil {
+			t.Fatal("image must not be nil")
+		}
+
+		assert.Equal(t, "/Users/jane/Pictures/2019/06/IMG_4120.JPG", img.FileName)
+		assert.Equal(t, "2019/06/IMG_4120.JPG", img.RelName)
+		assert.Equal(t, "Pictures", img.RootName)
+		assert.Equal(t, 5, img.Rating)
+		assert.True(t, img.Flagged())
+		assert.Equal(t, "Red", img.ColorLabel)
+		assert.Equal(t, "Alexanderplatz", img.Title)
+		assert.Equal(t, "Evening in Berlin", img.Caption)
+		assert.InDelta(t, 52.5208, img.Lat, 0.00001)
+		assert.InDelta(t, 13.4094, img.Lng, 0.00001)
+		assert.Equal(t, []string{"Places|Europe|Berlin", "Sunset"}, img.Keywords)
+		assert.Equal(t, []string{"Berlin", "Sunset"}, img.KeywordNames())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		img := catalog.Image(2)
+
+		if img == nil {
+			t.Fatal("image must not be nil")
+		}
+
+		assert.True(t, img.Rejected())
+		assert.Equal(t, 0, img.Rating)
+		assert.False(t, img.HasLatLng())
+		assert.Empty(t, img.Keywords)
+	})
+
+	t.Run("virtual copy", func(t *testing.T) {
+		assert.Nil(t, catalog.Image(4))
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		assert.Len(t, catalog.Collections, 2)
+
+		assert.Equal(t, "Best of 2019", catalog.Collections[0].Name)
+		assert.Equal(t, "Travel", catalog.Collections[0].Set)
+		assert.Equal(t, []int64{1, 3}, catalog.Collections[0].Images)
+
+		assert.Equal(t, "Cats", catalog.Collections[1].Name)
+		assert.Equal(t, "", catalog.Collections[1].Set)
+		assert.Equal(t, []int64{3}, catalog.Collections[1].Images)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Open("testdata/missing.lrcat")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestXmpDocument(t *testing.T) {
+	xmp := []byte(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title><rdf:Alt><rdf:li xml:lang="x-default">Beach</rdf:li></rdf:Alt></dc:title></rdf:Description></rdf:RDF></x:xmpmeta>`)
+
+	t.Run("plain", func(t *testing.T) {
+		doc, err := xmpDocument(xmp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Beach", doc.Title())
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		size := make([]byte, 4)
+		binary.BigEndian.PutUint32(size, uint32(len(xmp)))
+		buf.Write(size)
+
+		w := zlib.NewWriter(&buf)
+
+		if _, err := w.Write(xmp); err != nil {
+			t.Fatal(err)
+		}
+
+		w.Close()
+
+		doc, err := xmpDocument(buf.Bytes())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Beach", doc.Title())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := xmpDocument(nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+/*
+
+Package lightroom reads Adobe Lightroom Classic catalogs for importing ratings, keywords and collections.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package lightroom
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// Pick flags as stored in Lightroom catalogs.
+const (
+	PickRejected = -1
+	PickNone     = 0
+	PickFlagged  = 1
+)
+
+// Image represents a catalog image and its metadata.
+type Image struct {
+	ID         int64
+	FileName   string   // Absolute file name as stored in the catalog.
+	RootName   string   // Name of the catalog root folder.
+	RelName    string   // File name relative to the root folder.
+	Rating     int      // Star rating from 0 to 5.
+	Pick       int      // Pick flag: 1 if flagged, -1 if rejected.
+	ColorLabel string   // Color label name, e.g. Red.
+	Title      string   // Title from the XMP metadata.
+	Caption    string   // IPTC caption.
+	Lat        float64  // GPS latitude, including manual edits.
+	Lng        float64  // GPS longitude, including manual edits.
+	Keywords   []string // Keywords including parents like "Places|Europe|Berlin".
+}
+
+// Flagged tests if the image was picked.
+func (img Image) Flagged() bool {
+	return img.Pick == PickFlagged
+}
+
+// Rejected tests if the image was rejected.
+func (img Image) Rejected() bool {
+	return img.Pick == PickRejected
+}
+
+// HasLatLng tests if the image has GPS coordinates.
+func (img Image) HasLatLng() bool {
+	return img.Lat != 0.0 || img.Lng != 0.0
+}
+
+// KeywordNames returns the keyword names without their parents.
+func (img Image) KeywordNames() (result []string) {
+	for _, k := range img.Keywords {
+		if i := strings.LastIndex(k, meta.HierarchySep); i >= 0 {
+			k = k[i+len(meta.HierarchySep):]
+		}
+
+		result = append(result, k)
+	}
+
+	return result
+}
+
+// Images represents a list of catalog images.
+type Images []Image
+
+// Collection represents a collection of images.
+type Collection struct {
+	ID     int64
+	Name   string
+	Set    string  // Name of the parent collection set, if any.
+	Images []int64 // Image IDs in collection order.
+}
+
+// Collections represents a list of collections.
+type Collections []Collection
+
+// Catalog represents the images and collections of a Lightroom catalog.
+type Catalog struct {
+	FileName    string
+	Images      Images
+	Collections Collections
+}
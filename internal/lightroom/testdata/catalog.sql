@@ -0,0 +1,52 @@
+CREATE TABLE AgLibraryRootFolder (id_local INTEGER PRIMARY KEY, absolutePath UNIQUE NOT NULL DEFAULT '', name NOT NULL DEFAULT '');
+CREATE TABLE AgLibraryFolder (id_local INTEGER PRIMARY KEY, pathFromRoot NOT NULL DEFAULT '', rootFolder INTEGER NOT NULL DEFAULT 0);
+CREATE TABLE AgLibraryFile (id_local INTEGER PRIMARY KEY, baseName NOT NULL DEFAULT '', extension NOT NULL DEFAULT '', folder INTEGER NOT NULL DEFAULT 0, idx_filename NOT NULL DEFAULT '', originalFilename NOT NULL DEFAULT '');
+CREATE TABLE Adobe_images (id_local INTEGER PRIMARY KEY, captureTime, colorLabels NOT NULL DEFAULT '', masterImage INTEGER, pick NOT NULL DEFAULT 0, rating, rootFile INTEGER NOT NULL DEFAULT 0);
+CREATE TABLE AgHarvestedExifMetadata (id_local INTEGER PRIMARY KEY, image INTEGER, gpsLatitude, gpsLongitude, hasGPS INTEGER);
+CREATE TABLE AgLibraryIPTC (id_local INTEGER PRIMARY KEY, caption, copyright, image INTEGER NOT NULL DEFAULT 0);
+CREATE TABLE Adobe_AdditionalMetadata (id_local INTEGER PRIMARY KEY, image INTEGER NOT NULL DEFAULT 0, xmp NOT NULL DEFAULT '');
+CREATE TABLE AgLibraryKeyword (id_local INTEGER PRIMARY KEY, lc_name, name, parent INTEGER);
+CREATE TABLE AgLibraryKeywordImage (id_local INTEGER PRIMARY KEY, image INTEGER NOT NULL DEFAULT 0, tag INTEGER NOT NULL DEFAULT 0);
+CREATE TABLE AgLibraryCollection (id_local INTEGER PRIMARY KEY, creationId NOT NULL DEFAULT '', name NOT NULL DEFAULT '', parent INTEGER, systemOnly NOT NULL DEFAULT '');
+CREATE TABLE AgLibraryCollectionImage (id_local INTEGER PRIMARY KEY, collection INTEGER NOT NULL DEFAULT 0, image INTEGER NOT NULL DEFAULT 0, pick NOT NULL DEFAULT 0, positionInCollection);
+
+INSERT INTO AgLibraryRootFolder VALUES (1, '/Users/jane/Pictures/', 'Pictures');
+INSERT INTO AgLibraryFolder VALUES (1, '2019/06/', 1);
+INSERT INTO AgLibraryFolder VALUES (2, '2020/', 1);
+
+INSERT INTO AgLibraryFile VALUES (1, 'IMG_4120', 'JPG', 1, 'IMG_4120.JPG', 'IMG_4120.JPG');
+INSERT INTO AgLibraryFile VALUES (2, 'beach_sand', 'jpg', 2, 'beach_sand.jpg', 'beach_sand.jpg');
+INSERT INTO AgLibraryFile VALUES (3, 'cat_black', 'jpg', 2, 'cat_black.jpg', 'cat_black.jpg');
+
+INSERT INTO Adobe_images VALUES (1, '2019-06-09T12:59:22', 'Red', NULL, 1, 5, 1);
+INSERT INTO Adobe_images VALUES (2, '2020-01-01T10:00:00', '', NULL, -1, NULL, 2);
+INSERT INTO Adobe_images VALUES (3, '2020-01-01T10:00:00', '', NULL, 0, 2, 3);
+INSERT INTO Adobe_images VALUES (4, '2019-06-09T12:59:22', 'Blue', 1, 0, 3, 1);
+
+INSERT INTO AgHarvestedExifMetadata VALUES (1, 1, 52.5208, 13.4094, 1);
+INSERT INTO AgHarvestedExifMetadata VALUES (2, 2, 10.0, 10.0, 0);
+
+INSERT INTO AgLibraryIPTC VALUES (1, 'Evening in Berlin', NULL, 1);
+
+INSERT INTO Adobe_AdditionalMetadata VALUES (1, 1, '<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title><rdf:Alt><rdf:li xml:lang="x-default">Alexanderplatz</rdf:li></rdf:Alt></dc:title></rdf:Description></rdf:RDF></x:xmpmeta>');
+
+INSERT INTO AgLibraryKeyword VALUES (1, NULL, NULL, NULL);
+INSERT INTO AgLibraryKeyword VALUES (2, 'places', 'Places', 1);
+INSERT INTO AgLibraryKeyword VALUES (3, 'europe', 'Europe', 2);
+INSERT INTO AgLibraryKeyword VALUES (4, 'berlin', 'Berlin', 3);
+INSERT INTO AgLibraryKeyword VALUES (5, 'sunset', 'Sunset', 1);
+
+INSERT INTO AgLibraryKeywordImage VALUES (1, 1, 4);
+INSERT INTO AgLibraryKeywordImage VALUES (2, 1, 5);
+INSERT INTO AgLibraryKeywordImage VALUES (3, 3, 5);
+
+INSERT INTO AgLibraryCollection VALUES (1, 'com.adobe.ag.library.group', 'Travel', NULL, 0);
+INSERT INTO AgLibraryCollection VALUES (2, 'com.adobe.ag.library.collection', 'Best of 2019', 1, 0);
+INSERT INTO AgLibraryCollection VALUES (3, 'com.adobe.ag.library.smart_collection', 'Five Stars', NULL, 0);
+INSERT INTO AgLibraryCollection VALUES (4, 'com.adobe.ag.library.collection', 'Quick Collection', NULL, 1);
+INSERT INTO AgLibraryCollection VALUES (5, 'com.adobe.ag.library.collection', 'Cats', NULL, 0);
+
+INSERT INTO AgLibraryCollectionImage VALUES (1, 2, 3, 0, 'z');
+INSERT INTO AgLibraryCollectionImage VALUES (2, 2, 1, 0, 'a');
+INSERT INTO AgLibraryCollectionImage VALUES (3, 4, 1, 0, 'a');
+INSERT INTO AgLibraryCollectionImage VALUES (4, 5, 3, 0, 'a');
@@ -0,0 +1,276 @@
+package meta
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// AdjustmentsMaxLength is the maximum length of adjustments encoded as JSON, see File.FileEdits.
+const AdjustmentsMaxLength = 2048
+
+// Adjustments represents image edits stored in an Apple .AAE sidecar file.
+type Adjustments struct {
+	Editor      string         `json:"Editor,omitempty"`      // Bundle ID of the editing app, e.g. com.apple.camera
+	Format      string         `json:"Format,omitempty"`      // Adjustment format identifier, e.g. com.apple.photo
+	Version     string         `json:"Version,omitempty"`     // Adjustment format version
+	EditedAt    time.Time      `json:"EditedAt"`              // Time of the last edit
+	Width       int            `json:"Width,omitempty"`       // Width of the original image
+	Height      int            `json:"Height,omitempty"`      // Height of the original image
+	Orientation int            `json:"Orientation,omitempty"` // Exif orientation of the edited image
+	Crop        AdjustmentCrop `json:"Crop"`                  // Crop area relative to the original image
+	Effects     []string       `json:"Effects,omitempty"`     // Identifiers of all enabled adjustments
+}
+
+// AdjustmentCrop represents the crop area of an edited image.
+type AdjustmentCrop struct {
+	X      int     `json:"X"`
+	Y      int     `json:"Y"`
+	Width  int     `json:"Width"`
+	Height int     `json:"Height"`
+	Angle  float64 `json:"Angle"`
+}
+
+// Exists tests if the image was cropped.
+func (c AdjustmentCrop) Exists() bool {
+	return c.Width > 0 && c.Height > 0
+}
+
+// ActualWidth returns the width of the edited image.
+func (m Adjustments) ActualWidth() int {
+	if m.Crop.Exists() {
+		return m.Crop.Width
+	}
+
+	return m.Width
+}
+
+// ActualHeight returns the height of the edited image.
+func (m Adjustments) ActualHeight() int {
+	if m.Crop.Exists() {
+		return m.Crop.Height
+	}
+
+	return m.Height
+}
+
+// JSON returns the adjustments as JSON string, e.g. to store them in the database. Effects that
+// would exceed AdjustmentsMaxLength are omitted.
+func (m Adjustments) JSON() string {
+	m.Editor = txt.Clip(m.Editor, txt.ClipSlug)
+	m.Format = txt.Clip(m.Format, txt.ClipSlug)
+	m.Version = txt.Clip(m.Version, txt.ClipKeyword)
+
+	effects := m.Effects
+	m.Effects = make([]string, 0, len(effects))
+
+	for _, e := range effects {
+		if e = txt.Clip(e, txt.ClipKeyword); e != "" {
+			m.Effects = append(m.Effects, e)
+		}
+	}
+
+	for {
+		data, err := json.Marshal(m)
+
+		if err != nil {
+			log.Debugf("metadata: %s (aae json)", err)
+			return ""
+		} else if len(data) <= AdjustmentsMaxLength {
+			return string(data)
+		} else if len(m.Effects) == 0 {
+			log.Debugf("metadata: adjustments exceed %d bytes (aae json)", AdjustmentsMaxLength)
+			return ""
+		}
+
+		m.Effects = m.Effects[:len(m.Effects)-1]
+	}
+}
+
+// aaeData represents the JSON encoded adjustment data of the com.apple.photo format.
+type aaeData struct {
+	Metadata struct {
+		Width       int `json:"masterWidth"`
+		Height      int `json:"masterHeight"`
+		Orientation int `json:"orientation"`
+	} `json:"metadata"`
+	Adjustments []struct {
+		Identifier string                 `json:"identifier"`
+		Enabled    bool                   `json:"enabled"`
+		Settings   map[string]interface{} `json:"settings"`
+	} `json:"adjustments"`
+}
+
+// AAE parses an Apple .AAE adjustments file.
+func AAE(fileName string) (result Adjustments, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (aae panic)\nstack: %s", e, txt.Quote(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	plist, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, fmt.Errorf("metadata: can't read %s (aae)", txt.Quote(filepath.Base(fileName)))
+	}
+
+	values, err := plistDict(plist)
+
+	if err != nil {
+		return result, fmt.Errorf("metadata: %s in %s (aae)", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	result.Editor = values["adjustmentEditorBundleID"]
+	result.Format = values["adjustmentFormatIdentifier"]
+	result.Version = values["adjustmentFormatVersion"]
+
+	if t, err := time.Parse(time.RFC3339, values["adjustmentTimestamp"]); err == nil {
+		result.EditedAt = t.UTC()
+	}
+
+	if result.Format == "" {
+		return result, fmt.Errorf("metadata: no adjustments found in %s (aae)", txt.Quote(filepath.Base(fileName)))
+	}
+
+	// Adjustment data is compressed JSON, at least in recent versions.
+	data, err := aaeAdjustmentData(values["adjustmentData"])
+
+	if err != nil {
+		log.Debugf("metadata: %s in %s (aae)", err, txt.Quote(filepath.Base(fileName)))
+		return result, nil
+	}
+
+	result.Width = data.Metadata.Width
+	result.Height = data.Metadata.Height
+	result.Orientation = data.Metadata.Orientation
+
+	for _, a := range data.Adjustments {
+		if !a.Enabled {
+			continue
+		}
+
+		result.Effects = append(result.Effects, a.Identifier)
+
+		switch a.Identifier {
+		case "Crop":
+			result.Crop = AdjustmentCrop{
+				X:      aaeInt(a.Settings["xOrigin"]),
+				Y:      aaeInt(a.Settings["yOrigin"]),
+				Width:  aaeInt(a.Settings["width"]),
+				Height: aaeInt(a.Settings["height"]),
+			}
+
+			if angle, ok := a.Settings["straightenAngle"].(float64); ok {
+				result.Crop.Angle = angle
+			}
+		case "Orientation":
+			if o := aaeInt(a.Settings["value"]); o > 0 {
+				result.Orientation = o
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// aaeAdjustmentData decodes and parses base64 encoded, compressed JSON adjustment data.
+func aaeAdjustmentData(s string) (result aaeData, err error) {
+	s = strings.Join(strings.Fields(s), "")
+
+	if s == "" {
+		return result, fmt.Errorf("no adjustment data")
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(s)
+
+	if err != nil {
+		return result, err
+	}
+
+	jsonData, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(jsonData, &result)
+
+	return result, err
+}
+
+// aaeInt returns a JSON number as int.
+func aaeInt(v interface{}) int {
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+
+	return 0
+}
+
+// plistDict returns the values of the top level dictionary in an XML property list as strings.
+func plistDict(data []byte) (map[string]string, error) {
+	result := make(map[string]string)
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	key := ""
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return result, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			// Only read values of the top level dictionary: plist > dict > key / value.
+			if depth != 3 {
+				continue
+			}
+
+			var value string
+
+			if err := decoder.DecodeElement(&value, &t); err != nil {
+				return result, err
+			}
+
+			depth--
+
+			switch {
+			case t.Name.Local == "key":
+				key = value
+			case key != "":
+				if t.Name.Local == "true" || t.Name.Local == "false" {
+					value = t.Name.Local
+				}
+
+				result[key] = strings.TrimSpace(value)
+				key = ""
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("empty property list")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAAE(t *testing.T) {
+	t.Run("IMG_4120.AAE", func(t *testing.T) {
+		result, err := AAE("testdata/IMG_4120.AAE")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "com.apple.camera", result.Editor)
+		assert.Equal(t, "com.apple.photo", result.Format)
+		assert.Equal(t, "1.4", result.Version)
+		assert.Equal(t, "2019-06-09 10:59:22 +0000 UTC", result.EditedAt.String())
+		assert.Equal(t, 4032, result.Width)
+		assert.Equal(t, 3024, result.Height)
+		assert.Equal(t, 1, result.Orientation)
+		assert.Equal(t, AdjustmentCrop{X: 781, Y: 0, Width: 3024, Height: 3024}, result.Crop)
+		assert.Equal(t, []string{"Effect", "SmartTone", "SmartColor", "Crop"}, result.Effects)
+		assert.Equal(t, 3024, result.ActualWidth())
+		assert.Equal(t, 3024, result.ActualHeight())
+		assert.Equal(t, `{"Editor":"com.apple.camera","Format":"com.apple.photo","Version":"1.4","EditedAt":"2019-06-09T10:59:22Z","Width":4032,"Height":3024,"Orientation":1,"Crop":{"X":781,"Y":0,"Width":3024,"Height":3024,"Angle":0},"Effects":["Effect","SmartTone","SmartColor","Crop"]}`, result.JSON())
+	})
+
+	t.Run("not an aae file", func(t *testing.T) {
+		_, err := AAE("testdata/apple-photos.xmp")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := AAE("testdata/xxx.AAE")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAdjustments_JSON(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		m := Adjustments{}
+
+		assert.Equal(t, `{"EditedAt":"0001-01-01T00:00:00Z","Crop":{"X":0,"Y":0,"Width":0,"Height":0,"Angle":0}}`, m.JSON())
+	})
+
+	t.Run("too many effects", func(t *testing.T) {
+		m := Adjustments{Editor: "com.apple.camera", Format: "com.apple.photo", Version: "1.4"}
+
+		for i := 0; i < 500; i++ {
+			m.Effects = append(m.Effects, "SmartTone")
+		}
+
+		result := m.JSON()
+
+		assert.LessOrEqual(t, len(result), AdjustmentsMaxLength)
+		assert.Contains(t, result, `"Format":"com.apple.photo"`)
+		assert.Contains(t, result, `"Effects":["SmartTone"`)
+		assert.Len(t, m.Effects, 500)
+	})
+}
@@ -2,6 +2,8 @@ package meta
 
 import (
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/pkg/s2"
@@ -11,25 +13,33 @@ import (
 
 // Data represents image meta data.
 type Data struct {
-	DocumentID   string        `meta:"ImageUniqueID,OriginalDocumentID,DocumentID"`
+	DocumentID   string        `meta:"ImageUniqueID,OriginalDocumentID,DocumentID,ContentIdentifier,MediaGroupUUID"`
 	InstanceID   string        `meta:"InstanceID,DocumentID"`
 	TakenAt      time.Time     `meta:"DateTimeOriginal,CreationDate,CreateDate,MediaCreateDate,ContentCreateDate,DateTimeDigitized,DateTime"`
 	TakenAtLocal time.Time     `meta:"DateTimeOriginal,CreationDate,CreateDate,MediaCreateDate,ContentCreateDate,DateTimeDigitized,DateTime"`
 	TimeZone     string        `meta:"-"`
 	Duration     time.Duration `meta:"Duration,MediaDuration,TrackDuration"`
 	Codec        string        `meta:"CompressorID,Compression,FileType"`
+	FPS          float64       `meta:"VideoFrameRate"`
 	Title        string        `meta:"Title"`
 	Subject      string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets"`
 	Keywords     string        `meta:"Keywords"`
-	Notes        string        `meta:"-"`
-	Artist       string        `meta:"Artist,Creator,OwnerName"`
-	Description  string        `meta:"Description"`
-	Copyright    string        `meta:"Rights,Copyright"`
+	Hierarchy    []string      `meta:"-"`
+	Notes        string        `meta:"Instructions,SpecialInstructions"`
+	Artist       string        `meta:"Artist,Creator,By-line,OwnerName"`
+	Description  string        `meta:"Description,Caption-Abstract"`
+	Copyright    string        `meta:"Rights,Copyright,CopyrightNotice"`
+	City         string        `meta:"City"`
+	State        string        `meta:"State,Province-State"`
+	Country      string        `meta:"Country,Country-PrimaryLocationName"`
 	Projection   string        `meta:"ProjectionType"`
 	CameraMake   string        `meta:"CameraMake,Make"`
 	CameraModel  string        `meta:"CameraModel,Model"`
 	CameraOwner  string        `meta:"OwnerName"`
 	CameraSerial string        `meta:"SerialNumber"`
+	BurstUUID    string        `meta:"BurstUUID"`
+	Sequence     int           `meta:"SequenceNumber"`
+	SubSec       string        `meta:"SubSecTimeOriginal,SubSecTimeDigitized,SubSecTime"`
 	LensMake     string        `meta:"LensMake"`
 	LensModel    string        `meta:"Lens,LensModel"`
 	Flash        bool          `meta:"-"`
@@ -49,6 +59,10 @@ type Data struct {
 	Orientation  int           `meta:"-"`
 	Rotation     int           `meta:"Rotation"`
 	Views        int           `meta:"-"`
+	Favorite     bool          `meta:"-"`
+	Archived     bool          `meta:"-"`
+	Rating       int           `meta:"Rating"`
+	ColorLabel   string        `meta:"Label"`
 	Albums       []string      `meta:"-"`
 	Error        error         `meta:"-"`
 	All          map[string]string
@@ -100,6 +114,42 @@ func (data Data) HasTimeAndPlace() bool {
 	return !data.TakenAt.IsZero() && data.Lat != 0 && data.Lng != 0
 }
 
+// InBurst tests if the photo was taken in burst or continuous shooting mode.
+func (data Data) InBurst() bool {
+	return data.BurstUUID != "" || data.Sequence > 0
+}
+
+// TakenAtSubSec returns the UTC time including fractions of a second, if known.
+func (data Data) TakenAtSubSec() time.Time {
+	if data.TakenAt.IsZero() {
+		return data.TakenAt
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+
+		return r
+	}, data.SubSec)
+
+	if digits == "" {
+		return data.TakenAt
+	}
+
+	if len(digits) > 9 {
+		digits = digits[:9]
+	}
+
+	ns, err := strconv.Atoi(digits + strings.Repeat("0", 9-len(digits)))
+
+	if err != nil {
+		return data.TakenAt
+	}
+
+	return data.TakenAt.Add(time.Duration(ns))
+}
+
 // ActualWidth is the width after rotating the media file if needed.
 func (data Data) ActualWidth() int {
 	if data.Orientation > 4 {
@@ -163,3 +163,51 @@ func TestData_HasTimeAndPlace(t *testing.T) {
 		assert.Equal(t, false, data.HasTimeAndPlace())
 	})
 }
+
+func TestData_InBurst(t *testing.T) {
+	t.Run("burst uuid", func(t *testing.T) {
+		data := Data{BurstUUID: "0C8B5B76-48E4-4F52-A5B0-7B1D31E4F2B1"}
+
+		assert.True(t, data.InBurst())
+	})
+
+	t.Run("sequence", func(t *testing.T) {
+		data := Data{Sequence: 3}
+
+		assert.True(t, data.InBurst())
+	})
+
+	t.Run("false", func(t *testing.T) {
+		data := Data{SubSec: "600"}
+
+		assert.False(t, data.InBurst())
+	})
+}
+
+func TestData_TakenAtSubSec(t *testing.T) {
+	taken := time.Date(2019, 1, 1, 12, 30, 15, 0, time.UTC)
+
+	t.Run("milliseconds", func(t *testing.T) {
+		data := Data{TakenAt: taken, SubSec: "023"}
+
+		assert.Equal(t, taken.Add(23*time.Millisecond), data.TakenAtSubSec())
+	})
+
+	t.Run("microseconds", func(t *testing.T) {
+		data := Data{TakenAt: taken, SubSec: "899614"}
+
+		assert.Equal(t, taken.Add(899614*time.Microsecond), data.TakenAtSubSec())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		data := Data{TakenAt: taken}
+
+		assert.Equal(t, taken, data.TakenAtSubSec())
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		data := Data{SubSec: "15"}
+
+		assert.True(t, data.TakenAtSubSec().IsZero())
+	})
+}
@@ -191,6 +191,18 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 		}
 	}
 
+	if value, ok := tags["Rating"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Rating = SanitizeRating(i)
+		}
+	}
+
+	if value, ok := tags["RatingPercent"]; ok && data.Rating == 0 {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Rating = RatingFromPercent(i)
+		}
+	}
+
 	if value, ok := tags["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
@@ -268,6 +280,10 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 		}
 	}
 
+	if value, ok := tags["SubSecTimeOriginal"]; ok {
+		data.SubSec = strings.TrimSpace(value)
+	}
+
 	if value, ok := tags["Flash"]; ok {
 		if i, err := strconv.Atoi(value); err == nil && i&1 == 1 {
 			data.AddKeyword(KeywordFlash)
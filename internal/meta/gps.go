@@ -1,8 +1,10 @@
 package meta
 
 import (
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/dsoprea/go-exif/v3"
 )
@@ -50,7 +52,7 @@ func GpsToDecimal(s string) float32 {
 	co := GpsCoordsRegexp.FindAllString(s, -1)
 	re := GpsRefRegexp.FindAllString(s, -1)
 
-	if len(co) != 3 || len(re) != 1 {
+	if len(co) < 2 || len(co) > 3 || len(re) != 1 {
 		return 0
 	}
 
@@ -58,7 +60,11 @@ func GpsToDecimal(s string) float32 {
 		Orientation: re[0][0],
 		Degrees:     GpsCoord(co[0]),
 		Minutes:     GpsCoord(co[1]),
-		Seconds:     GpsCoord(co[2]),
+	}
+
+	// Seconds are optional, e.g. "52,27.5814N" in XMP.
+	if len(co) == 3 {
+		latDeg.Seconds = GpsCoord(co[2])
 	}
 
 	return float32(latDeg.Decimal())
@@ -79,3 +85,68 @@ func GpsCoord(s string) float64 {
 
 	return result
 }
+
+// iso6709Regexp matches ISO 6709 coordinates like "+52.5200+013.4050+034.000/".
+var iso6709Regexp = regexp.MustCompile(`^([+-]\d+(?:\.\d+)?)([+-]\d+(?:\.\d+)?)([+-]\d+(?:\.\d+)?)?`)
+
+// ISO6709 returns latitude, longitude and altitude from an ISO 6709 location string as used in videos.
+// Degrees may be followed by minutes and seconds, e.g. "+5231.2+01324.3/".
+func ISO6709(s string) (lat, lng float32, altitude int, ok bool) {
+	m := iso6709Regexp.FindStringSubmatch(strings.TrimSpace(s))
+
+	if m == nil {
+		return 0, 0, 0, false
+	}
+
+	latDeg, latOk := iso6709Degrees(m[1], 2)
+	lngDeg, lngOk := iso6709Degrees(m[2], 3)
+
+	if !latOk || !lngOk || latDeg < -90 || latDeg > 90 || lngDeg < -180 || lngDeg > 180 || latDeg == 0 && lngDeg == 0 {
+		return 0, 0, 0, false
+	}
+
+	if m[3] != "" {
+		if alt, err := strconv.ParseFloat(m[3], 64); err == nil {
+			altitude = int(math.Round(alt))
+		}
+	}
+
+	return float32(latDeg), float32(lngDeg), altitude, true
+}
+
+// iso6709Degrees converts a signed ISO 6709 coordinate to decimal degrees. The number of integer digits
+// determines whether the value contains degrees, minutes and seconds.
+func iso6709Degrees(s string, digits int) (float64, bool) {
+	sign := 1.0
+
+	if s[0] == '-' {
+		sign = -1.0
+	}
+
+	s = s[1:]
+	intLen := len(s)
+
+	if i := strings.Index(s, "."); i >= 0 {
+		intLen = i
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	switch intLen {
+	case digits:
+		return sign * value, true
+	case digits + 2:
+		deg := math.Floor(value / 100)
+		return sign * (deg + (value-deg*100)/60), true
+	case digits + 4:
+		deg := math.Floor(value / 10000)
+		min := math.Floor((value - deg*10000) / 100)
+		return sign * (deg + min/60 + (value-deg*10000-min*100)/3600), true
+	}
+
+	return 0, false
+}
@@ -57,6 +57,11 @@ func TestGpsToDecimal(t *testing.T) {
 		assert.Equal(t, float32(51.254852), r)
 	})
 
+	t.Run("xmp string", func(t *testing.T) {
+		assert.InDelta(t, float32(52.45969), GpsToDecimal("52,27.5814N"), 0.00001)
+		assert.InDelta(t, float32(-13.321832), GpsToDecimal("13,19.3099W"), 0.00001)
+	})
+
 	t.Run("empty string", func(t *testing.T) {
 		r := GpsToDecimal("")
 		assert.Equal(t, float32(0), r)
@@ -0,0 +1,45 @@
+package meta
+
+import (
+	"strings"
+)
+
+const (
+	HierarchySep        = "|"
+	HierarchySepDigikam = "/"
+)
+
+// HierarchyPath splits a hierarchical keyword like "Places|Europe|Berlin" into its sanitized levels.
+func HierarchyPath(s, sep string) (result []string) {
+	for _, level := range strings.Split(s, sep) {
+		if level = SanitizeString(level); level != "" {
+			result = append(result, level)
+		}
+	}
+
+	return result
+}
+
+// AddHierarchy appends a hierarchical keyword if it has at least two levels and does not exist yet.
+func (data *Data) AddHierarchy(s, sep string) {
+	// Lightroom separators take precedence, e.g. for digiKam tags like "Places|Europe|Berlin".
+	if strings.Contains(s, HierarchySep) {
+		sep = HierarchySep
+	}
+
+	path := HierarchyPath(s, sep)
+
+	if len(path) < 2 {
+		return
+	}
+
+	h := strings.Join(path, HierarchySep)
+
+	for _, existing := range data.Hierarchy {
+		if strings.EqualFold(existing, h) {
+			return
+		}
+	}
+
+	data.Hierarchy = append(data.Hierarchy, h)
+}
@@ -0,0 +1,42 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHierarchyPath(t *testing.T) {
+	t.Run("lightroom", func(t *testing.T) {
+		assert.Equal(t, []string{"Places", "Europe", "Berlin"}, HierarchyPath("Places|Europe|Berlin", HierarchySep))
+	})
+	t.Run("digikam", func(t *testing.T) {
+		assert.Equal(t, []string{"People", "Family", "Anna"}, HierarchyPath("People/Family/Anna", HierarchySepDigikam))
+	})
+	t.Run("empty levels", func(t *testing.T) {
+		assert.Equal(t, []string{"Places", "Berlin"}, HierarchyPath(" Places || Berlin ", HierarchySep))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, HierarchyPath("", HierarchySep))
+	})
+}
+
+func TestData_AddHierarchy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		data := NewData()
+		data.AddHierarchy("Places|Europe|Berlin", HierarchySep)
+		data.AddHierarchy("places|europe|berlin", HierarchySep)
+		data.AddHierarchy("People/Family/Anna", HierarchySepDigikam)
+		assert.Equal(t, []string{"Places|Europe|Berlin", "People|Family|Anna"}, data.Hierarchy)
+	})
+	t.Run("digikam with lightroom separator", func(t *testing.T) {
+		data := NewData()
+		data.AddHierarchy("Places|AC/DC", HierarchySepDigikam)
+		assert.Equal(t, []string{"Places|AC/DC"}, data.Hierarchy)
+	})
+	t.Run("single level", func(t *testing.T) {
+		data := NewData()
+		data.AddHierarchy("Berlin", HierarchySep)
+		assert.Empty(t, data.Hierarchy)
+	})
+}
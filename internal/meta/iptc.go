@@ -0,0 +1,194 @@
+package meta
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	iptc "github.com/dsoprea/go-iptc"
+	jpegstructure "github.com/dsoprea/go-jpeg-image-structure"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// IPTC IIM datasets, see https://www.iptc.org/std/IIM/4.2/specification/IIMV4.2.pdf
+var (
+	IptcObjectName   = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 5}
+	IptcKeywords     = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 25}
+	IptcInstructions = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 40}
+	IptcDateCreated  = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 55}
+	IptcTimeCreated  = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 60}
+	IptcByline       = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 80}
+	IptcCity         = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 90}
+	IptcState        = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 95}
+	IptcCountryCode  = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 100}
+	IptcCountry      = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 101}
+	IptcHeadline     = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 105}
+	IptcCopyright    = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 116}
+	IptcCaption      = iptc.StreamTagKey{RecordNumber: 2, DatasetNumber: 120}
+)
+
+// IPTC parses a JPEG file for IPTC IIM metadata and returns it as Data struct.
+func IPTC(fileName string, fileType fs.FileFormat) (data Data, err error) {
+	err = data.IPTC(fileName, fileType)
+
+	return data, err
+}
+
+// IPTC parses a JPEG file for IPTC IIM metadata. Existing values, e.g. from Exif, are not overwritten.
+func (data *Data) IPTC(fileName string, fileType fs.FileFormat) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (iptc panic)\nstack: %s", e, txt.Quote(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	logName := txt.Quote(filepath.Base(fileName))
+
+	if fileType != fs.FormatJpeg {
+		return fmt.Errorf("metadata: iptc not supported in %s", logName)
+	}
+
+	mc, err := jpegstructure.NewJpegMediaParser().ParseFile(fileName)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (parse jpeg)", err, logName)
+	}
+
+	sl, ok := mc.(*jpegstructure.SegmentList)
+
+	if !ok {
+		return fmt.Errorf("metadata: unexpected segment list in %s (parse jpeg)", logName)
+	}
+
+	tags, err := sl.Iptc()
+
+	if err != nil {
+		return fmt.Errorf("metadata: no iptc data in %s", logName)
+	}
+
+	if data.Title == "" {
+		if value := IptcValue(tags, IptcObjectName); value != "" {
+			data.Title = SanitizeTitle(value)
+		} else {
+			data.Title = SanitizeTitle(IptcValue(tags, IptcHeadline))
+		}
+	}
+
+	if data.Description == "" {
+		data.Description = SanitizeDescription(IptcValue(tags, IptcCaption))
+	}
+
+	if data.Artist == "" {
+		data.Artist = SanitizeString(IptcValue(tags, IptcByline))
+	}
+
+	if data.Copyright == "" {
+		data.Copyright = SanitizeString(IptcValue(tags, IptcCopyright))
+	}
+
+	if data.Notes == "" {
+		data.Notes = SanitizeString(IptcValue(tags, IptcInstructions))
+	}
+
+	if data.City == "" {
+		data.City = SanitizeString(IptcValue(tags, IptcCity))
+	}
+
+	if data.State == "" {
+		data.State = SanitizeString(IptcValue(tags, IptcState))
+	}
+
+	if data.Country == "" {
+		if value := IptcValue(tags, IptcCountry); value != "" {
+			data.Country = SanitizeString(value)
+		} else {
+			data.Country = SanitizeString(IptcValue(tags, IptcCountryCode))
+		}
+	}
+
+	for _, w := range IptcValues(tags, IptcKeywords) {
+		if w = SanitizeString(w); w == "" || data.HasKeyword(w) {
+			continue
+		} else if data.Keywords == "" {
+			data.Keywords = w
+		} else {
+			data.Keywords += ", " + w
+		}
+	}
+
+	data.AddLocationKeywords()
+
+	if data.TakenAt.IsZero() {
+		if taken, local := IptcTime(IptcValue(tags, IptcDateCreated), IptcValue(tags, IptcTimeCreated)); !taken.IsZero() {
+			data.TakenAt = taken
+			data.TakenAtLocal = local
+		}
+	}
+
+	return nil
+}
+
+// IptcValues returns all values of a repeatable IPTC dataset as strings.
+func IptcValues(tags map[iptc.StreamTagKey][]iptc.TagData, key iptc.StreamTagKey) (result []string) {
+	for _, value := range tags[key] {
+		if s := IptcString(value); s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// IptcValue returns the first value of an IPTC dataset as string.
+func IptcValue(tags map[iptc.StreamTagKey][]iptc.TagData, key iptc.StreamTagKey) string {
+	if values := IptcValues(tags, key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// IptcString converts IPTC data to a string, assuming ISO 8859-1 if it's not valid UTF-8.
+func IptcString(b []byte) string {
+	if utf8.Valid(b) {
+		return strings.TrimSpace(string(b))
+	}
+
+	runes := make([]rune, len(b))
+
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+
+	return strings.TrimSpace(string(runes))
+}
+
+// IptcTime returns the UTC and local time from IPTC date and time values like "20190512" and "171353+0200".
+func IptcTime(date, clock string) (taken, local time.Time) {
+	if len(date) != 8 {
+		return taken, local
+	}
+
+	if len(clock) >= 11 {
+		if t, err := time.Parse("20060102150405-0700", date+clock[:11]); err == nil {
+			local = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+			return t.UTC(), local
+		}
+	}
+
+	if len(clock) >= 6 {
+		if t, err := time.Parse("20060102150405", date+clock[:6]); err == nil {
+			return t, t
+		}
+	}
+
+	if t, err := time.Parse("20060102", date); err == nil {
+		return t, t
+	}
+
+	return taken, local
+}
@@ -0,0 +1,99 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPTC(t *testing.T) {
+	t.Run("iptc.jpg", func(t *testing.T) {
+		data, err := IPTC("testdata/iptc.jpg", fs.FormatJpeg)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Brandenburger Tor", data.Title)
+		assert.Equal(t, "The gate at night", data.Description)
+		assert.Equal(t, "Anna Müller", data.Artist)
+		// The credit line isn't a copyright notice.
+		assert.Equal(t, "", data.Copyright)
+		assert.Equal(t, "Do not crop", data.Notes)
+		assert.Equal(t, "Berlin", data.City)
+		assert.Equal(t, "Berlin", data.State)
+		assert.Equal(t, "Germany", data.Country)
+		assert.Equal(t, "Gate, Night, berlin, germany", data.Keywords)
+		assert.Equal(t, "2019-05-12T15:13:53Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2019-05-12T17:13:53Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+	})
+
+	t.Run("photoshop.jpg", func(t *testing.T) {
+		data, err := IPTC("testdata/photoshop.jpg", fs.FormatJpeg)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Night Shift / Berlin / 2020", data.Title)
+		assert.Equal(t, "Example file for development", data.Description)
+		assert.Equal(t, "Michael Mayer", data.Artist)
+		assert.Equal(t, "This is a legal notice", data.Copyright)
+		assert.Equal(t, "desk, coffee, compuer", data.Keywords)
+		assert.Equal(t, "2020-01-01T17:28:24Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+	})
+
+	t.Run("existing values", func(t *testing.T) {
+		data := Data{Title: "Exif Title", Keywords: "night"}
+
+		if err := data.IPTC("testdata/iptc.jpg", fs.FormatJpeg); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Exif Title", data.Title)
+		assert.Equal(t, "night, Gate, berlin, germany", data.Keywords)
+	})
+
+	t.Run("no iptc", func(t *testing.T) {
+		_, err := IPTC("testdata/no-exif-data.jpg", fs.FormatJpeg)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		_, err := IPTC("testdata/iptc.jpg", fs.FormatPng)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIptcString(t *testing.T) {
+	t.Run("utf8", func(t *testing.T) {
+		assert.Equal(t, "Müller", IptcString([]byte("Müller ")))
+	})
+	t.Run("latin1", func(t *testing.T) {
+		assert.Equal(t, "Müller", IptcString([]byte{'M', 0xfc, 'l', 'l', 'e', 'r'}))
+	})
+}
+
+func TestIptcTime(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		taken, local := IptcTime("20190512", "171353+0200")
+		assert.Equal(t, "2019-05-12 15:13:53 +0000 UTC", taken.String())
+		assert.Equal(t, "2019-05-12 17:13:53 +0000 UTC", local.String())
+	})
+	t.Run("no offset", func(t *testing.T) {
+		taken, local := IptcTime("20110710", "193428")
+		assert.Equal(t, "2011-07-10 19:34:28 +0000 UTC", taken.String())
+		assert.Equal(t, taken, local)
+	})
+	t.Run("date only", func(t *testing.T) {
+		taken, _ := IptcTime("20110710", "")
+		assert.Equal(t, "2011-07-10 00:00:00 +0000 UTC", taken.String())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		taken, _ := IptcTime("2011", "")
+		assert.True(t, taken.IsZero())
+	})
+}
@@ -96,6 +96,23 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		}
 	}
 
+	// Add hierarchical keywords, e.g. "Places|Europe|Berlin".
+	for _, r := range jsonValues["HierarchicalSubject"].Array() {
+		data.AddHierarchy(r.String(), HierarchySep)
+	}
+
+	for _, r := range jsonValues["TagsList"].Array() {
+		data.AddHierarchy(r.String(), HierarchySepDigikam)
+	}
+
+	// Use rating percentage if there is no star rating.
+	if value, ok := jsonValues["RatingPercent"]; ok && data.Rating == 0 {
+		data.Rating = RatingFromPercent(int(value.Int()))
+	}
+
+	data.Rating = SanitizeRating(data.Rating)
+	data.ColorLabel = SanitizeColorLabel(data.ColorLabel)
+
 	// Set latitude and longitude if known and not already set.
 	if data.Lat == 0 && data.Lng == 0 {
 		if data.GPSPosition != "" {
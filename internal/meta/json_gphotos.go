@@ -1,22 +1,33 @@
 package meta
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"gopkg.in/ugjka/go-tz.v2/tz"
 )
 
+// GPhoto represents photo metadata from Google Photos Takeout. People tags are imported as subject
+// and keywords, since there are no people entities yet. Photos from shared albums get the "shared" keyword.
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Views       int       `json:"imageViews,string"`
+	Geo         GGeo      `json:"geoData"`
+	TakenAt     GTime     `json:"photoTakenTime"`
+	CreatedAt   GTime     `json:"creationTime"`
+	UpdatedAt   GTime     `json:"modificationTime"`
+	Favorited   bool      `json:"favorited"`
+	Archived    bool      `json:"archived"`
+	Trashed     bool      `json:"trashed"`
+	People      []GPerson `json:"people"`
+	Origin      GOrigin   `json:"googlePhotosOrigin"`
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -27,6 +38,30 @@ func (m GPhoto) SanitizedDescription() string {
 	return SanitizeDescription(m.Description)
 }
 
+// PeopleNames returns the names of all people tagged in the photo.
+func (m GPhoto) PeopleNames() (names []string) {
+	for _, p := range m.People {
+		if name := SanitizeTitle(p.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+type GPerson struct {
+	Name string `json:"name"`
+}
+
+type GOrigin struct {
+	FromSharedAlbum *struct{} `json:"fromSharedAlbum"`
+}
+
+// Shared tests if the photo was added from a shared album.
+func (m GOrigin) Shared() bool {
+	return m.FromSharedAlbum != nil
+}
+
 type GMeta struct {
 	Album GAlbum `json:"albumData"`
 }
@@ -44,6 +79,47 @@ func (m GAlbum) Exists() bool {
 	return m.Title != ""
 }
 
+// GAlbumFile reads album metadata from a Google Photos Takeout file, usually named "metadata.json".
+func GAlbumFile(fileName string) (result GAlbum, err error) {
+	jsonData, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	} else if bytes.Contains(jsonData, []byte("photoTakenTime")) {
+		return result, fmt.Errorf("metadata: %s contains photo metadata", filepath.Base(fileName))
+	}
+
+	// Older exports wrap the album metadata in an "albumData" object.
+	p := GMeta{}
+
+	if err := json.Unmarshal(jsonData, &p); err != nil {
+		return result, err
+	} else if p.Album.Exists() {
+		return p.Album, nil
+	}
+
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return result, err
+	} else if !result.Exists() {
+		return result, fmt.Errorf("metadata: no album title in %s", filepath.Base(fileName))
+	}
+
+	return result, nil
+}
+
+// GPhotoFile reads photo metadata from a Google Photos Takeout JSON sidecar file.
+func GPhotoFile(fileName string) (result GPhoto, err error) {
+	jsonData, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return result, err
+	} else if err := json.Unmarshal(jsonData, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
 type GGeo struct {
 	Lat      float64 `json:"latitude"`
 	Lng      float64 `json:"longitude"`
@@ -114,6 +190,28 @@ func (data *Data) GPhoto(jsonData []byte) (err error) {
 		data.Views = p.Views
 	}
 
+	if p.Favorited {
+		data.Favorite = true
+	}
+
+	if p.Archived || p.Trashed {
+		data.Archived = true
+	}
+
+	if p.Origin.Shared() {
+		data.AddKeyword(KeywordShared)
+	}
+
+	if names := p.PeopleNames(); len(names) > 0 {
+		if data.Subject == "" {
+			data.Subject = strings.Join(names, ", ")
+		}
+
+		for _, name := range names {
+			data.AddKeyword(name)
+		}
+	}
+
 	if p.TakenAt.Exists() {
 		if data.TakenAt.IsZero() {
 			data.TakenAt = p.TakenAt.Time()
@@ -35,6 +35,20 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 	})
 
+	t.Run("burst.json", func(t *testing.T) {
+		data, err := JSON("testdata/burst.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "7C2D5A39-9E4F-4A11-8D6C-1F5B0E2A8C44", data.BurstUUID)
+		assert.Equal(t, "364", data.SubSec)
+		assert.True(t, data.InBurst())
+		assert.Equal(t, "2021-02-13 15:24:07 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, "2021-02-13 15:24:07.364 +0000 UTC", data.TakenAtSubSec().String())
+	})
+
 	t.Run("gopher-telegram.json", func(t *testing.T) {
 		data, err := JSON("testdata/gopher-telegram.json", "")
 
@@ -415,6 +429,21 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, 0, data.Views)
 	})
 
+	t.Run("gphotos-5.json", func(t *testing.T) {
+		data, err := JSON("testdata/gphotos-5.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-07-06 10:51:27 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, 12, data.Views)
+		assert.True(t, data.Favorite)
+		assert.True(t, data.Archived)
+		assert.Equal(t, "Jane Doe, John Doe", data.Subject)
+		assert.Equal(t, "shared, jane doe, john doe", data.Keywords)
+	})
+
 	t.Run("gphotos-album.json", func(t *testing.T) {
 		data, err := JSON("testdata/gphotos-album.json", "")
 
@@ -529,6 +558,7 @@ func TestJSON(t *testing.T) {
 
 		// t.Logf("DATA: %+v", data)
 
+		assert.Equal(t, 4, data.Rating)
 		assert.Equal(t, "jpeg", data.Codec)
 		assert.Equal(t, "", data.Artist)
 		assert.Equal(t, "2020-10-17T15:48:24Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
@@ -536,6 +566,7 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "Europe/Berlin", data.TimeZone)
 		assert.Equal(t, "", data.Title)
 		assert.Equal(t, "Berlin, Shop", data.Keywords)
+		assert.Empty(t, data.Hierarchy)
 		assert.Equal(t, "", data.Description)
 		assert.Equal(t, "", data.Copyright)
 		assert.Equal(t, 375, data.Height)
@@ -670,6 +701,16 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "", data.LensModel)
 	})
 
+	t.Run("hierarchy.json", func(t *testing.T) {
+		data, err := JSON("testdata/hierarchy.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Places|Europe|Berlin", "People|Family|Anna"}, data.Hierarchy)
+	})
+
 	t.Run("subject-1.json", func(t *testing.T) {
 		data, err := JSON("testdata/subject-1.json", "")
 
@@ -722,3 +763,63 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "holiday, greetings", data.Keywords)
 	})
 }
+
+func TestGAlbumFile(t *testing.T) {
+	t.Run("gphotos-album.json", func(t *testing.T) {
+		album, err := GAlbumFile("testdata/gphotos-album.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "iPhone", album.Title)
+		assert.Equal(t, "2011-11-07 21:34:34 +0000 UTC", album.Date.Time().String())
+	})
+
+	t.Run("gphotos-album-2.json", func(t *testing.T) {
+		album, err := GAlbumFile("testdata/gphotos-album-2.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Summer Trip", album.Title)
+		assert.Equal(t, "Sailing on the Baltic Sea", album.Description)
+		assert.Equal(t, "Rügen", album.Location)
+		assert.Equal(t, 2019, album.Date.Time().Year())
+	})
+
+	t.Run("gphotos-1.json", func(t *testing.T) {
+		_, err := GAlbumFile("testdata/gphotos-1.json")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := GAlbumFile("testdata/xxx.json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGPhotoFile(t *testing.T) {
+	t.Run("gphotos-5.json", func(t *testing.T) {
+		photo, err := GPhotoFile("testdata/gphotos-5.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, photo.Origin.Shared())
+	})
+
+	t.Run("gphotos-1.json", func(t *testing.T) {
+		photo, err := GPhotoFile("testdata/gphotos-1.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.Origin.Shared())
+	})
+}
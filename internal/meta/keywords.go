@@ -8,6 +8,7 @@ const (
 	KeywordBurst           = "burst"
 	KeywordPanorama        = "panorama"
 	KeywordEquirectangular = "equirectangular"
+	KeywordShared          = "shared"
 )
 
 var AutoKeywords = []string{KeywordHdr, KeywordBurst, KeywordPanorama, KeywordEquirectangular}
@@ -20,13 +21,24 @@ func (data *Data) AddKeyword(w string) {
 		return
 	}
 
-	if !strings.Contains(data.Keywords, w) {
-		if data.Keywords == "" {
-			data.Keywords = w
-		} else {
-			data.Keywords += ", " + w
+	if data.Keywords == "" {
+		data.Keywords = w
+	} else if !data.HasKeyword(w) {
+		data.Keywords += ", " + w
+	}
+}
+
+// HasKeyword tests if the keywords contain a word, ignoring case.
+func (data *Data) HasKeyword(w string) bool {
+	w = strings.ToLower(strings.TrimSpace(w))
+
+	for _, k := range strings.Split(data.Keywords, ",") {
+		if strings.ToLower(strings.TrimSpace(k)) == w {
+			return true
 		}
 	}
+
+	return false
 }
 
 // AutoAddKeywords automatically adds relevant keywords from a string (e.g. description).
@@ -43,3 +55,10 @@ func (data *Data) AutoAddKeywords(s string) {
 		}
 	}
 }
+
+// AddLocationKeywords adds city, state and country names as keywords.
+func (data *Data) AddLocationKeywords() {
+	for _, w := range []string{data.City, data.State, data.Country} {
+		data.AddKeyword(w)
+	}
+}
@@ -19,6 +19,19 @@ func TestData_AddKeyword(t *testing.T) {
 		data.AddKeyword("BAZ")
 
 		assert.Equal(t, "foobar, baz", data.Keywords)
+
+		data.AddKeyword("baz")
+
+		assert.Equal(t, "foobar, baz", data.Keywords)
+	})
+
+	t.Run("substring", func(t *testing.T) {
+		data := NewData()
+
+		data.AddKeyword("party")
+		data.AddKeyword("art")
+
+		assert.Equal(t, "party, art", data.Keywords)
 	})
 
 	t.Run("ignore", func(t *testing.T) {
@@ -32,6 +45,14 @@ func TestData_AddKeyword(t *testing.T) {
 	})
 }
 
+func TestData_HasKeyword(t *testing.T) {
+	data := Data{Keywords: "Party, night"}
+
+	assert.True(t, data.HasKeyword("party"))
+	assert.True(t, data.HasKeyword("Night"))
+	assert.False(t, data.HasKeyword("art"))
+}
+
 func TestData_AutoAddKeywords(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		data := NewData()
@@ -63,3 +84,25 @@ func TestData_AutoAddKeywords(t *testing.T) {
 		assert.Equal(t, "", data.Keywords)
 	})
 }
+
+func TestData_AddLocationKeywords(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		data := NewData()
+
+		data.City = "Berlin"
+		data.State = "Berlin"
+		data.Country = "Germany"
+
+		data.AddLocationKeywords()
+
+		assert.Equal(t, "berlin, germany", data.Keywords)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		data := NewData()
+
+		data.AddLocationKeywords()
+
+		assert.Equal(t, "", data.Keywords)
+	})
+}
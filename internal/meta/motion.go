@@ -0,0 +1,135 @@
+package meta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// xmpSignature is the header of XMP packets in JPEG APP1 segments.
+var xmpSignature = []byte("http://ns.adobe.com/xap/1.0/\x00")
+
+// MotionVideo represents the position of a video embedded in a motion photo.
+type MotionVideo struct {
+	Offset int64 // Start of the video in bytes from the beginning of the file.
+	Length int64 // Size of the video in bytes.
+}
+
+// MotionPhoto finds the video embedded in a Samsung or Google motion photo based on its XMP metadata.
+func MotionPhoto(fileName string) (video MotionVideo, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (motion photo panic)\nstack: %s", e, txt.Quote(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	logName := txt.Quote(filepath.Base(fileName))
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return video, fmt.Errorf("metadata: can't open %s (motion photo)", logName)
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return video, fmt.Errorf("metadata: can't read %s (motion photo)", logName)
+	}
+
+	packet, err := jpegXmp(file)
+
+	if err != nil {
+		return video, fmt.Errorf("metadata: %s in %s (motion photo)", err, logName)
+	}
+
+	doc := XmpDocument{}
+
+	if err := xml.Unmarshal(packet, &doc); err != nil {
+		return video, fmt.Errorf("metadata: invalid xmp in %s (motion photo)", logName)
+	}
+
+	if video = doc.MotionVideo(info.Size()); video.Length == 0 {
+		return video, fmt.Errorf("metadata: %s is not a motion photo", logName)
+	}
+
+	// Make sure the offset points to an MP4 file type box.
+	header := make([]byte, 8)
+
+	if _, err := file.ReadAt(header, video.Offset); err != nil || string(header[4:]) != "ftyp" {
+		return MotionVideo{}, fmt.Errorf("metadata: invalid video offset in %s (motion photo)", logName)
+	}
+
+	return video, nil
+}
+
+// jpegXmp returns the XMP packet embedded in a JPEG file.
+func jpegXmp(r io.Reader) ([]byte, error) {
+	br := bufio.NewReader(r)
+	soi := make([]byte, 2)
+
+	if _, err := io.ReadFull(br, soi); err != nil || soi[0] != 0xFF || soi[1] != 0xD8 {
+		return nil, fmt.Errorf("no jpeg header")
+	}
+
+	for {
+		b, err := br.ReadByte()
+
+		if err != nil {
+			return nil, fmt.Errorf("no xmp data")
+		} else if b != 0xFF {
+			continue
+		}
+
+		marker, err := br.ReadByte()
+
+		// Skip fill bytes.
+		for err == nil && marker == 0xFF {
+			marker, err = br.ReadByte()
+		}
+
+		if err != nil || marker == 0xDA || marker == 0xD9 {
+			// Metadata segments are located before the image data.
+			return nil, fmt.Errorf("no xmp data")
+		} else if marker == 0x01 || marker >= 0xD0 && marker <= 0xD7 {
+			// Markers without payload.
+			continue
+		}
+
+		var size uint16
+
+		if err := binary.Read(br, binary.BigEndian, &size); err != nil || size < 2 {
+			return nil, fmt.Errorf("invalid jpeg segment")
+		}
+
+		n := int(size) - 2
+
+		if marker != 0xE1 || n <= len(xmpSignature) {
+			if _, err := br.Discard(n); err != nil {
+				return nil, fmt.Errorf("invalid jpeg segment")
+			}
+
+			continue
+		}
+
+		data := make([]byte, n)
+
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, fmt.Errorf("invalid jpeg segment")
+		}
+
+		if bytes.HasPrefix(data, xmpSignature) {
+			return data[len(xmpSignature):], nil
+		}
+	}
+}
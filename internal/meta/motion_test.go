@@ -0,0 +1,49 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMotionPhoto(t *testing.T) {
+	t.Run("motion-photo.jpg", func(t *testing.T) {
+		video, err := MotionPhoto("testdata/motion-photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1908), video.Offset)
+		assert.Equal(t, int64(586), video.Length)
+	})
+
+	t.Run("micro-video.jpg", func(t *testing.T) {
+		video, err := MotionPhoto("testdata/micro-video.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1389), video.Offset)
+		assert.Equal(t, int64(586), video.Length)
+	})
+
+	t.Run("apple-photos.xmp", func(t *testing.T) {
+		_, err := MotionPhoto("testdata/apple-photos.xmp")
+
+		assert.EqualError(t, err, "metadata: no jpeg header in apple-photos.xmp (motion photo)")
+	})
+
+	t.Run("iptc.jpg", func(t *testing.T) {
+		_, err := MotionPhoto("testdata/iptc.jpg")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := MotionPhoto("testdata/xxx.jpg")
+
+		assert.EqualError(t, err, "metadata: can't open xxx.jpg (motion photo)")
+	})
+}
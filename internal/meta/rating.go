@@ -0,0 +1,47 @@
+package meta
+
+import (
+	"math"
+	"strings"
+)
+
+// Star ratings, photos rated with -1 have been rejected.
+const (
+	RatingRejected = -1
+	RatingMin      = 1
+	RatingMax      = 5
+)
+
+// ColorLabels contains the supported color labels, e.g. as used by Lightroom.
+var ColorLabels = []string{"red", "yellow", "green", "blue", "purple"}
+
+// SanitizeRating returns a valid star rating, or 0 if unrated.
+func SanitizeRating(rating int) int {
+	if rating < RatingRejected || rating > RatingMax {
+		return 0
+	}
+
+	return rating
+}
+
+// RatingFromPercent converts a rating percentage as used by Windows to stars.
+func RatingFromPercent(percent int) int {
+	if percent <= 0 || percent > 100 {
+		return 0
+	}
+
+	return int(math.Min(math.Round(float64(percent)/25)+1, RatingMax))
+}
+
+// SanitizeColorLabel returns a supported color label in lowercase, or an empty string.
+func SanitizeColorLabel(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	for _, label := range ColorLabels {
+		if s == label {
+			return label
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,33 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeRating(t *testing.T) {
+	assert.Equal(t, 0, SanitizeRating(0))
+	assert.Equal(t, 4, SanitizeRating(4))
+	assert.Equal(t, RatingRejected, SanitizeRating(-1))
+	assert.Equal(t, 0, SanitizeRating(-2))
+	assert.Equal(t, 0, SanitizeRating(6))
+}
+
+func TestRatingFromPercent(t *testing.T) {
+	assert.Equal(t, 0, RatingFromPercent(0))
+	assert.Equal(t, 1, RatingFromPercent(1))
+	assert.Equal(t, 2, RatingFromPercent(25))
+	assert.Equal(t, 3, RatingFromPercent(50))
+	assert.Equal(t, 4, RatingFromPercent(75))
+	assert.Equal(t, 5, RatingFromPercent(99))
+	assert.Equal(t, 5, RatingFromPercent(100))
+	assert.Equal(t, 0, RatingFromPercent(101))
+}
+
+func TestSanitizeColorLabel(t *testing.T) {
+	assert.Equal(t, "red", SanitizeColorLabel("Red"))
+	assert.Equal(t, "purple", SanitizeColorLabel(" purple "))
+	assert.Equal(t, "", SanitizeColorLabel("Approved"))
+	assert.Equal(t, "", SanitizeColorLabel(""))
+}
@@ -0,0 +1,359 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// rafSignature is the header of Fujifilm RAF files.
+var rafSignature = []byte("FUJIFILMCCD-RAW ")
+
+// cr3PreviewUUID identifies the box containing the PRVW preview in Canon CR3 files.
+var cr3PreviewUUID = []byte{0xea, 0xf4, 0x2b, 0x5e, 0x1c, 0x98, 0x4b, 0x88, 0xb9, 0xfb, 0xb7, 0xdc, 0x40, 0x6e, 0x4d, 0x16}
+
+// tiffMaxIfds is the maximum number of image file directories that are searched for previews.
+const tiffMaxIfds = 64
+
+// PreviewImage represents the position and size of a JPEG preview embedded in a RAW file.
+type PreviewImage struct {
+	Offset int64 // Start of the JPEG in bytes from the beginning of the file.
+	Length int64 // Size of the JPEG in bytes.
+	Width  int
+	Height int
+}
+
+// Pixels returns the number of pixels of the preview.
+func (p PreviewImage) Pixels() int {
+	return p.Width * p.Height
+}
+
+// RawPreview finds the largest embedded JPEG preview in a RAW file, e.g. CR2, CR3, NEF, ARW, DNG, RAF, or ORF.
+func RawPreview(fileName string) (preview PreviewImage, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (raw preview panic)\nstack: %s", e, txt.Quote(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	logName := txt.Quote(filepath.Base(fileName))
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return preview, fmt.Errorf("metadata: can't open %s (raw preview)", logName)
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return preview, fmt.Errorf("metadata: can't read %s (raw preview)", logName)
+	}
+
+	size := info.Size()
+	header := make([]byte, 16)
+
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return preview, fmt.Errorf("metadata: can't read %s (raw preview)", logName)
+	}
+
+	var candidates []PreviewImage
+
+	switch {
+	case bytes.Equal(header, rafSignature):
+		candidates = rafPreviews(file)
+	case string(header[4:8]) == "ftyp":
+		candidates = cr3Previews(file, size)
+	default:
+		candidates = tiffPreviews(file, size)
+	}
+
+	for _, c := range candidates {
+		if c.Offset <= 0 || c.Length <= 0 || c.Offset+c.Length > size {
+			continue
+		}
+
+		if c.Width, c.Height = jpegDimensions(file, c.Offset, c.Offset+c.Length); c.Pixels() > preview.Pixels() {
+			preview = c
+		}
+	}
+
+	if preview.Length == 0 {
+		return preview, fmt.Errorf("metadata: no embedded preview in %s", logName)
+	}
+
+	return preview, nil
+}
+
+// rafPreviews returns the preview referenced in the header of a Fujifilm RAF file.
+func rafPreviews(r io.ReaderAt) []PreviewImage {
+	b := make([]byte, 8)
+
+	if _, err := r.ReadAt(b, 84); err != nil {
+		return nil
+	}
+
+	return []PreviewImage{{
+		Offset: int64(binary.BigEndian.Uint32(b[0:4])),
+		Length: int64(binary.BigEndian.Uint32(b[4:8])),
+	}}
+}
+
+// cr3Previews returns the PRVW preview and the JPEG tracks of a Canon CR3 file.
+func cr3Previews(r io.ReaderAt, size int64) (result []PreviewImage) {
+	for offset := int64(0); offset < size; {
+		atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, size)
+
+		if err != nil {
+			break
+		}
+
+		switch atomType {
+		case "moov":
+			result = append(result, cr3Tracks(r, dataOffset, atomEnd)...)
+		case "uuid":
+			id := make([]byte, 16)
+
+			if _, err := r.ReadAt(id, dataOffset); err == nil && bytes.Equal(id, cr3PreviewUUID) {
+				// The uuid is followed by 8 bytes and the PRVW box.
+				result = append(result, cr3Prvw(r, dataOffset+24, atomEnd)...)
+			}
+		}
+
+		offset = atomEnd
+	}
+
+	return result
+}
+
+// cr3Prvw returns the JPEG contained in a PRVW box.
+func cr3Prvw(r io.ReaderAt, offset, end int64) []PreviewImage {
+	atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, end)
+
+	if err != nil || atomType != "PRVW" {
+		return nil
+	}
+
+	b := make([]byte, 16)
+
+	if _, err := r.ReadAt(b, dataOffset); err != nil {
+		return nil
+	}
+
+	length := int64(binary.BigEndian.Uint32(b[12:16]))
+
+	if dataOffset+16+length > atomEnd {
+		return nil
+	}
+
+	return []PreviewImage{{Offset: dataOffset + 16, Length: length}}
+}
+
+// cr3Tracks returns the first sample of each track, one of which is a full size JPEG.
+func cr3Tracks(r io.ReaderAt, offset, end int64) (result []PreviewImage) {
+	var walk func(offset, end int64, sample *PreviewImage)
+
+	walk = func(offset, end int64, sample *PreviewImage) {
+		for offset < end {
+			atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, end)
+
+			if err != nil {
+				return
+			}
+
+			switch atomType {
+			case "trak":
+				track := PreviewImage{}
+				walk(dataOffset, atomEnd, &track)
+				result = append(result, track)
+			case "mdia", "minf", "stbl":
+				walk(dataOffset, atomEnd, sample)
+			case "stsz", "stco", "co64":
+				if sample == nil {
+					break
+				}
+
+				b, err := videoAtomData(r, dataOffset, atomEnd)
+
+				if err != nil || len(b) < 12 {
+					break
+				}
+
+				switch {
+				case atomType == "stsz":
+					if n := binary.BigEndian.Uint32(b[4:8]); n > 0 {
+						sample.Length = int64(n)
+					} else if len(b) >= 16 && binary.BigEndian.Uint32(b[8:12]) > 0 {
+						sample.Length = int64(binary.BigEndian.Uint32(b[12:16]))
+					}
+				case atomType == "stco" && binary.BigEndian.Uint32(b[4:8]) > 0:
+					sample.Offset = int64(binary.BigEndian.Uint32(b[8:12]))
+				case atomType == "co64" && binary.BigEndian.Uint32(b[4:8]) > 0 && len(b) >= 16:
+					sample.Offset = int64(binary.BigEndian.Uint64(b[8:16]))
+				}
+			}
+
+			offset = atomEnd
+		}
+	}
+
+	walk(offset, end, nil)
+
+	return result
+}
+
+// tiffPreviews returns the JPEG images referenced in the image file directories of TIFF based RAW files.
+func tiffPreviews(r io.ReaderAt, size int64) (result []PreviewImage) {
+	header := make([]byte, 8)
+
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil
+	}
+
+	var order binary.ByteOrder
+
+	switch string(header[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	// Olympus and Panasonic use custom magic numbers.
+	switch order.Uint16(header[2:4]) {
+	case 42, 0x4F52, 0x5352, 0x55:
+	default:
+		return nil
+	}
+
+	ifds := []int64{int64(order.Uint32(header[4:8]))}
+	visited := make(map[int64]bool)
+
+	for len(ifds) > 0 && len(visited) < tiffMaxIfds {
+		offset := ifds[0]
+		ifds = ifds[1:]
+
+		if offset <= 0 || offset+2 > size || visited[offset] {
+			continue
+		}
+
+		visited[offset] = true
+
+		b := make([]byte, 2)
+
+		if _, err := r.ReadAt(b, offset); err != nil {
+			continue
+		}
+
+		count := int64(order.Uint16(b))
+		entries := make([]byte, count*12+4)
+
+		if _, err := r.ReadAt(entries, offset+2); err != nil {
+			continue
+		}
+
+		var compression, stripCount uint32
+		var jpeg, strip PreviewImage
+
+		for i := int64(0); i < count; i++ {
+			e := entries[i*12 : i*12+12]
+			tag, typ, n := order.Uint16(e[0:2]), order.Uint16(e[2:4]), order.Uint32(e[4:8])
+
+			value := order.Uint32(e[8:12])
+
+			if typ == 3 {
+				// Short values are stored in the first two bytes.
+				value = uint32(order.Uint16(e[8:10]))
+			}
+
+			switch tag {
+			case 0x0103: // Compression
+				compression = value
+			case 0x0111: // StripOffsets
+				strip.Offset, stripCount = int64(value), n
+			case 0x0117: // StripByteCounts
+				strip.Length = int64(value)
+			case 0x0201: // JPEGInterchangeFormat
+				jpeg.Offset = int64(value)
+			case 0x0202: // JPEGInterchangeFormatLength
+				jpeg.Length = int64(value)
+			case 0x002E: // Panasonic JpgFromRaw
+				result = append(result, PreviewImage{Offset: int64(value), Length: int64(n)})
+			case 0x014A: // SubIFDs
+				if n == 1 {
+					ifds = append(ifds, int64(value))
+				} else if n > 1 && n < tiffMaxIfds {
+					sub := make([]byte, n*4)
+
+					if _, err := r.ReadAt(sub, int64(value)); err == nil {
+						for j := uint32(0); j < n; j++ {
+							ifds = append(ifds, int64(order.Uint32(sub[j*4:j*4+4])))
+						}
+					}
+				}
+			}
+		}
+
+		if jpeg.Offset > 0 && jpeg.Length > 0 {
+			result = append(result, jpeg)
+		}
+
+		// Old-style and new-style JPEG compression, may also be lossless raw data.
+		if (compression == 6 || compression == 7) && stripCount == 1 {
+			result = append(result, strip)
+		}
+
+		ifds = append(ifds, int64(order.Uint32(entries[count*12:count*12+4])))
+	}
+
+	return result
+}
+
+// jpegDimensions returns the size of a baseline or progressive JPEG image, or zero if the data is not supported.
+func jpegDimensions(r io.ReaderAt, offset, end int64) (width, height int) {
+	b := make([]byte, 9)
+
+	if _, err := r.ReadAt(b[:2], offset); err != nil || b[0] != 0xFF || b[1] != 0xD8 {
+		return 0, 0
+	}
+
+	for pos := offset + 2; pos+4 <= end; {
+		if _, err := r.ReadAt(b[:4], pos); err != nil || b[0] != 0xFF {
+			return 0, 0
+		}
+
+		marker := b[1]
+
+		switch {
+		case marker == 0xFF:
+			// Fill byte.
+			pos++
+			continue
+		case marker == 0xC0 || marker == 0xC1 || marker == 0xC2:
+			if _, err := r.ReadAt(b, pos+4); err != nil {
+				return 0, 0
+			}
+
+			return int(binary.BigEndian.Uint16(b[3:5])), int(binary.BigEndian.Uint16(b[1:3]))
+		case marker >= 0xC3 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC:
+			// Lossless and arithmetic coding, e.g. raw sensor data.
+			return 0, 0
+		case marker == 0xDA || marker == 0xD9:
+			return 0, 0
+		}
+
+		pos += 2 + int64(binary.BigEndian.Uint16(b[2:4]))
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,63 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPreview(t *testing.T) {
+	t.Run("raw-preview.cr2", func(t *testing.T) {
+		preview, err := RawPreview("testdata/raw-preview.cr2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(146), preview.Offset)
+		assert.Equal(t, 320, preview.Width)
+		assert.Equal(t, 240, preview.Height)
+	})
+
+	t.Run("raw-preview.cr3", func(t *testing.T) {
+		preview, err := RawPreview("testdata/raw-preview.cr3")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 320, preview.Width)
+		assert.Equal(t, 240, preview.Height)
+	})
+
+	t.Run("raw-preview.raf", func(t *testing.T) {
+		preview, err := RawPreview("testdata/raw-preview.raf")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(100), preview.Offset)
+		assert.Equal(t, int64(3442), preview.Length)
+		assert.Equal(t, 320, preview.Width)
+		assert.Equal(t, 240, preview.Height)
+	})
+
+	t.Run("exif-example.tiff", func(t *testing.T) {
+		_, err := RawPreview("testdata/exif-example.tiff")
+
+		assert.EqualError(t, err, "metadata: no embedded preview in exif-example.tiff")
+	})
+
+	t.Run("android.mp4", func(t *testing.T) {
+		_, err := RawPreview("testdata/android.mp4")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not existing", func(t *testing.T) {
+		_, err := RawPreview("testdata/xxx.cr2")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,173 @@
+package meta
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"gopkg.in/ugjka/go-tz.v2/tz"
+)
+
+// QuickTime metadata keys as used by Apple devices.
+const (
+	VideoKeyCreationDate      = "com.apple.quicktime.creationdate"
+	VideoKeyLocation          = "com.apple.quicktime.location.ISO6709"
+	VideoKeyMake              = "com.apple.quicktime.make"
+	VideoKeyModel             = "com.apple.quicktime.model"
+	VideoKeyContentIdentifier = "com.apple.quicktime.content.identifier"
+)
+
+// QuickTime user data atoms.
+const (
+	VideoAtomLocation = "\xa9xyz"
+	VideoAtomMake     = "\xa9mak"
+	VideoAtomModel    = "\xa9mod"
+	VideoAtomDay      = "\xa9day"
+)
+
+// videoTimeLayouts contains the supported layouts of creation dates in video metadata.
+var videoTimeLayouts = []string{"2006-01-02T15:04:05-0700", time.RFC3339, "2006-01-02T15:04:05Z0700"}
+
+// Video parses an MP4 or QuickTime video file and returns a Data struct.
+func Video(fileName string) (data Data, err error) {
+	err = data.Video(fileName)
+
+	return data, err
+}
+
+// Video parses an MP4 or QuickTime video file for metadata without using ExifTool.
+// Existing values are kept, so that it can be used to complete ExifTool metadata.
+func (data *Data) Video(fileName string) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (video panic)\nstack: %s", e, txt.Quote(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	logName := txt.Quote(filepath.Base(fileName))
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return fmt.Errorf("metadata: can't open %s (video)", logName)
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return fmt.Errorf("metadata: can't read %s (video)", logName)
+	}
+
+	v, err := parseVideo(file, info.Size())
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (video)", err, logName)
+	}
+
+	if data.All == nil {
+		data.All = make(map[string]string)
+	}
+
+	for k, val := range v.Values {
+		if _, ok := data.All[k]; !ok {
+			data.All[k] = val
+		}
+	}
+
+	if v.Timescale > 0 && data.Duration == 0 {
+		data.Duration = time.Duration(float64(v.Duration) / float64(v.Timescale) * float64(time.Second)).Round(time.Millisecond)
+	}
+
+	if t := v.Track; t != nil {
+		if data.Codec == "" {
+			data.Codec = strings.ToLower(strings.TrimSpace(t.Codec))
+		}
+
+		if data.Width == 0 || data.Height == 0 {
+			data.Width = t.Width
+			data.Height = t.Height
+		}
+
+		if data.Rotation == 0 {
+			data.Rotation = t.Rotation
+		}
+
+		if t.Samples > 0 && t.Duration > 0 && t.Timescale > 0 && data.FPS == 0 {
+			data.FPS = math.Round(float64(t.Samples)*float64(t.Timescale)/float64(t.Duration)*100) / 100
+		}
+	}
+
+	if data.Orientation == 0 {
+		switch data.Rotation {
+		case -180, 180:
+			data.Orientation = 3
+		case 90:
+			data.Orientation = 6
+		case -90, 270:
+			data.Orientation = 8
+		default:
+			data.Orientation = 1
+		}
+	}
+
+	if s := v.Value(VideoKeyMake, VideoAtomMake); s != "" && data.CameraMake == "" {
+		data.CameraMake = SanitizeString(s)
+	}
+
+	if s := v.Value(VideoKeyModel, VideoAtomModel); s != "" && data.CameraModel == "" {
+		data.CameraModel = SanitizeString(s)
+	}
+
+	if id := rnd.SanitizeUUID(v.Value(VideoKeyContentIdentifier)); id != "" && data.DocumentID == "" {
+		data.DocumentID = id
+	}
+
+	if lat, lng, alt, ok := ISO6709(v.Value(VideoKeyLocation, VideoAtomLocation)); ok && data.Lat == 0 && data.Lng == 0 {
+		data.Lat = lat
+		data.Lng = lng
+		data.Altitude = alt
+
+		if zones, err := tz.GetZone(tz.Point{Lat: float64(lat), Lon: float64(lng)}); err == nil && len(zones) > 0 {
+			data.TimeZone = zones[0]
+		}
+	}
+
+	if !data.TakenAt.IsZero() {
+		return nil
+	}
+
+	// Prefer creation dates with time zone offset over the UTC time in the movie header.
+	if s := v.Value(VideoKeyCreationDate, VideoAtomDay); s != "" {
+		for _, layout := range videoTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				data.TakenAt = t.Round(time.Second).UTC()
+				data.TakenAtLocal = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+				break
+			}
+		}
+	}
+
+	if data.TakenAt.IsZero() && !v.Created.IsZero() {
+		data.TakenAt = v.Created.Round(time.Second).UTC()
+		data.TakenAtLocal = data.TakenAt
+
+		if data.TimeZone == "" {
+			// Keep UTC.
+		} else if loc, err := time.LoadLocation(data.TimeZone); err != nil {
+			log.Warnf("metadata: unknown time zone %s in %s (video)", data.TimeZone, logName)
+		} else {
+			t := data.TakenAt.In(loc)
+			data.TakenAtLocal = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		}
+	}
+
+	return nil
+}
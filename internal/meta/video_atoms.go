@@ -0,0 +1,424 @@
+package meta
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// videoEpochOffset is the number of seconds between 1904-01-01, the start of time in MP4 and QuickTime files,
+// and the Unix epoch.
+const videoEpochOffset = 2082844800
+
+// videoMaxAtomSize is the maximum size of atoms that are read into memory.
+const videoMaxAtomSize = 8 * 1024 * 1024
+
+// videoContainers contains the atom types that only contain other atoms.
+var videoContainers = map[string]bool{
+	"moov": true,
+	"trak": true,
+	"mdia": true,
+	"minf": true,
+	"stbl": true,
+	"udta": true,
+}
+
+// videoTopLevel contains the atom types that may start an MP4 or QuickTime file.
+var videoTopLevel = map[string]bool{
+	"ftyp": true,
+	"moov": true,
+	"mdat": true,
+	"wide": true,
+	"free": true,
+	"skip": true,
+	"pnot": true,
+}
+
+// videoTrack represents the metadata of a track in an MP4 or QuickTime file.
+type videoTrack struct {
+	Handler   string
+	Codec     string
+	Width     int
+	Height    int
+	Rotation  int
+	Timescale uint32
+	Duration  uint64
+	Samples   uint64
+}
+
+// videoInfo represents the metadata found in an MP4 or QuickTime file.
+type videoInfo struct {
+	Movie     bool
+	Created   time.Time
+	Timescale uint32
+	Duration  uint64
+	Track     *videoTrack
+	Keys      []string
+	Values    map[string]string
+}
+
+// Value returns the first non-empty metadata value for the given keys.
+func (v *videoInfo) Value(keys ...string) string {
+	for _, k := range keys {
+		if s := strings.TrimSpace(v.Values[k]); s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
+
+// parseVideo reads the ISO-BMFF / QuickTime atom structure of a video file.
+func parseVideo(r io.ReaderAt, size int64) (*videoInfo, error) {
+	v := &videoInfo{Values: make(map[string]string)}
+
+	header := make([]byte, 8)
+
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return v, fmt.Errorf("file too short")
+	} else if !videoTopLevel[string(header[4:8])] {
+		return v, fmt.Errorf("unsupported file type")
+	}
+
+	if err := v.walk(r, 0, size); err != nil {
+		return v, err
+	} else if !v.Movie {
+		return v, fmt.Errorf("no movie header found")
+	}
+
+	return v, nil
+}
+
+// walk reads all atoms between offset and end, and descends into containers.
+func (v *videoInfo) walk(r io.ReaderAt, offset, end int64) error {
+	for offset+8 <= end {
+		atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, end)
+
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case atomType == "trak":
+			track := &videoTrack{}
+
+			if err := v.walkTrack(r, track, dataOffset, atomEnd); err != nil {
+				return err
+			}
+
+			if track.Handler == "vide" && v.Track == nil {
+				v.Track = track
+			}
+		case videoContainers[atomType]:
+			if atomType == "moov" {
+				v.Movie = true
+			}
+
+			if err := v.walk(r, dataOffset, atomEnd); err != nil {
+				return err
+			}
+		case atomType == "meta":
+			if err := v.walkMeta(r, dataOffset, atomEnd); err != nil {
+				return err
+			}
+		case atomType == "mvhd":
+			if b, err := videoAtomData(r, dataOffset, atomEnd); err == nil {
+				v.parseMvhd(b)
+			}
+		case strings.HasPrefix(atomType, "\xa9"):
+			if b, err := videoAtomData(r, dataOffset, atomEnd); err == nil {
+				v.Values[atomType] = videoUserDataText(b)
+			}
+		}
+
+		offset = atomEnd
+	}
+
+	return nil
+}
+
+// walkTrack reads the atoms of a track.
+func (v *videoInfo) walkTrack(r io.ReaderAt, t *videoTrack, offset, end int64) error {
+	for offset+8 <= end {
+		atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, end)
+
+		if err != nil {
+			return err
+		}
+
+		if videoContainers[atomType] {
+			if err := v.walkTrack(r, t, dataOffset, atomEnd); err != nil {
+				return err
+			}
+
+			offset = atomEnd
+			continue
+		}
+
+		switch atomType {
+		case "tkhd", "mdhd", "hdlr", "stsd", "stts":
+			b, err := videoAtomData(r, dataOffset, atomEnd)
+
+			if err != nil {
+				break
+			}
+
+			switch atomType {
+			case "tkhd":
+				t.parseTkhd(b)
+			case "mdhd":
+				t.parseMdhd(b)
+			case "hdlr":
+				// Ignore data handlers, e.g. "dhlr" in QuickTime files.
+				if len(b) >= 12 && string(b[4:8]) != "dhlr" {
+					t.Handler = string(b[8:12])
+				}
+			case "stsd":
+				t.parseStsd(b)
+			case "stts":
+				t.parseStts(b)
+			}
+		}
+
+		offset = atomEnd
+	}
+
+	return nil
+}
+
+// walkMeta reads QuickTime metadata keys and values. ISO meta atoms are full atoms with version and flags.
+func (v *videoInfo) walkMeta(r io.ReaderAt, offset, end int64) error {
+	peek := make([]byte, 4)
+
+	if _, err := r.ReadAt(peek, offset+4); err == nil && string(peek) != "hdlr" {
+		offset += 4
+	}
+
+	for offset+8 <= end {
+		atomType, dataOffset, atomEnd, err := videoAtomHeader(r, offset, end)
+
+		if err != nil {
+			return err
+		}
+
+		switch atomType {
+		case "keys":
+			if b, err := videoAtomData(r, dataOffset, atomEnd); err == nil {
+				v.parseKeys(b)
+			}
+		case "ilst":
+			if b, err := videoAtomData(r, dataOffset, atomEnd); err == nil {
+				v.parseIlst(b)
+			}
+		}
+
+		offset = atomEnd
+	}
+
+	return nil
+}
+
+// videoAtomHeader reads an atom header and returns the type, data offset and end offset.
+func videoAtomHeader(r io.ReaderAt, offset, end int64) (atomType string, dataOffset, atomEnd int64, err error) {
+	header := make([]byte, 16)
+
+	if _, err := r.ReadAt(header[:8], offset); err != nil {
+		return "", 0, 0, fmt.Errorf("can't read atom at offset %d", offset)
+	}
+
+	size := int64(binary.BigEndian.Uint32(header[0:4]))
+	atomType = string(header[4:8])
+	dataOffset = offset + 8
+
+	switch size {
+	case 0:
+		// Atom extends to the end of the file.
+		size = end - offset
+	case 1:
+		if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+			return "", 0, 0, fmt.Errorf("can't read atom size at offset %d", offset)
+		}
+
+		size = int64(binary.BigEndian.Uint64(header[8:16]))
+		dataOffset += 8
+	}
+
+	atomEnd = offset + size
+
+	if atomEnd < dataOffset || atomEnd > end {
+		return "", 0, 0, fmt.Errorf("invalid atom size %d at offset %d", size, offset)
+	}
+
+	return atomType, dataOffset, atomEnd, nil
+}
+
+// videoAtomData returns the data of an atom.
+func videoAtomData(r io.ReaderAt, offset, end int64) ([]byte, error) {
+	if end-offset > videoMaxAtomSize {
+		return nil, fmt.Errorf("atom too large")
+	}
+
+	b := make([]byte, end-offset)
+
+	if _, err := r.ReadAt(b, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// videoTime converts seconds since 1904 to time, or returns zero time if not set.
+func videoTime(seconds uint64) time.Time {
+	if seconds == 0 || seconds > math.MaxInt64 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(seconds)-videoEpochOffset, 0).UTC()
+}
+
+// parseMvhd reads creation time, timescale and duration from the movie header.
+func (v *videoInfo) parseMvhd(b []byte) {
+	if len(b) >= 32 && b[0] == 1 {
+		v.Created = videoTime(binary.BigEndian.Uint64(b[4:12]))
+		v.Timescale = binary.BigEndian.Uint32(b[20:24])
+		v.Duration = binary.BigEndian.Uint64(b[24:32])
+	} else if len(b) >= 20 {
+		v.Created = videoTime(uint64(binary.BigEndian.Uint32(b[4:8])))
+		v.Timescale = binary.BigEndian.Uint32(b[12:16])
+		v.Duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+	}
+}
+
+// parseTkhd reads dimensions and rotation from the track header.
+func (t *videoTrack) parseTkhd(b []byte) {
+	// Offset of the transformation matrix depends on the version.
+	offset := 40
+
+	if len(b) > 0 && b[0] == 1 {
+		offset = 52
+	}
+
+	if len(b) < offset+44 {
+		return
+	}
+
+	m := make([]int32, 4)
+
+	for i, pos := range []int{0, 4, 12, 16} {
+		m[i] = int32(binary.BigEndian.Uint32(b[offset+pos:offset+pos+4])) >> 16
+	}
+
+	switch {
+	case m[0] == 0 && m[1] == 1 && m[2] == -1 && m[3] == 0:
+		t.Rotation = 90
+	case m[0] == -1 && m[1] == 0 && m[2] == 0 && m[3] == -1:
+		t.Rotation = 180
+	case m[0] == 0 && m[1] == -1 && m[2] == 1 && m[3] == 0:
+		t.Rotation = 270
+	}
+
+	t.Width = int(binary.BigEndian.Uint32(b[offset+36:offset+40]) >> 16)
+	t.Height = int(binary.BigEndian.Uint32(b[offset+40:offset+44]) >> 16)
+}
+
+// parseMdhd reads timescale and duration from the media header.
+func (t *videoTrack) parseMdhd(b []byte) {
+	if len(b) >= 32 && b[0] == 1 {
+		t.Timescale = binary.BigEndian.Uint32(b[20:24])
+		t.Duration = binary.BigEndian.Uint64(b[24:32])
+	} else if len(b) >= 20 {
+		t.Timescale = binary.BigEndian.Uint32(b[12:16])
+		t.Duration = uint64(binary.BigEndian.Uint32(b[16:20]))
+	}
+}
+
+// parseStsd reads the codec and, if missing in the track header, dimensions from the first sample description.
+func (t *videoTrack) parseStsd(b []byte) {
+	if len(b) < 16 || binary.BigEndian.Uint32(b[4:8]) == 0 {
+		return
+	}
+
+	t.Codec = string(b[12:16])
+
+	// Visual sample entries store width and height after 24 reserved and pre-defined bytes.
+	if entry := b[8:]; len(entry) >= 36 && (t.Width == 0 || t.Height == 0) {
+		t.Width = int(binary.BigEndian.Uint16(entry[32:34]))
+		t.Height = int(binary.BigEndian.Uint16(entry[34:36]))
+	}
+}
+
+// parseStts counts the samples in the time-to-sample table.
+func (t *videoTrack) parseStts(b []byte) {
+	if len(b) < 8 {
+		return
+	}
+
+	count := int(binary.BigEndian.Uint32(b[4:8]))
+
+	for i := 0; i < count && 16+i*8 <= len(b); i++ {
+		t.Samples += uint64(binary.BigEndian.Uint32(b[8+i*8 : 12+i*8]))
+	}
+}
+
+// parseKeys reads the QuickTime metadata key names.
+func (v *videoInfo) parseKeys(b []byte) {
+	if len(b) < 8 {
+		return
+	}
+
+	count := int(binary.BigEndian.Uint32(b[4:8]))
+	offset := 8
+
+	for i := 0; i < count && offset+8 <= len(b); i++ {
+		size := int(binary.BigEndian.Uint32(b[offset : offset+4]))
+
+		if size < 8 || offset+size > len(b) {
+			return
+		}
+
+		v.Keys = append(v.Keys, string(b[offset+8:offset+size]))
+		offset += size
+	}
+}
+
+// parseIlst reads the QuickTime metadata values. Items refer to keys by their one-based index.
+func (v *videoInfo) parseIlst(b []byte) {
+	for offset := 0; offset+8 <= len(b); {
+		size := int(binary.BigEndian.Uint32(b[offset : offset+4]))
+
+		if size < 8 || offset+size > len(b) {
+			return
+		}
+
+		key := string(b[offset+4 : offset+8])
+
+		if i := int(binary.BigEndian.Uint32(b[offset+4 : offset+8])); i > 0 && i <= len(v.Keys) {
+			key = v.Keys[i-1]
+		}
+
+		// Only UTF-8 text values are supported.
+		if item := b[offset+8 : offset+size]; len(item) >= 16 && string(item[4:8]) == "data" && binary.BigEndian.Uint32(item[8:12]) == 1 {
+			if dataSize := int(binary.BigEndian.Uint32(item[0:4])); dataSize >= 16 && dataSize <= len(item) {
+				v.Values[key] = string(item[16:dataSize])
+			}
+		}
+
+		offset += size
+	}
+}
+
+// videoUserDataText returns the text of a QuickTime user data atom, which is prefixed with its length and language.
+func videoUserDataText(b []byte) string {
+	if len(b) < 4 {
+		return ""
+	}
+
+	if size := int(binary.BigEndian.Uint16(b[0:2])); size > 0 && size <= len(b)-4 {
+		return string(b[4 : 4+size])
+	}
+
+	return string(b[4:])
+}
@@ -0,0 +1,127 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideo(t *testing.T) {
+	t.Run("iphone-live.mov", func(t *testing.T) {
+		data, err := Video("testdata/iphone-live.mov")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-06-09 10:59:22 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, "2019-06-09 12:59:22 +0000 UTC", data.TakenAtLocal.String())
+		assert.Equal(t, "Europe/Berlin", data.TimeZone)
+		assert.Equal(t, 3*time.Second, data.Duration)
+		assert.Equal(t, "hvc1", data.Codec)
+		assert.Equal(t, 1920, data.Width)
+		assert.Equal(t, 1080, data.Height)
+		assert.Equal(t, 90, data.Rotation)
+		assert.Equal(t, 6, data.Orientation)
+		assert.Equal(t, 1080, data.ActualWidth())
+		assert.Equal(t, 30.0, data.FPS)
+		assert.Equal(t, float32(52.52), data.Lat)
+		assert.Equal(t, float32(13.405), data.Lng)
+		assert.Equal(t, 34, data.Altitude)
+		assert.Equal(t, "Apple", data.CameraMake)
+		assert.Equal(t, "iPhone XS", data.CameraModel)
+		assert.Equal(t, "0ab0d8e8-8c3f-4f4b-9b8c-0b4e2a1f5c11", data.DocumentID)
+		assert.True(t, data.HasDocumentID())
+	})
+
+	t.Run("android.mp4", func(t *testing.T) {
+		data, err := Video("testdata/android.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2020-07-04 18:30:00 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, "2020-07-05 04:30:00 +0000 UTC", data.TakenAtLocal.String())
+		assert.Equal(t, "Australia/Sydney", data.TimeZone)
+		assert.Equal(t, 2500*time.Millisecond, data.Duration)
+		assert.Equal(t, "avc1", data.Codec)
+		assert.Equal(t, 1280, data.Width)
+		assert.Equal(t, 720, data.Height)
+		assert.Equal(t, 0, data.Rotation)
+		assert.Equal(t, 1, data.Orientation)
+		assert.Equal(t, 30.0, data.FPS)
+		assert.Equal(t, float32(-33.8688), data.Lat)
+		assert.Equal(t, float32(151.2093), data.Lng)
+		assert.Equal(t, "samsung", data.CameraMake)
+		assert.Equal(t, "SM-G973F", data.CameraModel)
+		assert.Equal(t, "", data.DocumentID)
+	})
+
+	t.Run("christmas.mp4", func(t *testing.T) {
+		data, err := Video("../../assets/examples/christmas.mp4")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, data.TakenAt.IsZero())
+		assert.Equal(t, "avc1", data.Codec)
+		assert.Equal(t, 640, data.Width)
+		assert.Equal(t, 416, data.Height)
+		assert.Equal(t, 1, data.Orientation)
+	})
+
+	t.Run("not a video", func(t *testing.T) {
+		_, err := Video("testdata/iptc.jpg")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := Video("testdata/xxx.mp4")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestISO6709(t *testing.T) {
+	t.Run("decimal", func(t *testing.T) {
+		lat, lng, alt, ok := ISO6709("+52.5200+013.4050+034.000/")
+
+		assert.True(t, ok)
+		assert.Equal(t, float32(52.52), lat)
+		assert.Equal(t, float32(13.405), lng)
+		assert.Equal(t, 34, alt)
+	})
+
+	t.Run("minutes", func(t *testing.T) {
+		lat, lng, alt, ok := ISO6709("+5231.2-01324.3/")
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.52, lat, 0.0001)
+		assert.InDelta(t, -13.405, lng, 0.0001)
+		assert.Equal(t, 0, alt)
+	})
+
+	t.Run("seconds", func(t *testing.T) {
+		lat, lng, _, ok := ISO6709("-335207.7+1511233.5/")
+
+		assert.True(t, ok)
+		assert.InDelta(t, -33.86881, lat, 0.0001)
+		assert.InDelta(t, 151.20931, lng, 0.0001)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, _, ok := ISO6709("52.52, 13.405")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, _, _, ok := ISO6709("+95.0000+013.4050/")
+
+		assert.False(t, ok)
+	})
+}
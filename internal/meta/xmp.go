@@ -57,5 +57,40 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.LensModel = doc.LensModel()
 	}
 
+	if doc.Keywords() != "" {
+		data.Keywords = doc.Keywords()
+	}
+
+	for _, h := range doc.HierarchicalSubject() {
+		data.AddHierarchy(h, HierarchySep)
+	}
+
+	for _, h := range doc.TagsList() {
+		data.AddHierarchy(h, HierarchySepDigikam)
+	}
+
+	if doc.Rating() != 0 {
+		data.Rating = doc.Rating()
+	}
+
+	if doc.Favorite() {
+		data.Favorite = true
+	}
+
+	if doc.ColorLabel() != "" {
+		data.ColorLabel = doc.ColorLabel()
+	}
+
+	if taken, local := doc.TakenAt(); !taken.IsZero() {
+		data.TakenAt = taken
+		data.TakenAtLocal = local
+	}
+
+	if lat, lng := doc.LatLng(); lat != 0 && lng != 0 {
+		data.Lat = lat
+		data.Lng = lng
+		data.Altitude = doc.Altitude()
+	}
+
 	return nil
 }
@@ -3,8 +3,20 @@ package meta
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// XmpTimeLayouts are the supported XMP date formats, optionally with time zone offset.
+var XmpTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
 // XmpDocument represents an XMP sidecar file.
 type XmpDocument struct {
 	XMLName xml.Name `xml:"xmpmeta" json:"xmpmeta,omitempty"`
@@ -33,6 +45,7 @@ type XmpDocument struct {
 			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
 			MetadataDate    string `xml:"MetadataDate"`    // 2020-01-01T17:28:23.89961...
 			Rating          string `xml:"Rating"`          // 4
+			Label           string `xml:"Label"`           // Red
 			Lens            string `xml:"Lens"`            // HUAWEI P30 Rear Main Came...
 			LensModel       string `xml:"LensModel"`       // HUAWEI P30 Rear Main Came...
 			DateCreated     string `xml:"DateCreated"`     // 2020-01-01T17:28:25.72962...
@@ -75,7 +88,25 @@ type XmpDocument struct {
 					Text string   `xml:",chardata" json:"text,omitempty"`
 					Li   []string `xml:"li"` // desk, coffee, computer
 				} `xml:"Bag" json:"bag,omitempty"`
+				Seq struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Beach, Sunset (Apple Photos)
+				} `xml:"Seq" json:"seq,omitempty"`
 			} `xml:"subject" json:"subject,omitempty"`
+			HierarchicalSubject struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Places|Europe|Berlin
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"hierarchicalSubject" json:"hierarchicalsubject,omitempty"`
+			TagsList struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Seq  struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Places/Europe/Berlin
+				} `xml:"Seq" json:"seq,omitempty"`
+			} `xml:"TagsList" json:"tagslist,omitempty"`
 			Rights struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Alt  struct {
@@ -188,6 +219,24 @@ type XmpDocument struct {
 					Li   string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
+			MotionPhoto      string `xml:"MotionPhoto,attr" json:"motionphoto,omitempty"`           // 1
+			MicroVideo       string `xml:"MicroVideo,attr" json:"microvideo,omitempty"`             // 1
+			MicroVideoOffset string `xml:"MicroVideoOffset,attr" json:"microvideooffset,omitempty"` // 4021078
+			Directory        struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Seq  struct {
+					Text string `xml:",chardata" json:"text,omitempty"`
+					Li   []struct {
+						Text string `xml:",chardata" json:"text,omitempty"`
+						Item struct {
+							Mime     string `xml:"Mime,attr" json:"mime,omitempty"`         // video/mp4
+							Semantic string `xml:"Semantic,attr" json:"semantic,omitempty"` // MotionPhoto
+							Length   string `xml:"Length,attr" json:"length,omitempty"`     // 4021078
+							Padding  string `xml:"Padding,attr" json:"padding,omitempty"`   // 0
+						} `xml:"Item" json:"item,omitempty"`
+					} `xml:"li" json:"li,omitempty"`
+				} `xml:"Seq" json:"seq,omitempty"`
+			} `xml:"Directory" json:"directory,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
 }
@@ -203,7 +252,12 @@ func (doc *XmpDocument) Load(filename string) error {
 }
 
 func (doc *XmpDocument) Title() string {
-	return SanitizeTitle(doc.RDF.Description.Title.Alt.Li.Text)
+	if s := SanitizeTitle(doc.RDF.Description.Title.Alt.Li.Text); s != "" {
+		return s
+	}
+
+	// Apple Photos exports the title as simple text.
+	return SanitizeTitle(doc.RDF.Description.Title.Text)
 }
 
 func (doc *XmpDocument) Artist() string {
@@ -211,7 +265,12 @@ func (doc *XmpDocument) Artist() string {
 }
 
 func (doc *XmpDocument) Description() string {
-	return SanitizeDescription(doc.RDF.Description.Description.Alt.Li.Text)
+	if s := SanitizeDescription(doc.RDF.Description.Description.Alt.Li.Text); s != "" {
+		return s
+	}
+
+	// Apple Photos exports the description as simple text.
+	return SanitizeDescription(doc.RDF.Description.Description.Text)
 }
 
 func (doc *XmpDocument) Copyright() string {
@@ -229,3 +288,148 @@ func (doc *XmpDocument) CameraModel() string {
 func (doc *XmpDocument) LensModel() string {
 	return SanitizeString(doc.RDF.Description.LensModel)
 }
+
+func (doc *XmpDocument) Keywords() string {
+	var result []string
+
+	for _, w := range append(doc.RDF.Description.Subject.Bag.Li, doc.RDF.Description.Subject.Seq.Li...) {
+		if w = SanitizeString(w); w != "" {
+			result = append(result, w)
+		}
+	}
+
+	return strings.Join(result, ", ")
+}
+
+func (doc *XmpDocument) HierarchicalSubject() []string {
+	return doc.RDF.Description.HierarchicalSubject.Bag.Li
+}
+
+func (doc *XmpDocument) TagsList() []string {
+	return doc.RDF.Description.TagsList.Seq.Li
+}
+
+func (doc *XmpDocument) Rating() int {
+	rating, err := strconv.Atoi(strings.TrimSpace(doc.RDF.Description.Rating))
+
+	if err != nil {
+		return 0
+	}
+
+	return SanitizeRating(rating)
+}
+
+// Apple tests if the document was created by Apple software, e.g. when exporting from Apple Photos.
+func (doc *XmpDocument) Apple() bool {
+	return strings.HasPrefix(doc.Xmptk, "XMP Core ")
+}
+
+// Favorite tests if the image was marked as favorite, which Apple Photos exports as a rating of 5.
+func (doc *XmpDocument) Favorite() bool {
+	return doc.Apple() && strings.TrimSpace(doc.RDF.Description.Rating) == "5"
+}
+
+func (doc *XmpDocument) ColorLabel() string {
+	return SanitizeColorLabel(doc.RDF.Description.Label)
+}
+
+func (doc *XmpDocument) TakenAt() (taken, local time.Time) {
+	for _, s := range []string{doc.RDF.Description.DateTimeOriginal, doc.RDF.Description.DateCreated} {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		for _, layout := range XmpTimeLayouts {
+			t, err := time.Parse(layout, s)
+
+			if err != nil {
+				continue
+			}
+
+			t = t.Round(time.Second)
+
+			if strings.HasSuffix(layout, "Z07:00") {
+				local = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+				return t.UTC(), local
+			}
+
+			return t, t
+		}
+	}
+
+	return taken, local
+}
+
+func (doc *XmpDocument) LatLng() (lat, lng float32) {
+	lat = GpsToDecimal(doc.RDF.Description.GPSLatitude)
+	lng = GpsToDecimal(doc.RDF.Description.GPSLongitude)
+
+	if lat == 0 || lng == 0 {
+		return 0, 0
+	}
+
+	return lat, lng
+}
+
+func (doc *XmpDocument) Altitude() int {
+	values := strings.Split(strings.TrimSpace(doc.RDF.Description.GPSAltitude), "/")
+
+	if len(values) == 0 || len(values) > 2 {
+		return 0
+	}
+
+	alt, err := strconv.ParseFloat(values[0], 64)
+
+	if err != nil {
+		return 0
+	}
+
+	if len(values) == 2 {
+		d, err := strconv.ParseFloat(values[1], 64)
+
+		if err != nil || d == 0 {
+			return 0
+		}
+
+		alt = alt / d
+	}
+
+	if strings.TrimSpace(doc.RDF.Description.GPSAltitudeRef) == "1" {
+		alt = -alt
+	}
+
+	return int(alt)
+}
+
+// MotionVideo returns the position of the video embedded in a Samsung or Google motion photo of the given size.
+func (doc *XmpDocument) MotionVideo(fileSize int64) (video MotionVideo) {
+	d := doc.RDF.Description
+
+	if strings.TrimSpace(d.MotionPhoto) == "1" {
+		end := fileSize
+
+		// Media items are stored in directory order after the primary image.
+		for i := len(d.Directory.Seq.Li) - 1; i > 0; i-- {
+			item := d.Directory.Seq.Li[i].Item
+			length, _ := strconv.ParseInt(strings.TrimSpace(item.Length), 10, 64)
+			padding, _ := strconv.ParseInt(strings.TrimSpace(item.Padding), 10, 64)
+
+			end -= length + padding
+
+			if item.Semantic == "MotionPhoto" && strings.HasPrefix(item.Mime, "video/") {
+				video = MotionVideo{Offset: end, Length: length}
+				break
+			}
+		}
+	} else if strings.TrimSpace(d.MicroVideo) == "1" {
+		// The legacy offset is counted from the end of the file.
+		offset, _ := strconv.ParseInt(strings.TrimSpace(d.MicroVideoOffset), 10, 64)
+		video = MotionVideo{Offset: fileSize - offset, Length: offset}
+	}
+
+	if video.Offset <= 0 || video.Length <= 0 || video.Offset+video.Length > fileSize {
+		return MotionVideo{}
+	}
+
+	return video
+}
@@ -7,6 +7,17 @@ import (
 )
 
 func TestXMP(t *testing.T) {
+	t.Run("hierarchy", func(t *testing.T) {
+		data, err := XMP("testdata/hierarchy.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Berlin, Anna", data.Keywords)
+		assert.Equal(t, []string{"Places|Europe|Berlin", "People|Family|Anna"}, data.Hierarchy)
+	})
+
 	t.Run("photoshop", func(t *testing.T) {
 		data, err := XMP("testdata/photoshop.xmp")
 
@@ -21,6 +32,13 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, "desk, coffee, computer", data.Keywords)
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, "2020-01-01 17:28:23 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, "2020-01-01 17:28:23 +0000 UTC", data.TakenAtLocal.String())
+		assert.InDelta(t, 52.45969, data.Lat, 0.00001)
+		assert.InDelta(t, 13.321832, data.Lng, 0.00001)
+		assert.Equal(t, 0, data.Altitude)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -39,6 +57,32 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "EF24-105mm f/4L IS USM", data.LensModel)
 	})
 
+	t.Run("apple-photos", func(t *testing.T) {
+		data, err := XMP("testdata/apple-photos.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Sunset at Prerow", data.Title)
+		assert.Equal(t, "Last evening at the beach", data.Description)
+		assert.Equal(t, "Beach, Sunset", data.Keywords)
+		assert.Equal(t, 5, data.Rating)
+		assert.True(t, data.Favorite)
+		assert.Equal(t, "2019-06-09 18:59:22 +0000 UTC", data.TakenAt.String())
+		assert.Equal(t, "2019-06-09 20:59:22 +0000 UTC", data.TakenAtLocal.String())
+	})
+
+	t.Run("photoshop favorite", func(t *testing.T) {
+		data, err := XMP("testdata/photoshop.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, data.Favorite)
+	})
+
 	t.Run("iphone_7", func(t *testing.T) {
 		data, err := XMP("testdata/iphone_7.xmp")
 
@@ -0,0 +1,597 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// XMP namespace URIs.
+const (
+	XmpNsX         = "adobe:ns:meta/"
+	XmpNsRdf       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XmpNsXml       = "http://www.w3.org/XML/1998/namespace"
+	XmpNsDc        = "http://purl.org/dc/elements/1.1/"
+	XmpNsXmp       = "http://ns.adobe.com/xap/1.0/"
+	XmpNsExif      = "http://ns.adobe.com/exif/1.0/"
+	XmpNsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+)
+
+// XmpTemplate is the content of new XMP sidecar files.
+var XmpTemplate = "<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n" +
+	"<x:xmpmeta xmlns:x=\"" + XmpNsX + "\" x:xmptk=\"PhotoPrism\">\n" +
+	"   <rdf:RDF xmlns:rdf=\"" + XmpNsRdf + "\">\n" +
+	"      <rdf:Description rdf:about=\"\"/>\n" +
+	"   </rdf:RDF>\n" +
+	"</x:xmpmeta>\n" +
+	"<?xpacket end=\"w\"?>\n"
+
+var xmpTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+var xmpAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "\n", "&#xA;", "\r", "&#xD;", "\t", "&#x9;")
+
+// SaveXMP writes title, description, keywords, rating, color label, GPS position, time and copyright
+// to an XMP sidecar file. Other properties, including unknown namespaces, are preserved. Empty titles,
+// descriptions, keywords, copyrights, ratings and color labels remove existing properties, while
+// time and GPS position are only written if known. A new file is created if needed.
+func (data Data) SaveXMP(fileName string) error {
+	content := []byte(XmpTemplate)
+
+	if b, err := ioutil.ReadFile(fileName); err == nil {
+		content = b
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	doc, err := ParseXmpTree(content)
+
+	if err != nil {
+		return fmt.Errorf("metadata: %s in %s (write xmp)", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	if data.Title != "" {
+		doc.SetAlt(XmpNsDc, "dc", "title", data.Title)
+	} else {
+		doc.Unset(XmpNsDc, "title")
+	}
+
+	if data.Description != "" {
+		doc.SetAlt(XmpNsDc, "dc", "description", data.Description)
+	} else {
+		doc.Unset(XmpNsDc, "description")
+	}
+
+	if keywords := data.KeywordList(); len(keywords) > 0 {
+		doc.SetBag(XmpNsDc, "dc", "subject", keywords)
+	} else {
+		doc.Unset(XmpNsDc, "subject")
+	}
+
+	if data.Copyright != "" {
+		doc.SetAlt(XmpNsDc, "dc", "rights", data.Copyright)
+	} else {
+		doc.Unset(XmpNsDc, "rights")
+	}
+
+	if data.Rating != 0 {
+		doc.SetText(XmpNsXmp, "xmp", "Rating", strconv.Itoa(data.Rating))
+	} else {
+		doc.Unset(XmpNsXmp, "Rating")
+	}
+
+	if label := SanitizeColorLabel(data.ColorLabel); label != "" {
+		doc.SetText(XmpNsXmp, "xmp", "Label", strings.Title(label))
+	} else {
+		doc.Unset(XmpNsXmp, "Label")
+	}
+
+	if s := data.XmpTime(); s != "" {
+		doc.SetText(XmpNsExif, "exif", "DateTimeOriginal", s)
+		doc.SetText(XmpNsPhotoshop, "photoshop", "DateCreated", s)
+	}
+
+	if data.Lat != 0 && data.Lng != 0 {
+		doc.SetText(XmpNsExif, "exif", "GPSLatitude", XmpGps(data.Lat, 'N', 'S'))
+		doc.SetText(XmpNsExif, "exif", "GPSLongitude", XmpGps(data.Lng, 'E', 'W'))
+
+		if data.Altitude != 0 {
+			ref := "0"
+			alt := data.Altitude
+
+			if alt < 0 {
+				ref = "1"
+				alt = -alt
+			}
+
+			doc.SetText(XmpNsExif, "exif", "GPSAltitude", fmt.Sprintf("%d/1", alt))
+			doc.SetText(XmpNsExif, "exif", "GPSAltitudeRef", ref)
+		}
+	}
+
+	doc.SetText(XmpNsXmp, "xmp", "MetadataDate", time.Now().Format("2006-01-02T15:04:05Z07:00"))
+
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, doc.Bytes(), os.ModePerm)
+}
+
+// KeywordList returns the keywords as a list without duplicates.
+func (data Data) KeywordList() (result []string) {
+	seen := make(map[string]bool)
+
+	for _, w := range strings.Split(data.Keywords, ",") {
+		w = strings.TrimSpace(w)
+
+		if w == "" || seen[strings.ToLower(w)] {
+			continue
+		}
+
+		seen[strings.ToLower(w)] = true
+		result = append(result, w)
+	}
+
+	return result
+}
+
+// XmpTime returns the time when the photo was taken in XMP format, including the time
+// zone offset if known.
+func (data Data) XmpTime() string {
+	if data.TakenAt.IsZero() {
+		return ""
+	}
+
+	if data.TimeZone != "" {
+		if loc, err := time.LoadLocation(data.TimeZone); err == nil {
+			return data.TakenAt.In(loc).Format("2006-01-02T15:04:05Z07:00")
+		}
+	}
+
+	if data.TakenAtLocal.IsZero() {
+		return data.TakenAt.Format("2006-01-02T15:04:05")
+	}
+
+	return data.TakenAtLocal.Format("2006-01-02T15:04:05")
+}
+
+// XmpGps returns a GPS coordinate in XMP format, e.g. "52,27.5814N".
+func XmpGps(coord float32, pos, neg byte) string {
+	ref := pos
+	v := float64(coord)
+
+	if v < 0 {
+		ref = neg
+		v = -v
+	}
+
+	deg := math.Floor(v)
+
+	return fmt.Sprintf("%d,%.4f%c", int(deg), (v-deg)*60, ref)
+}
+
+// XmpNode represents an element or another raw token in an XMP document.
+type XmpNode struct {
+	Name   xml.Name
+	Attr   []xml.Attr
+	Nodes  []*XmpNode
+	Parent *XmpNode
+	Token  xml.Token
+}
+
+// XmpTree represents an XMP document that can be modified without losing unknown content.
+type XmpTree struct {
+	Root         *XmpNode
+	Descriptions []*XmpNode
+}
+
+// ParseXmpTree parses an XMP document, namespace prefixes and formatting are kept as they are.
+func ParseXmpTree(data []byte) (*XmpTree, error) {
+	root := &XmpNode{}
+	current := root
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		token, err := dec.RawToken()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &XmpNode{Name: t.Name, Attr: t.Copy().Attr, Parent: current}
+			current.Nodes = append(current.Nodes, n)
+			current = n
+		case xml.EndElement:
+			if current == root || current.Name != t.Name {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+
+			current = current.Parent
+		default:
+			current.Nodes = append(current.Nodes, &XmpNode{Token: xml.CopyToken(t), Parent: current})
+		}
+	}
+
+	if current != root {
+		return nil, fmt.Errorf("element %s not closed", current.Name.Local)
+	}
+
+	rdf := root.Find(XmpNsRdf, "RDF")
+
+	if rdf == nil {
+		return nil, fmt.Errorf("rdf element not found")
+	}
+
+	doc := &XmpTree{Root: root}
+
+	for _, n := range rdf.Nodes {
+		if n.IsElement(XmpNsRdf, "Description") {
+			doc.Descriptions = append(doc.Descriptions, n)
+		}
+	}
+
+	if len(doc.Descriptions) == 0 {
+		prefix, _ := rdf.Prefix(XmpNsRdf)
+		desc := &XmpNode{
+			Name: xml.Name{Space: prefix, Local: "Description"},
+			Attr: []xml.Attr{{Name: xml.Name{Space: prefix, Local: "about"}}},
+		}
+
+		rdf.Append(desc)
+		doc.Descriptions = append(doc.Descriptions, desc)
+	}
+
+	return doc, nil
+}
+
+// Bytes returns the XMP document as XML.
+func (doc *XmpTree) Bytes() []byte {
+	var buf bytes.Buffer
+
+	doc.Root.write(&buf)
+
+	return buf.Bytes()
+}
+
+// Replace returns an empty property element, an existing element is reused so that its
+// position stays the same. Other occurrences in attributes or elements are removed.
+func (doc *XmpTree) Replace(ns, prefix, name string) (result *XmpNode) {
+	for _, desc := range doc.Descriptions {
+		attr := desc.Attr[:0]
+
+		for _, a := range desc.Attr {
+			if a.Name.Local == name && a.Name.Space != "" && a.Name.Space != "xmlns" && desc.Namespace(a.Name.Space) == ns {
+				continue
+			}
+
+			attr = append(attr, a)
+		}
+
+		desc.Attr = attr
+
+		for _, n := range desc.Elements(ns, name) {
+			if result == nil {
+				result = n
+				result.Nodes = nil
+			} else {
+				desc.Remove(n)
+			}
+		}
+	}
+
+	if result != nil {
+		return result
+	}
+
+	desc := doc.Descriptions[0]
+	result = &XmpNode{Name: xml.Name{Space: desc.Declare(ns, prefix), Local: name}}
+
+	desc.Append(result)
+
+	return result
+}
+
+// Unset removes a property from all descriptions, both as attribute and as element.
+func (doc *XmpTree) Unset(ns, name string) {
+	for _, desc := range doc.Descriptions {
+		attr := desc.Attr[:0]
+
+		for _, a := range desc.Attr {
+			if a.Name.Local == name && a.Name.Space != "" && a.Name.Space != "xmlns" && desc.Namespace(a.Name.Space) == ns {
+				continue
+			}
+
+			attr = append(attr, a)
+		}
+
+		desc.Attr = attr
+
+		for _, n := range desc.Elements(ns, name) {
+			desc.Remove(n)
+		}
+	}
+}
+
+// SetText replaces a simple property.
+func (doc *XmpTree) SetText(ns, prefix, name, value string) {
+	n := doc.Replace(ns, prefix, name)
+	n.Nodes = []*XmpNode{{Token: xml.CharData(value), Parent: n}}
+}
+
+// SetAlt replaces a language alternative property with a default value.
+func (doc *XmpTree) SetAlt(ns, prefix, name, value string) {
+	n := doc.Replace(ns, prefix, name)
+	rdf := n.Declare(XmpNsRdf, "rdf")
+	alt := &XmpNode{Name: xml.Name{Space: rdf, Local: "Alt"}}
+	n.Append(alt)
+
+	li := &XmpNode{
+		Name: xml.Name{Space: rdf, Local: "li"},
+		Attr: []xml.Attr{{Name: xml.Name{Space: "xml", Local: "lang"}, Value: "x-default"}},
+	}
+
+	alt.Append(li)
+	li.Nodes = []*XmpNode{{Token: xml.CharData(value), Parent: li}}
+}
+
+// SetBag replaces an unordered array property.
+func (doc *XmpTree) SetBag(ns, prefix, name string, values []string) {
+	n := doc.Replace(ns, prefix, name)
+	rdf := n.Declare(XmpNsRdf, "rdf")
+	bag := &XmpNode{Name: xml.Name{Space: rdf, Local: "Bag"}}
+	n.Append(bag)
+
+	for _, v := range values {
+		li := &XmpNode{Name: xml.Name{Space: rdf, Local: "li"}}
+		bag.Append(li)
+		li.Nodes = []*XmpNode{{Token: xml.CharData(v), Parent: li}}
+	}
+}
+
+// IsElement tests if the node is an element with the given namespace and name.
+func (n *XmpNode) IsElement(ns, name string) bool {
+	return n.Token == nil && n.Name.Local == name && n.Namespace(n.Name.Space) == ns
+}
+
+// Find returns the first descendant element with the given namespace and name.
+func (n *XmpNode) Find(ns, name string) *XmpNode {
+	for _, c := range n.Nodes {
+		if c.Token != nil {
+			continue
+		} else if c.IsElement(ns, name) {
+			return c
+		} else if result := c.Find(ns, name); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// Elements returns all child elements with the given namespace and name.
+func (n *XmpNode) Elements(ns, name string) (result []*XmpNode) {
+	for _, c := range n.Nodes {
+		if c.IsElement(ns, name) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// Namespace returns the namespace URI of a prefix in the scope of the node.
+func (n *XmpNode) Namespace(prefix string) string {
+	if prefix == "xml" {
+		return XmpNsXml
+	}
+
+	for e := n; e != nil; e = e.Parent {
+		for _, a := range e.Attr {
+			if prefix == "" && a.Name.Space == "" && a.Name.Local == "xmlns" {
+				return a.Value
+			} else if prefix != "" && a.Name.Space == "xmlns" && a.Name.Local == prefix {
+				return a.Value
+			}
+		}
+	}
+
+	return ""
+}
+
+// Prefix returns the prefix of a namespace URI in the scope of the node.
+func (n *XmpNode) Prefix(ns string) (string, bool) {
+	for e := n; e != nil; e = e.Parent {
+		for _, a := range e.Attr {
+			if a.Name.Space == "xmlns" && a.Value == ns && n.Namespace(a.Name.Local) == ns {
+				return a.Name.Local, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Declare returns the prefix of a namespace URI and adds a declaration if needed.
+func (n *XmpNode) Declare(ns, prefix string) string {
+	if p, ok := n.Prefix(ns); ok {
+		return p
+	}
+
+	p := prefix
+
+	for i := 1; n.Namespace(p) != ""; i++ {
+		p = fmt.Sprintf("%s%d", prefix, i)
+	}
+
+	n.Attr = append(n.Attr, xml.Attr{Name: xml.Name{Space: "xmlns", Local: p}, Value: ns})
+
+	return p
+}
+
+// Append adds a child element, indented like its siblings.
+func (n *XmpNode) Append(e *XmpNode) {
+	e.Parent = n
+
+	nodes := n.Nodes
+	var tail []*XmpNode
+
+	if l := len(nodes); l > 0 && nodes[l-1].IsSpace() {
+		tail = []*XmpNode{nodes[l-1]}
+		nodes = nodes[:l-1]
+	} else {
+		tail = []*XmpNode{{Token: xml.CharData(n.Indent()), Parent: n}}
+	}
+
+	indent := &XmpNode{Token: xml.CharData(n.ChildIndent()), Parent: n}
+
+	n.Nodes = append(append(nodes, indent, e), tail...)
+}
+
+// Remove removes a child node including leading whitespace.
+func (n *XmpNode) Remove(c *XmpNode) {
+	for i, node := range n.Nodes {
+		if node != c {
+			continue
+		}
+
+		if i > 0 && n.Nodes[i-1].IsSpace() {
+			n.Nodes = append(n.Nodes[:i-1], n.Nodes[i+1:]...)
+		} else {
+			n.Nodes = append(n.Nodes[:i], n.Nodes[i+1:]...)
+		}
+
+		return
+	}
+}
+
+// IsSpace tests if the node only contains whitespace characters.
+func (n *XmpNode) IsSpace() bool {
+	if data, ok := n.Token.(xml.CharData); ok {
+		return strings.TrimSpace(string(data)) == ""
+	}
+
+	return false
+}
+
+// Indent returns the whitespace in front of the element.
+func (n *XmpNode) Indent() string {
+	if n.Parent == nil {
+		return ""
+	}
+
+	for i, c := range n.Parent.Nodes {
+		if c == n && i > 0 && n.Parent.Nodes[i-1].IsSpace() {
+			return string(n.Parent.Nodes[i-1].Token.(xml.CharData))
+		}
+	}
+
+	return "\n"
+}
+
+// ChildIndent returns the whitespace in front of child elements.
+func (n *XmpNode) ChildIndent() string {
+	for i, c := range n.Nodes {
+		if c.Token == nil && i > 0 && n.Nodes[i-1].IsSpace() {
+			return string(n.Nodes[i-1].Token.(xml.CharData))
+		}
+	}
+
+	return n.Indent() + n.IndentUnit()
+}
+
+// IndentUnit returns the whitespace added per nesting level, as used in the document.
+func (n *XmpNode) IndentUnit() string {
+	for e := n.Parent; e != nil && e.Parent != nil; e = e.Parent {
+		for i, c := range e.Nodes {
+			if c.Token != nil || i == 0 || !e.Nodes[i-1].IsSpace() {
+				continue
+			}
+
+			outer, inner := e.Indent(), string(e.Nodes[i-1].Token.(xml.CharData))
+
+			if len(inner) > len(outer) && strings.HasPrefix(inner, outer) {
+				return inner[len(outer):]
+			}
+		}
+	}
+
+	return "   "
+}
+
+// write serializes the node and its children.
+func (n *XmpNode) write(buf *bytes.Buffer) {
+	switch t := n.Token.(type) {
+	case xml.CharData:
+		buf.WriteString(xmpTextEscaper.Replace(string(t)))
+		return
+	case xml.Comment:
+		buf.WriteString("<!--")
+		buf.Write(t)
+		buf.WriteString("-->")
+		return
+	case xml.ProcInst:
+		buf.WriteString("<?" + t.Target)
+
+		if len(t.Inst) > 0 {
+			buf.WriteString(" ")
+			buf.Write(t.Inst)
+		}
+
+		buf.WriteString("?>")
+		return
+	case xml.Directive:
+		buf.WriteString("<!")
+		buf.Write(t)
+		buf.WriteString(">")
+		return
+	}
+
+	if n.Name.Local == "" {
+		for _, c := range n.Nodes {
+			c.write(buf)
+		}
+
+		return
+	}
+
+	buf.WriteString("<" + xmpQName(n.Name))
+
+	for _, a := range n.Attr {
+		buf.WriteString(" " + xmpQName(a.Name) + "=\"" + xmpAttrEscaper.Replace(a.Value) + "\"")
+	}
+
+	if len(n.Nodes) == 0 {
+		buf.WriteString("/>")
+		return
+	}
+
+	buf.WriteString(">")
+
+	for _, c := range n.Nodes {
+		c.write(buf)
+	}
+
+	buf.WriteString("</" + xmpQName(n.Name) + ">")
+}
+
+// xmpQName returns a qualified XML name with prefix.
+func xmpQName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,200 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_SaveXMP(t *testing.T) {
+	t.Run("new file", func(t *testing.T) {
+		fileName := "testdata/new-sidecar.xmp"
+
+		defer os.Remove(fileName)
+
+		data := Data{
+			Title:        "Berlin / Fernsehturm",
+			Description:  "Tower & <TV>",
+			Keywords:     "berlin, tower, Berlin",
+			Copyright:    "CC BY-SA 4.0",
+			Rating:       5,
+			ColorLabel:   "red",
+			TakenAt:      time.Date(2020, 1, 1, 16, 28, 23, 0, time.UTC),
+			TakenAtLocal: time.Date(2020, 1, 1, 17, 28, 23, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+			Lat:          52.520645,
+			Lng:          -13.409779,
+			Altitude:     -12,
+		}
+
+		if err := data.SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Berlin / Fernsehturm", result.Title)
+		assert.Equal(t, "Tower & <TV>", result.Description)
+		assert.Equal(t, "berlin, tower", result.Keywords)
+		assert.Equal(t, "CC BY-SA 4.0", result.Copyright)
+		assert.Equal(t, 5, result.Rating)
+		assert.Equal(t, "red", result.ColorLabel)
+		assert.Equal(t, data.TakenAt, result.TakenAt)
+		assert.Equal(t, data.TakenAtLocal, result.TakenAtLocal)
+		assert.InDelta(t, data.Lat, result.Lat, 0.00001)
+		assert.InDelta(t, data.Lng, result.Lng, 0.00001)
+		assert.Equal(t, -12, result.Altitude)
+	})
+
+	t.Run("photoshop", func(t *testing.T) {
+		fileName := "testdata/photoshop-edited.xmp"
+		original, err := ioutil.ReadFile("testdata/photoshop.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fileName, original, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		if err := (Data{Title: "Day Shift", Keywords: "desk, tea"}).SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Day Shift", result.Title)
+		assert.Equal(t, "desk, tea", result.Keywords)
+		assert.Equal(t, "", result.Description)
+		assert.Equal(t, "", result.Copyright)
+		assert.Equal(t, "Michael Mayer", result.Artist)
+		assert.Equal(t, 0, result.Rating)
+
+		b, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content := string(b)
+
+		assert.True(t, strings.HasPrefix(content, "<?xpacket begin="))
+		assert.Contains(t, content, `xmlns:Iptc4xmpCore="http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/"`)
+		assert.Contains(t, content, "<Iptc4xmpCore:CiEmailWork>hello@photoprism.org</Iptc4xmpCore:CiEmailWork>")
+		assert.Equal(t, 1, strings.Count(content, "<dc:title>"))
+		assert.NotContains(t, content, "Night Shift")
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		fileName := "testdata/darktable-edited.xmp"
+		original := `<?xml version="1.0" encoding="UTF-8"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 4.4.0-Exiv2">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:darktable="http://darktable.sf.net/"
+    xmp:Rating="1"
+    darktable:history_end="2">
+   <darktable:history>
+    <rdf:Seq>
+     <rdf:li darktable:operation="exposure"/>
+    </rdf:Seq>
+   </darktable:history>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+		if err := ioutil.WriteFile(fileName, []byte(original), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		if err := (Data{Title: "Darktable", Rating: 3}).SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Darktable", result.Title)
+		assert.Equal(t, 3, result.Rating)
+
+		b, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content := string(b)
+
+		assert.NotContains(t, content, `xmp:Rating="1"`)
+		assert.Contains(t, content, `darktable:history_end="2"`)
+		assert.Contains(t, content, `<rdf:li darktable:operation="exposure"/>`)
+		assert.Contains(t, content, `xmlns:dc="http://purl.org/dc/elements/1.1/"`)
+		assert.Contains(t, content, "\n   <dc:title>\n    <rdf:Alt>\n     <rdf:li xml:lang=\"x-default\">Darktable</rdf:li>\n    </rdf:Alt>\n   </dc:title>\n")
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		fileName := "testdata/cleared-sidecar.xmp"
+
+		defer os.Remove(fileName)
+
+		if err := (Data{Title: "Cleared", Description: "Text", Keywords: "tea", Copyright: "Me", Rating: 5, ColorLabel: "red"}).SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := (Data{}).SaveXMP(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", result.Title)
+		assert.Equal(t, "", result.Description)
+		assert.Equal(t, "", result.Keywords)
+		assert.Equal(t, "", result.Copyright)
+		assert.Equal(t, 0, result.Rating)
+		assert.Equal(t, "", result.ColorLabel)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fileName := "testdata/invalid-sidecar.xmp"
+
+		if err := ioutil.WriteFile(fileName, []byte("<x:xmpmeta><rdf:RDF>"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		assert.Error(t, Data{Title: "Invalid"}.SaveXMP(fileName))
+	})
+}
+
+func TestXmpGps(t *testing.T) {
+	assert.Equal(t, "52,27.5814N", XmpGps(52.45969, 'N', 'S'))
+	assert.Equal(t, "13,19.3099W", XmpGps(-13.321832, 'E', 'W'))
+}
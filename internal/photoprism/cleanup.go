@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fastwalk"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -64,8 +65,9 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 
 	if err := fastwalk.Walk(thumbPath, func(fileName string, info os.FileMode) error {
 		base := filepath.Base(fileName)
+		isTiles := info.IsDir() && strings.HasSuffix(base, thumb.TilesSuffix)
 
-		if info.IsDir() || strings.HasPrefix(base, ".") {
+		if info.IsDir() && !isTiles || strings.HasPrefix(base, ".") {
 			return nil
 		}
 
@@ -78,6 +80,23 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 		hash := base[:i]
 		logName := txt.Quote(fs.RelName(fileName, thumbPath))
 
+		if isTiles {
+			// Image tiles are removed together with their folder.
+			if ok := hashes[hash]; ok {
+				// Do nothing.
+			} else if opt.Dry {
+				thumbs++
+				log.Debugf("cleanup: orphaned tiles %s would be removed", logName)
+			} else if err := os.RemoveAll(fileName); err != nil {
+				log.Warnf("cleanup: %s in %s", err, logName)
+			} else {
+				thumbs++
+				log.Debugf("cleanup: removed orphaned tiles %s", logName)
+			}
+
+			return filepath.SkipDir
+		}
+
 		if ok := hashes[hash]; ok {
 			// Do nothing.
 		} else if opt.Dry {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -13,9 +14,11 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/disintegration/imaging"
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -257,6 +260,15 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 		"xmpName":  filepath.Base(xmpName),
 	})
 
+	// Use the embedded preview if possible, as external converters are slow and may not be installed.
+	if f.IsRaw() && !c.conf.DisableRawPreview() {
+		if mediaFile, err := c.RawPreview(f, jpegName); err != nil {
+			log.Debugf("convert: %s", err)
+		} else {
+			return mediaFile, nil
+		}
+	}
+
 	if f.IsImageOther() {
 		_, err = thumb.Jpeg(f.FileName(), jpegName)
 
@@ -302,6 +314,62 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 	return NewMediaFile(jpegName)
 }
 
+// RawPreview saves the largest JPEG preview embedded in a RAW file with the correct orientation.
+func (c *Convert) RawPreview(f *MediaFile, jpegName string) (*MediaFile, error) {
+	preview, err := meta.RawPreview(f.FileName())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Smaller previews are not usable as they can't be used for the fit_720 thumbnail.
+	if minSize := thumb.Types["fit_720"].Width; preview.Width < minSize && preview.Height < minSize {
+		return nil, fmt.Errorf("convert: embedded preview of %s is too small (%dx%d)", txt.Quote(f.BaseName()), preview.Width, preview.Height)
+	}
+
+	src, err := os.Open(f.FileName())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer src.Close()
+
+	data := io.NewSectionReader(src, preview.Offset, preview.Length)
+
+	// RAW files store the orientation in their own metadata, previews are usually not rotated.
+	if orientation := f.Orientation(); orientation > 1 {
+		img, err := imaging.Decode(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := imaging.Save(thumb.Rotate(img, orientation), jpegName, imaging.JPEGQuality(c.conf.JpegQuality())); err != nil {
+			return nil, err
+		}
+
+		return NewMediaFile(jpegName)
+	}
+
+	dest, err := os.Create(jpegName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(dest, data); err != nil {
+		dest.Close()
+		return nil, err
+	}
+
+	if err := dest.Close(); err != nil {
+		return nil, err
+	}
+
+	return NewMediaFile(jpegName)
+}
+
 // AvcConvertCommand returns the command for converting video files to MPEG-4 AVC.
 func (c *Convert) AvcConvertCommand(f *MediaFile, avcName string) (result *exec.Cmd, useMutex bool, err error) {
 	if f.IsVideo() {
@@ -390,3 +458,57 @@ func (c *Convert) ToAvc(f *MediaFile) (*MediaFile, error) {
 
 	return NewMediaFile(avcName)
 }
+
+// ToMotionVideo extracts the video embedded in a Samsung or Google motion photo to the sidecar folder.
+func (c *Convert) ToMotionVideo(f *MediaFile) (*MediaFile, error) {
+	if f == nil {
+		return nil, fmt.Errorf("convert: file is nil (found a bug?)")
+	}
+
+	if !f.Exists() {
+		return nil, fmt.Errorf("convert: can not extract video, file does not exist (%s)", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	video, err := meta.MotionPhoto(f.FileName())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.conf.SidecarWritable() {
+		return nil, fmt.Errorf("convert: disabled in read only mode (%s)", f.RelName(c.conf.OriginalsPath()))
+	}
+
+	videoName := fs.FileName(f.FileName(), c.conf.SidecarPath(), c.conf.OriginalsPath(), fs.Mp4Ext)
+
+	if mediaFile, err := NewMediaFile(videoName); err == nil && mediaFile.FileSize() == video.Length {
+		return mediaFile, nil
+	}
+
+	log.Debugf("convert: %s -> %s", f.RelName(c.conf.OriginalsPath()), filepath.Base(videoName))
+
+	src, err := os.Open(f.FileName())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer src.Close()
+
+	dest, err := os.Create(videoName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(dest, io.NewSectionReader(src, video.Offset, video.Length)); err != nil {
+		dest.Close()
+		return nil, err
+	}
+
+	if err := dest.Close(); err != nil {
+		return nil, err
+	}
+
+	return NewMediaFile(videoName)
+}
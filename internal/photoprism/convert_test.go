@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
@@ -244,3 +245,95 @@ func TestConvert_Start(t *testing.T) {
 
 	assert.NotEqual(t, oldHash, newHash, "Fingerprint of old and new JPEG file must not be the same")
 }
+
+func TestConvert_ToMotionVideo(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("motion-photo.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile("testdata/motion-photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := convert.ToMotionVideo(mf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(videoFile.FileName())
+
+		assert.Equal(t, "motion-photo.jpg.mp4", videoFile.BaseName())
+		assert.True(t, videoFile.IsVideo())
+		assert.Equal(t, int64(586), videoFile.FileSize())
+	})
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := convert.ToMotionVideo(mf)
+
+		assert.Error(t, err)
+		assert.Nil(t, videoFile)
+	})
+}
+
+func TestConvert_RawPreview(t *testing.T) {
+	conf := config.TestConfig()
+	convert := NewConvert(conf)
+
+	t.Run("raw-preview.cr2", func(t *testing.T) {
+		mf, err := NewMediaFile("testdata/raw-preview.cr2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegName := "testdata/raw-preview.cr2.jpg"
+
+		jpegFile, err := convert.RawPreview(mf, jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(jpegName)
+
+		assert.True(t, jpegFile.IsJpeg())
+
+		img, err := imaging.Open(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The preview must be rotated by 90° clockwise, so that the red corner is at the top right.
+		assert.Equal(t, 720, img.Bounds().Dx())
+		assert.Equal(t, 960, img.Bounds().Dy())
+
+		r, g, b, _ := img.At(700, 20).RGBA()
+
+		assert.Greater(t, r>>8, uint32(200))
+		assert.Less(t, g>>8, uint32(50))
+		assert.Less(t, b>>8, uint32(50))
+	})
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(conf.ExamplesPath(), "elephants.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegFile, err := convert.RawPreview(mf, "testdata/elephants.jpg.jpg")
+
+		assert.Error(t, err)
+		assert.Nil(t, jpegFile)
+	})
+}
@@ -0,0 +1,95 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Estimate represents a worker that estimates the location of photos without GPS.
+type Estimate struct {
+	conf *config.Config
+}
+
+// NewEstimate returns a new location estimate worker.
+func NewEstimate(conf *config.Config) *Estimate {
+	instance := &Estimate{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start estimates the location of photos without GPS based on time-adjacent geotagged photos.
+// Existing estimates are updated, so that it can be run again e.g. after adding GPS tracks.
+func (w *Estimate) Start(opt entity.EstimateOptions) (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("estimate: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return updated, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	limit := 100
+	offset := 0
+
+	for {
+		photos, err := query.PhotosEstimate(limit, offset)
+
+		if err != nil {
+			return updated, err
+		}
+
+		if len(photos) == 0 {
+			break
+		}
+
+		for _, p := range photos {
+			if mutex.MainWorker.Canceled() {
+				return updated, errors.New("estimate: canceled")
+			}
+
+			if !p.EstimatePosition(opt) {
+				continue
+			}
+
+			if err := p.Save(); err != nil {
+				log.Errorf("estimate: %s (update %s)", err, p.String())
+				continue
+			}
+
+			if w.conf.BackupYaml() {
+				yamlFile := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+				if err := p.LoadLabels(); err != nil {
+					log.Errorf("estimate: %s (load labels)", err)
+				} else if err := p.SaveAsYaml(yamlFile); err != nil {
+					log.Errorf("estimate: %s (update yaml)", err)
+				}
+			}
+
+			updated++
+		}
+
+		offset += limit
+	}
+
+	if updated > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("estimate: %s", err)
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,19 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestEstimate_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	w := NewEstimate(conf)
+
+	if _, err := w.Start(entity.EstimateOptions{Window: time.Hour, SameFolder: true}); err != nil {
+		t.Fatal(err)
+	}
+}
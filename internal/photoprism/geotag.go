@@ -0,0 +1,230 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/track"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Geotag represents a worker that adds coordinates from GPS tracks to photos without location.
+type Geotag struct {
+	conf *config.Config
+}
+
+// GeotagResult represents a photo location change based on a GPS track.
+type GeotagResult struct {
+	PhotoUID     string    `json:"UID"`
+	PhotoTitle   string    `json:"Title"`
+	TakenAt      time.Time `json:"TakenAt"`
+	TrackTime    time.Time `json:"TrackTime"`
+	Lat          float32   `json:"Lat"`
+	Lng          float32   `json:"Lng"`
+	Altitude     int       `json:"Altitude"`
+	PrevLat      float32   `json:"PrevLat"`
+	PrevLng      float32   `json:"PrevLng"`
+	PrevPlaceSrc string    `json:"PrevPlaceSrc"`
+}
+
+// GeotagResults represents a list of photo location changes.
+type GeotagResults []GeotagResult
+
+// NewGeotag returns a new geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	instance := &Geotag{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start matches photos with GPS tracks and updates their location unless it's a dry run.
+func (w *Geotag) Start(opt GeotagOptions) (results GeotagResults, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	// Dry runs don't change anything and may run in parallel to other workers.
+	if !opt.DryRun {
+		if err := mutex.MainWorker.Start(); err != nil {
+			return results, err
+		}
+
+		defer mutex.MainWorker.Stop()
+	}
+
+	loc := time.UTC
+
+	if opt.TimeZone != "" {
+		if loc, err = time.LoadLocation(opt.TimeZone); err != nil {
+			return results, fmt.Errorf("geotag: invalid time zone %s", txt.Quote(opt.TimeZone))
+		}
+	}
+
+	t, err := w.Track(opt.Files)
+
+	if err != nil {
+		return results, err
+	}
+
+	// Photos without time zone may have been taken up to 14 hours before or after UTC.
+	margin := 14*time.Hour + opt.Offset
+
+	if opt.Offset < 0 {
+		margin = 14*time.Hour - opt.Offset
+	}
+
+	photos, err := query.GeotagPhotos(t.Start().Add(-margin), t.End().Add(margin))
+
+	if err != nil {
+		return results, err
+	}
+
+	log.Infof("geotag: matching %d photos with %d track points", len(photos), len(t))
+
+	for _, p := range photos {
+		if mutex.MainWorker.Canceled() {
+			return results, fmt.Errorf("geotag: worker canceled")
+		}
+
+		at := GeotagTime(p, loc).Add(opt.Offset)
+		pos, ok := t.Position(at, opt.MaxGap)
+
+		if !ok {
+			continue
+		}
+
+		r := GeotagResult{
+			PhotoUID:     p.PhotoUID,
+			PhotoTitle:   p.PhotoTitle,
+			TakenAt:      p.TakenAt,
+			TrackTime:    at,
+			Lat:          float32(pos.Lat),
+			Lng:          float32(pos.Lng),
+			Altitude:     int(pos.Altitude),
+			PrevLat:      p.PhotoLat,
+			PrevLng:      p.PhotoLng,
+			PrevPlaceSrc: p.PlaceSrc,
+		}
+
+		if r.Lat == r.PrevLat && r.Lng == r.PrevLng {
+			continue
+		}
+
+		results = append(results, r)
+
+		if opt.DryRun {
+			log.Infof("geotag: would set location of %s to %f, %f (dry run)", p.String(), r.Lat, r.Lng)
+			continue
+		}
+
+		if err := w.Update(p, r); err != nil {
+			log.Errorf("geotag: %s (update %s)", err, p.String())
+		} else {
+			log.Infof("geotag: set location of %s to %f, %f", p.String(), r.Lat, r.Lng)
+		}
+	}
+
+	if !opt.DryRun && len(results) > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("geotag: %s", err)
+		}
+	}
+
+	return results, nil
+}
+
+// Track returns the merged track points of the given files, or of all files in the tracks path.
+func (w *Geotag) Track(fileNames []string) (result track.Track, err error) {
+	var errs []error
+
+	if len(fileNames) == 0 {
+		result, fileNames, errs = track.ReadDir(w.conf.TracksPath())
+	} else {
+		result, errs = track.ReadFiles(fileNames)
+	}
+
+	for _, e := range errs {
+		log.Warnf("geotag: %s", e)
+	}
+
+	if result.Empty() && len(fileNames) == 0 {
+		return result, fmt.Errorf("geotag: no track files found in %s", txt.Quote(filepath.Base(w.conf.TracksPath())))
+	} else if result.Empty() {
+		return result, fmt.Errorf("geotag: no track points found in %s", txt.Quote(strings.Join(baseNames(fileNames), ", ")))
+	}
+
+	return result, nil
+}
+
+// Update sets the photo location and saves the changes.
+func (w *Geotag) Update(p entity.Photo, r GeotagResult) error {
+	p.SetCoordinates(r.Lat, r.Lng, r.Altitude, entity.SrcTrack)
+
+	if p.PlaceSrc != entity.SrcTrack {
+		return fmt.Errorf("location source %s has priority", txt.Quote(p.PlaceSrc))
+	}
+
+	details := p.GetDetails()
+	locKeywords, labels := p.UpdateLocation()
+
+	p.AddLabels(labels)
+
+	words := txt.UniqueWords(txt.Words(details.Keywords))
+	words = append(words, locKeywords...)
+	details.Keywords = strings.Join(txt.UniqueWords(words), ", ")
+
+	if err := p.IndexKeywords(); err != nil {
+		log.Errorf("geotag: %s", err)
+	}
+
+	if err := p.Save(); err != nil {
+		return err
+	}
+
+	if w.conf.BackupYaml() {
+		yamlFile := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+		if err := p.SaveAsYaml(yamlFile); err != nil {
+			log.Errorf("geotag: %s (update yaml)", err)
+		}
+	}
+
+	if _, err := SaveXmp(w.conf, p); err != nil {
+		log.Errorf("geotag: %s (update xmp)", err)
+	}
+
+	return nil
+}
+
+// GeotagTime returns the UTC time that is used to find a photo's position on a track. Photos without
+// time zone information are assumed to have been taken in the given camera time zone.
+func GeotagTime(p entity.Photo, loc *time.Location) time.Time {
+	if p.TimeZone != "" || !p.TakenAt.Equal(p.TakenAtLocal) || loc == nil {
+		return p.TakenAt.UTC()
+	}
+
+	local := p.TakenAtLocal
+
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, loc).UTC()
+}
+
+// baseNames returns the base names of the given files.
+func baseNames(fileNames []string) (result []string) {
+	for _, fileName := range fileNames {
+		result = append(result, filepath.Base(fileName))
+	}
+
+	return result
+}
@@ -0,0 +1,11 @@
+package photoprism
+
+import "time"
+
+type GeotagOptions struct {
+	Files    []string
+	Offset   time.Duration
+	MaxGap   time.Duration
+	TimeZone string
+	DryRun   bool
+}
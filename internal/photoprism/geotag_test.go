@@ -0,0 +1,162 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+const geotagTestGpx = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="Test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="52.5000" lon="13.4000"><ele>30</ele><time>2007-12-31T23:55:00Z</time></trkpt>
+    <trkpt lat="52.5100" lon="13.4100"><ele>40</ele><time>2008-01-01T00:05:00Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+func TestGeotag_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	dir, err := ioutil.TempDir("", "geotag")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "track.gpx")
+
+	if err := ioutil.WriteFile(fileName, []byte(geotagTestGpx), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewGeotag(conf)
+
+	t.Run("dry run", func(t *testing.T) {
+		results, err := w.Start(GeotagOptions{Files: []string{fileName}, MaxGap: time.Hour, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, r := range results {
+			if r.PhotoUID != "pt9jtdre2lvl0yh9" {
+				continue
+			}
+
+			found = true
+
+			assert.InDelta(t, 52.505, r.Lat, 0.0001)
+			assert.InDelta(t, 13.405, r.Lng, 0.0001)
+			assert.Equal(t, 35, r.Altitude)
+			assert.Equal(t, float32(48.519234), r.PrevLat)
+		}
+
+		assert.True(t, found)
+
+		// Dry runs must not change photos.
+		p := entity.Photo{PhotoUID: "pt9jtdre2lvl0yh9"}
+
+		if err := p.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, float32(48.519234), p.PhotoLat)
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		results, err := w.Start(GeotagOptions{Files: []string{fileName}, Offset: -4 * time.Minute, MaxGap: time.Hour, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			if r.PhotoUID == "pt9jtdre2lvl0yh9" {
+				assert.InDelta(t, 52.501, r.Lat, 0.0001)
+				assert.Equal(t, time.Date(2007, 12, 31, 23, 56, 0, 0, time.UTC), r.TrackTime)
+			}
+		}
+	})
+
+	t.Run("max gap", func(t *testing.T) {
+		results, err := w.Start(GeotagOptions{Files: []string{fileName}, MaxGap: time.Minute, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		results, err := w.Start(GeotagOptions{Files: []string{fileName}, MaxGap: time.Hour, TimeZone: "Europe/Berlin", DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Berlin is one hour ahead of UTC, so local midnight is outside the track.
+		for _, r := range results {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh9", r.PhotoUID)
+		}
+	})
+
+	t.Run("invalid time zone", func(t *testing.T) {
+		_, err := w.Start(GeotagOptions{Files: []string{fileName}, TimeZone: "Mars/Olympus", DryRun: true})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("no track", func(t *testing.T) {
+		_, err := w.Start(GeotagOptions{Files: []string{filepath.Join(dir, "missing.gpx")}, DryRun: true})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGeotagTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unknown time zone", func(t *testing.T) {
+		p := entity.Photo{
+			TakenAt:      time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+		}
+
+		assert.Equal(t, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC), GeotagTime(p, berlin))
+		assert.Equal(t, time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC), GeotagTime(p, time.UTC))
+	})
+
+	t.Run("known time zone", func(t *testing.T) {
+		p := entity.Photo{
+			TakenAt:      time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+			TimeZone:     "Europe/Berlin",
+		}
+
+		assert.Equal(t, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC), GeotagTime(p, time.UTC))
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		p := entity.Photo{
+			TakenAt:      time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+		}
+
+		assert.Equal(t, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC), GeotagTime(p, berlin))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
@@ -60,12 +61,25 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	done := make(fs.Done)
 	ind := imp.index
 	importPath := opt.Path
+	tpl := imp.Template(opt)
 
 	if !fs.PathExists(importPath) {
 		event.Error(fmt.Sprintf("import: %s does not exist", importPath))
 		return done
 	}
 
+	if err := tpl.Validate(); err != nil {
+		event.Error(fmt.Sprintf("import: %s", err.Error()))
+		return done
+	}
+
+	// Title of the album files are added to, used for the {album} template token.
+	albumTitle := ""
+
+	if tpl.Uses("album") {
+		albumTitle = ImportAlbumTitle(opt.Albums)
+	}
+
 	if err := mutex.MainWorker.Start(); err != nil {
 		event.Error(fmt.Sprintf("import: %s", err.Error()))
 		return done
@@ -93,6 +107,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 	filesImported := 0
 	indexOpt := IndexOptionsAll()
+
+	// Albums from Google Photos Takeout folders and the positions of their files.
+	albums := make(map[string]*entity.Album)
+	albumOrder := make(map[string]map[string]int)
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
 	if err := ignore.Dir(importPath); err != nil {
@@ -181,12 +199,34 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 			related.Files = files
 
+			dir := filepath.Dir(fileName)
+			album, ok := albums[dir]
+
+			if !ok {
+				album = TakeoutAlbum(dir)
+				albums[dir] = album
+
+				if album != nil {
+					albumOrder[dir] = TakeoutAlbumOrder(dir)
+				}
+			}
+
+			title := albumTitle
+
+			if album != nil {
+				title = album.AlbumTitle
+			}
+
 			jobs <- ImportJob{
-				FileName:  fileName,
-				Related:   related,
-				IndexOpt:  indexOpt,
-				ImportOpt: opt,
-				Imp:       imp,
+				FileName:   fileName,
+				Related:    related,
+				IndexOpt:   indexOpt,
+				ImportOpt:  opt,
+				Imp:        imp,
+				Album:      album,
+				AlbumOrder: albumOrder[dir][fileName],
+				AlbumTitle: title,
+				Template:   tpl,
 			}
 
 			return nil
@@ -249,10 +289,31 @@ func (imp *Import) Cancel() {
 }
 
 // DestinationFilename returns the destination filename of a MediaFile to be imported.
-func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile) (string, error) {
-	fileName := mainFile.CanonicalName()
+// The path template is rendered with the metadata of the main file, so that related files stay together.
+func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile, tpl fs.PathTemplate, album string) (string, error) {
+	values := mainFile.PathValues(album)
+
+	// Country and city are taken from the reverse geocoded location if possible.
+	if tpl.Uses("country") || tpl.Uses("city") {
+		if place := mainFile.Place(); place != nil {
+			if country := place.CountryName(); country != "" {
+				values["country"] = country
+			}
+
+			if !place.NoCity() {
+				values["city"] = place.City()
+			}
+		}
+	}
+
+	relName, err := tpl.Render(values)
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName := path.Base(relName)
 	fileExtension := mediaFile.Extension()
-	dateCreated := mainFile.DateCreated()
 
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(mediaFile.Hash()); err == nil {
@@ -265,8 +326,12 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 		}
 	}
 
-	//	Mon Jan 2 15:04:05 -0700 MST 2006
-	pathName := filepath.Join(imp.originalsPath(), dateCreated.Format("2006/01"))
+	// Keep edited versions recognizable, so that they are stacked with their original.
+	if mediaFile != mainFile && mediaFile.IsEdited() && !mainFile.IsEdited() {
+		fileName += fs.EditedSuffix
+	}
+
+	pathName := filepath.Join(imp.originalsPath(), filepath.FromSlash(path.Dir(relName)))
 
 	iteration := 0
 
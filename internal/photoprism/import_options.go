@@ -7,6 +7,7 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	Template               string // Destination path template, uses the import settings if empty.
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
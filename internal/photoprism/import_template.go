@@ -0,0 +1,101 @@
+package photoprism
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ImportPreview represents the destination of a sample file for a path template.
+type ImportPreview struct {
+	FileName string `json:"fileName"`
+	DestName string `json:"destName"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Template returns the destination path template for the import options.
+func (imp *Import) Template(opt ImportOptions) fs.PathTemplate {
+	if opt.Template != "" {
+		return fs.PathTemplate(opt.Template)
+	} else if s := imp.conf.Settings().Import.Template; s != "" {
+		return fs.PathTemplate(s)
+	}
+
+	return fs.DefaultPathTemplate
+}
+
+// Preview returns the destination names of the files for the template, relative to the originals path.
+func (imp *Import) Preview(tpl fs.PathTemplate, fileNames []string, album string) (result []ImportPreview, err error) {
+	if err := tpl.Validate(); err != nil {
+		return result, err
+	}
+
+	for _, fileName := range fileNames {
+		preview := ImportPreview{FileName: fs.RelName(fileName, imp.conf.ImportPath())}
+
+		if mf, err := NewMediaFile(fileName); err != nil {
+			preview.Error = err.Error()
+		} else if destName, err := imp.DestinationFilename(mf, mf, tpl, album); err != nil {
+			preview.DestName = fs.RelName(destName, imp.originalsPath())
+			preview.Error = err.Error()
+		} else {
+			preview.DestName = fs.RelName(destName, imp.originalsPath())
+		}
+
+		result = append(result, preview)
+	}
+
+	return result, nil
+}
+
+// PreviewFiles returns up to limit media file names in the import path that can be used to preview templates.
+func (imp *Import) PreviewFiles(limit int) (result []string) {
+	importPath := imp.conf.ImportPath()
+	found := errors.New("found enough files")
+
+	_ = filepath.Walk(importPath, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		} else if len(result) >= limit {
+			return found
+		}
+
+		hidden := strings.HasPrefix(info.Name(), ".")
+
+		if info.IsDir() {
+			if hidden && fileName != importPath {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !hidden && fs.IsMedia(fileName) {
+			result = append(result, fileName)
+		}
+
+		return nil
+	})
+
+	return result
+}
+
+// ImportAlbumTitle returns the title of the first album, which may be given as uid or title.
+func ImportAlbumTitle(albums []string) string {
+	for _, album := range albums {
+		if album == "" {
+			continue
+		} else if !rnd.IsPPID(album, 'a') {
+			return album
+		} else if a, err := query.AlbumByUID(album); err == nil {
+			return a.AlbumTitle
+		}
+	}
+
+	return ""
+}
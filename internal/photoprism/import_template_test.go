@@ -0,0 +1,77 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport_Template(t *testing.T) {
+	conf := config.TestConfig()
+	imp := NewImport(conf, nil, nil)
+
+	assert.Equal(t, fs.PathTemplate(fs.DefaultPathTemplate), imp.Template(ImportOptionsCopy(conf.ImportPath())))
+
+	opt := ImportOptionsMove(conf.ImportPath())
+	opt.Template = "{yyyy}/{name}"
+
+	assert.Equal(t, fs.PathTemplate("{yyyy}/{name}"), imp.Template(opt))
+}
+
+func TestImport_Preview(t *testing.T) {
+	conf := config.TestConfig()
+	imp := NewImport(conf, nil, nil)
+
+	fileNames := []string{"testdata/digikam.jpg", "testdata/missing.jpg"}
+	result, err := imp.Preview("{make}/{yyyy}/{album}/{yyyy}{mm}{dd}_{name}_{hash}", fileNames, "Holiday/2020")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, "HUAWEI/2020/Holiday_2020/20201017_digikam_c0c7135.jpg", result[0].DestName)
+	assert.Empty(t, result[0].Error)
+	assert.Empty(t, result[1].DestName)
+	assert.NotEmpty(t, result[1].Error)
+
+	_, err = imp.Preview("{type", fileNames, "")
+
+	assert.EqualError(t, err, "template contains an opening brace without closing brace")
+}
+
+func TestImport_TemplatePlace(t *testing.T) {
+	conf := config.TestConfig()
+	imp := NewImport(conf, nil, nil)
+
+	t.Run("place", func(t *testing.T) {
+		mf, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		place := entity.PlaceFixtures.Pointer("mexico")
+
+		if cell := entity.FirstOrCreateCell(&entity.Cell{ID: mf.MetaData().CellID(), PlaceID: place.ID}); cell == nil {
+			t.Fatal("cell should not be nil")
+		}
+
+		result, err := imp.DestinationFilename(mf, mf, "{country}/{city}/{name}", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Mexico/Teotihuacán/elephants.jpg", fs.RelName(result, conf.OriginalsPath()))
+	})
+}
+
+func TestImportAlbumTitle(t *testing.T) {
+	assert.Equal(t, "", ImportAlbumTitle(nil))
+	assert.Equal(t, "Holiday", ImportAlbumTitle([]string{"", "Holiday"}))
+	assert.Equal(t, "Christmas2030", ImportAlbumTitle([]string{"at9lxuqxpogaaba7"}))
+}
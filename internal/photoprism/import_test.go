@@ -6,6 +6,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,7 +42,7 @@ func TestImport_DestinationFilename(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fileName, err := imp.DestinationFilename(rawFile, rawFile)
+	fileName, err := imp.DestinationFilename(rawFile, rawFile, fs.DefaultPathTemplate, "")
 
 	if err != nil {
 		t.Fatal(err)
@@ -13,11 +13,15 @@ import (
 )
 
 type ImportJob struct {
-	FileName  string
-	Related   RelatedFiles
-	IndexOpt  IndexOptions
-	ImportOpt ImportOptions
-	Imp       *Import
+	FileName   string
+	Related    RelatedFiles
+	IndexOpt   IndexOptions
+	ImportOpt  ImportOptions
+	Imp        *Import
+	Album      *entity.Album
+	AlbumOrder int
+	AlbumTitle string
+	Template   fs.PathTemplate
 }
 
 func ImportWorker(jobs <-chan ImportJob) {
@@ -28,6 +32,11 @@ func ImportWorker(jobs <-chan ImportJob) {
 		opt := job.ImportOpt
 		indexOpt := job.IndexOpt
 		importPath := job.ImportOpt.Path
+		tpl := job.Template
+
+		if tpl == "" {
+			tpl = imp.Template(opt)
+		}
 
 		if related.Main == nil {
 			log.Warnf("import: %s belongs to no supported media file", txt.Quote(fs.RelName(job.FileName, importPath)))
@@ -54,7 +63,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		for _, f := range related.Files {
 			relFileName := f.RelName(importPath)
 
-			if destFileName, err := imp.DestinationFilename(related.Main, f); err == nil {
+			if destFileName, err := imp.DestinationFilename(related.Main, f, tpl, job.AlbumTitle); err == nil {
 				destDir := filepath.Dir(destFileName)
 
 				if fs.PathExists(destDir) {
@@ -90,6 +99,15 @@ func ImportWorker(jobs <-chan ImportJob) {
 			} else {
 				log.Warnf("import: %s", err)
 
+				// Add existing photos to the album of the folder as well.
+				if job.Album == nil || !related.Main.HasSameName(f) {
+					// Do nothing.
+				} else if existing, err := entity.FirstFileByHash(f.Hash()); err != nil {
+					log.Debugf("import: %s in %s (find existing file)", err, txt.Quote(f.BaseName()))
+				} else if err := job.Album.AddPhoto(existing.PhotoUID, job.AlbumOrder); err != nil {
+					log.Errorf("import: %s in %s (add to album)", err, txt.Quote(f.BaseName()))
+				}
+
 				if opt.RemoveExistingFiles {
 					if err := f.Remove(); err != nil {
 						log.Errorf("import: failed deleting %s (%s)", txt.Quote(f.BaseName()), err.Error())
@@ -164,6 +182,12 @@ func ImportWorker(jobs <-chan ImportJob) {
 					if err := entity.AddPhotoToAlbums(res.PhotoUID, opt.Albums); err != nil {
 						log.Warn(err)
 					}
+
+					if job.Album != nil {
+						if err := job.Album.AddPhoto(res.PhotoUID, job.AlbumOrder); err != nil {
+							log.Errorf("import: %s in %s (add to album)", err, txt.Quote(f.BaseName()))
+						}
+					}
 				} else {
 					continue
 				}
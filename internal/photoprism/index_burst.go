@@ -0,0 +1,64 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+var burstMutex = sync.Mutex{}
+
+// Burst adds a photo to the burst it was taken in, based on the burst UUID or the sequence number,
+// sub-second capture time and serial number of the camera. Returns nil if the photo isn't part of a burst.
+func (ind *Index) Burst(photo *entity.Photo, data meta.Data) (*entity.Burst, error) {
+	if photo == nil || !data.InBurst() {
+		return nil, nil
+	}
+
+	takenAt := data.TakenAtSubSec()
+
+	// Prevent concurrent workers from creating the same burst twice.
+	burstMutex.Lock()
+	defer burstMutex.Unlock()
+
+	var burst *entity.Burst
+
+	if data.BurstUUID != "" {
+		burst = entity.FindBurstByUUID(data.BurstUUID)
+	} else if photo.CameraSerial == "" || takenAt.IsZero() {
+		return nil, nil
+	} else {
+		burst = entity.FindBurstByTime(photo.CameraSerial, takenAt, ind.conf.Settings().BurstInterval())
+	}
+
+	if burst == nil {
+		burst = entity.NewBurst(data.BurstUUID, photo.CameraSerial, takenAt)
+
+		if err := burst.Create(); err != nil {
+			return nil, err
+		}
+	} else {
+		burst.AddTime(takenAt)
+
+		if err := burst.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	if prevUID := photo.BurstUID; prevUID != burst.BurstUID {
+		if err := photo.Update("BurstUID", burst.BurstUID); err != nil {
+			return nil, err
+		}
+
+		photo.BurstUID = burst.BurstUID
+
+		if prev := entity.FindBurst(prevUID); prev != nil {
+			if err := prev.UpdateBest(); err != nil {
+				log.Errorf("index: %s (update previous burst)", err)
+			}
+		}
+	}
+
+	return burst, burst.UpdateBest()
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
@@ -92,11 +93,20 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	photo := entity.NewPhoto(o.Stack)
 	metaData := meta.NewData()
 	labels := classify.Labels{}
+	restoredLabels := classify.Labels{}
+	restored := false
+	hierarchy := []string{}
 	stripSequence := Config().Settings().StackSequences() && o.Stack
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo(stripSequence)
 	fullBase := m.BasePrefix(false)
 	logName := txt.Quote(fileName)
+
+	// Stack edited versions like "IMG_E1234.HEIC" with their original.
+	if original := fs.EditedOriginal(m.FileName()); original != "" && o.Stack {
+		fileBase = fs.BasePrefix(original, stripSequence)
+		fullBase = fs.BasePrefix(original, false)
+	}
 	fileSize, modTime, err := m.Stat()
 
 	if err != nil {
@@ -237,11 +247,29 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if yamlName := fs.FormatYaml.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); yamlName != "" {
 			if err := photo.LoadFromYaml(yamlName); err != nil {
 				log.Errorf("index: %s in %s (restore from yaml)", err.Error(), logName)
-			} else if err := photo.Find(); err != nil {
-				log.Infof("index: restored from %s", txt.Quote(filepath.Base(yamlName)))
-			} else {
+			} else if yamlLabels := photo.ClassifyLabels(); photo.Find() == nil {
 				photoExists = true
 				log.Infof("index: uid %s restored from %s", photo.PhotoUID, txt.Quote(filepath.Base(yamlName)))
+			} else if o.FromSidecars {
+				// Labels are added after the photo was created.
+				restored = true
+				restoredLabels = yamlLabels
+				photo.Labels = nil
+
+				// Backups created by earlier versions don't include the local time.
+				if photo.TakenAtLocal.IsZero() {
+					photo.TakenAtLocal = photo.TakenAt
+				}
+
+				// Backups don't include the quality score.
+				if photo.PhotoQuality == -1 {
+					photo.PhotoQuality = photo.QualityScore()
+				}
+
+				log.Infof("index: restored from %s", txt.Quote(filepath.Base(yamlName)))
+			} else {
+				photo.Labels = nil
+				log.Infof("index: restored from %s", txt.Quote(filepath.Base(yamlName)))
 			}
 		}
 	}
@@ -266,10 +294,35 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if photoExists {
 			if q := entity.UnscopedDb().Where("file_type = 'jpg' AND file_primary = 1 AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
 				file.FilePrimary = m.IsJpeg()
+			} else if m.IsJpeg() && fs.IsEdited(m.FileName()) && !fs.IsEdited(primaryFile.FileName) {
+				// Edited versions take precedence over originals.
+				file.FilePrimary = true
 			}
 		} else {
 			file.FilePrimary = m.IsJpeg()
 		}
+	} else if photoExists && !fs.IsEdited(m.FileName()) {
+		// Keep edited versions as primary file if the original is indexed again later.
+		var jpegs entity.Files
+
+		if err := entity.UnscopedDb().Where("file_type = 'jpg' AND file_missing = 0 AND photo_id = ? AND id <> ?", photo.ID, file.ID).Find(&jpegs).Error; err != nil {
+			log.Errorf("index: %s in %s (find edited versions)", err, logName)
+		} else {
+			for _, f := range jpegs {
+				if !fs.IsEdited(f.FileName) {
+					continue
+				}
+
+				file.FilePrimary = false
+				f.FilePrimary = true
+
+				if err := f.ResolvePrimary(); err != nil {
+					log.Errorf("index: %s in %s (resolve primary file)", err, logName)
+				}
+
+				break
+			}
+		}
 	}
 
 	// Set file original name if available.
@@ -282,10 +335,12 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		photo.OriginalName = fs.StripKnownExt(file.OriginalName)
 	}
 
-	if photo.PhotoQuality == -1 && (file.FilePrimary || fileChanged) {
+	if restored && photo.DeletedAt != nil {
+		// Keep archived state restored from backup.
+	} else if photo.PhotoQuality == -1 && (file.FilePrimary || fileChanged) {
 		// Restore photos that have been purged automatically.
 		photo.DeletedAt = nil
-	} else if photo.DeletedAt != nil {
+	} else if photo.DeletedAt != nil && !o.FromSidecars {
 		// Don't waste time indexing deleted / archived photos.
 		result.Status = IndexArchived
 
@@ -316,6 +371,22 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			}
 		}
 
+		// Perceptual hash for finding similar images.
+		if h, err := m.Phash(Config().ThumbPath()); err != nil {
+			log.Warnf("index: %s in %s (perceptual hash)", err.Error(), logName)
+		} else {
+			file.FilePhash = h.Hex()
+		}
+
+		// Sharpness score for choosing the best frame of a burst.
+		if !Config().Settings().StackBursts() || !m.MetaData().InBurst() {
+			// Do nothing.
+		} else if score, err := m.Sharpness(Config().ThumbPath()); err != nil {
+			log.Warnf("index: %s in %s (sharpness)", err.Error(), logName)
+		} else {
+			file.FileSharpness = score
+		}
+
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
 			file.FileHeight = m.Height()
@@ -346,9 +417,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.SetDescription(metaData.Description, entity.SrcXmp)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcXmp)
+			photo.SetRating(metaData.Rating, entity.SrcXmp)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcXmp)
+
+			if !photoExists && metaData.Favorite {
+				photo.PhotoFavorite = true
+			}
 
 			// Update metadata details.
 			details.SetKeywords(metaData.Keywords, entity.SrcXmp)
+			hierarchy = append(hierarchy, metaData.Hierarchy...)
 			details.SetNotes(metaData.Notes, entity.SrcXmp)
 			details.SetSubject(metaData.Subject, entity.SrcXmp)
 			details.SetArtist(metaData.Artist, entity.SrcXmp)
@@ -356,6 +434,17 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		} else {
 			file.FileError = err.Error()
 		}
+	case m.IsAAE():
+		if adjustments, err := meta.AAE(m.FileName()); err == nil {
+			// Keep the crop area, angle and effects, as they don't describe the sidecar file itself.
+			file.FileEdits = adjustments.JSON()
+
+			if len(adjustments.Effects) > 0 {
+				log.Debugf("index: %s has adjustments %s", logName, txt.Quote(strings.Join(adjustments.Effects, ", ")))
+			}
+		} else {
+			file.FileError = err.Error()
+		}
 	case m.IsRaw(), m.IsHEIF(), m.IsImageOther():
 		if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
@@ -363,13 +452,17 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
 			details.SetKeywords(metaData.Keywords, entity.SrcMeta)
+			hierarchy = append(hierarchy, metaData.Hierarchy...)
 			details.SetNotes(metaData.Notes, entity.SrcMeta)
 			details.SetSubject(metaData.Subject, entity.SrcMeta)
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
+			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Infof("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
@@ -433,15 +526,24 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
-			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
+
+			// Keep the capture time of motion photos, as the embedded video has no time zone.
+			if !m.IsMotionVideo() {
+				photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
+			}
+
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
 			details.SetKeywords(metaData.Keywords, entity.SrcMeta)
+			hierarchy = append(hierarchy, metaData.Hierarchy...)
 			details.SetNotes(metaData.Notes, entity.SrcMeta)
 			details.SetSubject(metaData.Subject, entity.SrcMeta)
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
+			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Infof("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
@@ -498,7 +600,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 		if photo.TypeSrc == entity.SrcAuto {
 			// Update photo type only if not manually modified.
-			if file.FileDuration == 0 || file.FileDuration > time.Millisecond*3100 {
+			if m.IsMotionVideo() {
+				photo.PhotoType = entity.TypeLive
+			} else if file.FileDuration == 0 || file.FileDuration > time.Millisecond*3100 {
 				photo.PhotoType = entity.TypeVideo
 			} else {
 				photo.PhotoType = entity.TypeLive
@@ -557,13 +661,29 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
 
 			// Update metadata details.
 			details.SetKeywords(metaData.Keywords, entity.SrcMeta)
+			hierarchy = append(hierarchy, metaData.Hierarchy...)
 			details.SetNotes(metaData.Notes, entity.SrcMeta)
 			details.SetSubject(metaData.Subject, entity.SrcMeta)
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
+			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
+
+			// Keep favorite and archive flags of new photos, e.g. from Google Photos.
+			if !photoExists {
+				if metaData.Favorite {
+					photo.PhotoFavorite = true
+				}
+
+				if metaData.Archived && photo.DeletedAt == nil {
+					archived := entity.Timestamp()
+					photo.DeletedAt = &archived
+				}
+			}
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Debugf("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
@@ -667,7 +787,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		event.EntitiesCreated("photos", []entity.Photo{photo})
 	}
 
+	// Add restored labels first, so that labels removed by the user stay removed.
+	photo.AddLabels(restoredLabels)
 	photo.AddLabels(labels)
+	photo.AddLabelHierarchy(hierarchy)
 
 	file.PhotoID = photo.ID
 	result.PhotoID = photo.ID
@@ -710,6 +833,19 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			log.Tracef("index: no keywords for %s", logName)
 		}
 
+		// Assign default artist, copyright and license based on rules.
+		artist, copyright, license := ind.conf.Rights().Find(config.RightsSubject{
+			Serial:      photo.CameraSerial,
+			CameraMake:  m.CameraMake(),
+			CameraModel: m.CameraModel(),
+			Path:        photo.PhotoPath,
+			Account:     o.Account,
+		})
+
+		details.SetArtist(artist, entity.SrcRule)
+		details.SetCopyright(copyright, entity.SrcRule)
+		details.SetLicense(license, entity.SrcRule)
+
 		photo.PhotoQuality = photo.QualityScore()
 
 		if err := photo.Save(); err != nil {
@@ -802,6 +938,14 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 		return result
 	}
 
+	if !o.Stack || !file.FilePrimary || photo.PhotoStack == entity.IsUnstacked || !Config().Settings().StackBursts() {
+		// Do nothing.
+	} else if burst, err := ind.Burst(&photo, m.MetaData()); err != nil {
+		log.Errorf("index: %s in %s (burst)", err.Error(), logName)
+	} else if burst != nil {
+		log.Debugf("index: %s belongs to burst %s with %d photos", logName, burst.BurstUID, burst.PhotoCount)
+	}
+
 	if file.FilePrimary && Config().BackupYaml() {
 		// Write YAML sidecar file (optional).
 		yamlFile := photo.YamlFileName(Config().OriginalsPath(), Config().SidecarPath())
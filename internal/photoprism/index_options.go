@@ -1,10 +1,12 @@
 package photoprism
 
 type IndexOptions struct {
-	Path    string
-	Rescan  bool
-	Convert bool
-	Stack   bool
+	Path         string
+	Rescan       bool
+	Convert      bool
+	Stack        bool
+	Account      string
+	FromSidecars bool // Rebuild the index from originals and YAML sidecar files, including archived photos.
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
@@ -55,6 +55,16 @@ func IndexMain(related *RelatedFiles, ind *Index, opt IndexOptions) (result Inde
 		}
 	}
 
+	if opt.Convert && f.IsMotionPhoto() {
+		if videoFile, err := ind.convert.ToMotionVideo(f); err != nil {
+			log.Errorf("index: %s in %s (extract motion video)", err.Error(), txt.Quote(f.BaseName()))
+		} else {
+			log.Debugf("index: %s created", txt.Quote(videoFile.BaseName()))
+
+			related.Files = append(related.Files, videoFile)
+		}
+	}
+
 	result = ind.MediaFile(f, opt, "")
 
 	if result.Indexed() && f.IsJpeg() {
@@ -136,6 +146,16 @@ func IndexRelated(related RelatedFiles, ind *Index, opt IndexOptions) (result In
 			}
 		}
 
+		if opt.Convert && f.IsMotionPhoto() {
+			if videoFile, err := ind.convert.ToMotionVideo(f); err != nil {
+				log.Errorf("index: %s in %s (extract motion video)", err.Error(), txt.Quote(f.BaseName()))
+			} else {
+				log.Debugf("index: %s created", txt.Quote(videoFile.BaseName()))
+
+				related.Files = append(related.Files, videoFile)
+			}
+		}
+
 		res := ind.MediaFile(f, opt, "")
 
 		if res.Indexed() && f.IsJpeg() {
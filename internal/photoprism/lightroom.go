@@ -0,0 +1,317 @@
+package photoprism
+
+import (
+	"fmt"
+	"path"
+	"runtime/debug"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/lightroom"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Ways of matching catalog images with indexed files.
+const (
+	LightroomMatchPath = "path"
+	LightroomMatchHash = "hash"
+)
+
+// Lightroom represents a worker that imports metadata and collections from Lightroom Classic catalogs.
+type Lightroom struct {
+	conf *config.Config
+}
+
+// LightroomResult represents the changes for a catalog image.
+type LightroomResult struct {
+	ImageID   int64    `json:"ImageID"`
+	FileName  string   `json:"FileName"`
+	PhotoUID  string   `json:"UID"`
+	MatchedBy string   `json:"MatchedBy"`
+	Changes   []string `json:"Changes"`
+}
+
+// Matched tests if the catalog image was matched with an indexed file.
+func (r LightroomResult) Matched() bool {
+	return r.PhotoUID != ""
+}
+
+// LightroomResults represents the changes for all images in a catalog.
+type LightroomResults []LightroomResult
+
+// Matched returns the number of matched images.
+func (r LightroomResults) Matched() (count int) {
+	for _, result := range r {
+		if result.Matched() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// NewLightroom returns a new Lightroom catalog import worker.
+func NewLightroom(conf *config.Config) *Lightroom {
+	instance := &Lightroom{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Start imports ratings, flags, color labels, keywords, titles, captions, locations and collections
+// from a Lightroom catalog, or only reports the changes if it's a dry run.
+func (w *Lightroom) Start(opt LightroomOptions) (results LightroomResults, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("lightroom: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	// Dry runs don't change anything and may run in parallel to other workers.
+	if !opt.DryRun {
+		if err := mutex.MainWorker.Start(); err != nil {
+			return results, err
+		}
+
+		defer mutex.MainWorker.Stop()
+	}
+
+	catalog, err := lightroom.Open(opt.Catalog)
+
+	if err != nil {
+		return results, err
+	}
+
+	log.Infof("lightroom: found %d images and %d collections in %s", len(catalog.Images), len(catalog.Collections), txt.Quote(opt.Catalog))
+
+	index := make(map[int64]int, len(catalog.Images))
+
+	for _, img := range catalog.Images {
+		if mutex.MainWorker.Canceled() {
+			return results, fmt.Errorf("lightroom: worker canceled")
+		}
+
+		r := LightroomResult{ImageID: img.ID, FileName: img.FileName}
+
+		file, matchedBy := w.Match(img, opt.Path)
+
+		if matchedBy == "" {
+			log.Debugf("lightroom: no indexed file found for %s", txt.Quote(img.FileName))
+			results = append(results, r)
+			continue
+		}
+
+		p, err := query.PhotoByID(uint64(file.PhotoID))
+
+		if err != nil {
+			log.Errorf("lightroom: %s (find photo for %s)", err, txt.Quote(file.FileName))
+			results = append(results, r)
+			continue
+		}
+
+		r.PhotoUID = p.PhotoUID
+		r.MatchedBy = matchedBy
+
+		if r.Changes, err = w.Update(&p, img, opt.DryRun); err != nil {
+			log.Errorf("lightroom: %s (update %s)", err, p.String())
+		} else if len(r.Changes) == 0 {
+			log.Debugf("lightroom: %s is up to date", p.String())
+		} else if opt.DryRun {
+			log.Infof("lightroom: would change %s of %s (dry run)", strings.Join(r.Changes, ", "), p.String())
+		} else {
+			log.Infof("lightroom: changed %s of %s", strings.Join(r.Changes, ", "), p.String())
+		}
+
+		index[img.ID] = len(results)
+		results = append(results, r)
+	}
+
+	for _, c := range catalog.Collections {
+		var album *entity.Album
+
+		for order, id := range c.Images {
+			i, ok := index[id]
+
+			if !ok {
+				continue
+			}
+
+			results[i].Changes = append(results[i].Changes, fmt.Sprintf("album %s", txt.Quote(c.Name)))
+
+			if opt.DryRun {
+				continue
+			}
+
+			if album == nil {
+				if album = w.Album(c); album == nil {
+					break
+				}
+			}
+
+			if err := album.AddPhoto(results[i].PhotoUID, order); err != nil {
+				log.Errorf("lightroom: %s (add to album %s)", err, txt.Quote(album.AlbumTitle))
+			}
+		}
+	}
+
+	if !opt.DryRun && results.Matched() > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("lightroom: %s", err)
+		}
+	}
+
+	return results, nil
+}
+
+// Match finds the indexed file of a catalog image by relative path and, if the original is accessible, by hash.
+// Returns the way it was matched, or an empty string if no file was found.
+func (w *Lightroom) Match(img lightroom.Image, pathName string) (file entity.File, matchedBy string) {
+	names := []string{img.RelName, path.Join(img.RootName, img.RelName)}
+
+	for _, name := range names {
+		if file, err := query.FileByName(path.Join(pathName, name), entity.RootOriginals); err == nil {
+			return file, LightroomMatchPath
+		}
+	}
+
+	if !fs.FileExists(img.FileName) {
+		return file, ""
+	}
+
+	if file, err := query.FileByHash(fs.Hash(img.FileName)); err == nil {
+		return file, LightroomMatchHash
+	}
+
+	return file, ""
+}
+
+// Update applies the catalog metadata to a photo and returns the changes. Nothing is saved if it's a dry run.
+func (w *Lightroom) Update(p *entity.Photo, img lightroom.Image, dryRun bool) (changes []string, err error) {
+	details := p.GetDetails()
+
+	if img.Rejected() && p.PhotoRating != meta.RatingRejected {
+		if p.SetRating(meta.RatingRejected, entity.SrcLrcat); p.PhotoRating == meta.RatingRejected {
+			changes = append(changes, "rejected")
+		}
+	} else if !img.Rejected() && img.Rating > 0 && p.PhotoRating != img.Rating {
+		if p.SetRating(img.Rating, entity.SrcLrcat); p.PhotoRating == img.Rating {
+			changes = append(changes, fmt.Sprintf("rating %d", img.Rating))
+		}
+	}
+
+	if img.Flagged() && !p.PhotoFavorite {
+		p.PhotoFavorite = true
+		changes = append(changes, "favorite")
+	}
+
+	if label := meta.SanitizeColorLabel(img.ColorLabel); label != "" && p.PhotoColorLabel != label {
+		if p.SetColorLabel(label, entity.SrcLrcat); p.PhotoColorLabel == label {
+			changes = append(changes, fmt.Sprintf("color label %s", label))
+		}
+	}
+
+	if title := txt.Clip(img.Title, txt.ClipDefault); title != "" && p.PhotoTitle != title {
+		if p.SetTitle(title, entity.SrcLrcat); p.PhotoTitle == title {
+			changes = append(changes, fmt.Sprintf("title %s", txt.Quote(title)))
+		}
+	}
+
+	if caption := txt.Clip(img.Caption, txt.ClipDescription); caption != "" && p.PhotoDescription != caption {
+		if p.SetDescription(caption, entity.SrcLrcat); p.PhotoDescription == caption {
+			changes = append(changes, "caption")
+		}
+	}
+
+	locChanged := false
+
+	if lat, lng := float32(img.Lat), float32(img.Lng); img.HasLatLng() && (p.PhotoLat != lat || p.PhotoLng != lng) {
+		if p.SetCoordinates(lat, lng, p.PhotoAltitude, entity.SrcLrcat); p.PlaceSrc == entity.SrcLrcat {
+			locChanged = true
+			changes = append(changes, fmt.Sprintf("location %f, %f", lat, lng))
+		}
+	}
+
+	words := txt.UniqueWords(txt.Words(details.Keywords))
+	existing := make(map[string]bool, len(words))
+
+	for _, word := range words {
+		existing[word] = true
+	}
+
+	var added []string
+
+	for _, word := range txt.UniqueWords(txt.Words(strings.Join(img.KeywordNames(), ", "))) {
+		if !existing[word] {
+			added = append(added, word)
+		}
+	}
+
+	if len(added) > 0 {
+		changes = append(changes, fmt.Sprintf("keywords %s", txt.Quote(strings.Join(added, ", "))))
+	}
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	if locChanged {
+		locKeywords, labels := p.UpdateLocation()
+		p.AddLabels(labels)
+		words = append(words, locKeywords...)
+	}
+
+	details.Keywords = strings.Join(txt.UniqueWords(append(words, added...)), ", ")
+
+	p.AddLabelHierarchy(img.Keywords)
+	p.PhotoQuality = p.QualityScore()
+
+	if err := p.IndexKeywords(); err != nil {
+		log.Errorf("lightroom: %s", err)
+	}
+
+	if err := p.Save(); err != nil {
+		return changes, err
+	}
+
+	if w.conf.BackupYaml() {
+		yamlFile := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+		if err := p.SaveAsYaml(yamlFile); err != nil {
+			log.Errorf("lightroom: %s (update yaml)", err)
+		}
+	}
+
+	return changes, nil
+}
+
+// Album returns the album for a catalog collection, and creates it if needed. The name of the parent
+// collection set is used as album category.
+func (w *Lightroom) Album(c lightroom.Collection) *entity.Album {
+	album := entity.NewAlbum(c.Name, entity.AlbumDefault)
+
+	if err := album.Find(); err == nil {
+		return album
+	}
+
+	album.AlbumOrder = entity.SortOrderAlbum
+
+	if c.Set != "" {
+		album.AlbumCategory = txt.Title(txt.Clip(c.Set, txt.ClipKeyword))
+	}
+
+	if err := album.Create(); err != nil {
+		log.Errorf("lightroom: %s (create album %s)", err, txt.Quote(c.Name))
+		return nil
+	}
+
+	log.Infof("lightroom: created album %s", txt.Quote(album.AlbumTitle))
+
+	return album
+}
@@ -0,0 +1,7 @@
+package photoprism
+
+type LightroomOptions struct {
+	Catalog string
+	Path    string
+	DryRun  bool
+}
@@ -0,0 +1,135 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/lightroom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLightroom_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	dir, err := ioutil.TempDir("", "lightroom")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "catalog.lrcat")
+
+	sql, err := ioutil.ReadFile("../lightroom/testdata/catalog.sql")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if db, err := gorm.Open("sqlite3", fileName); err != nil {
+		t.Fatal(err)
+	} else if err := db.Exec(string(sql)).Error; err != nil {
+		db.Close()
+		t.Fatal(err)
+	} else {
+		db.Close()
+	}
+
+	w := NewLightroom(conf)
+
+	t.Run("dry run", func(t *testing.T) {
+		results, err := w.Start(LightroomOptions{Catalog: fileName, DryRun: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 3)
+		assert.Equal(t, 0, results.Matched())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := w.Start(LightroomOptions{Catalog: filepath.Join(dir, "missing.lrcat"), DryRun: true})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLightroom_Match(t *testing.T) {
+	w := NewLightroom(config.TestConfig())
+
+	t.Run("path", func(t *testing.T) {
+		file, matchedBy := w.Match(lightroom.Image{RelName: "exampleFileName.jpg", RootName: "Pictures"}, "")
+
+		assert.Equal(t, LightroomMatchPath, matchedBy)
+		assert.Equal(t, "exampleFileName.jpg", file.FileName)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, matchedBy := w.Match(lightroom.Image{RelName: "2019/missing.jpg", FileName: "/Users/jane/Pictures/2019/missing.jpg"}, "")
+
+		assert.Equal(t, "", matchedBy)
+	})
+}
+
+func TestLightroom_Update(t *testing.T) {
+	w := NewLightroom(config.TestConfig())
+
+	img := lightroom.Image{
+		Rating:     4,
+		Pick:       lightroom.PickFlagged,
+		ColorLabel: "Red",
+		Title:      "Alexanderplatz",
+		Caption:    "Evening in Berlin",
+		Lat:        52.5208,
+		Lng:        13.4094,
+		Keywords:   []string{"Places|Europe|Berlin", "Sunset"},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		p := entity.Photo{PhotoUID: "pt9jtdre2lvl0yh7", Details: &entity.Details{Keywords: "sunset"}}
+
+		changes, err := w.Update(&p, img, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"rating 4", "favorite", "color label red", "title Alexanderplatz", "caption", "location 52.520802, 13.409400", "keywords berlin"}, changes)
+		assert.Equal(t, 4, p.PhotoRating)
+		assert.Equal(t, entity.SrcLrcat, p.RatingSrc)
+		assert.Equal(t, "sunset", p.Details.Keywords)
+	})
+
+	t.Run("manual title", func(t *testing.T) {
+		p := entity.Photo{PhotoTitle: "My Title", TitleSrc: entity.SrcManual, Details: &entity.Details{}}
+
+		changes, err := w.Update(&p, lightroom.Image{Title: "Alexanderplatz"}, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, changes)
+		assert.Equal(t, "My Title", p.PhotoTitle)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		p := entity.Photo{PhotoRating: 3, Details: &entity.Details{}}
+
+		changes, err := w.Update(&p, lightroom.Image{Rating: 3, Pick: lightroom.PickRejected}, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"rejected"}, changes)
+		assert.Equal(t, -1, p.PhotoRating)
+	})
+}
@@ -222,6 +222,64 @@ func (m *MediaFile) CanonicalName() string {
 	return fs.CanonicalName(m.DateCreated(), m.Checksum())
 }
 
+// PathValues returns the values of the destination path template tokens, see fs.PathTokens.
+func (m *MediaFile) PathValues(album string) fs.PathValues {
+	date := m.DateCreated()
+	data := m.MetaData()
+
+	checksum := strings.ToUpper(m.Checksum())
+
+	if len(checksum) != 8 {
+		checksum = "EEEEEEEE"
+	}
+
+	hash := m.Hash()
+
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	return fs.PathValues{
+		"yyyy":    date.Format("2006"),
+		"yy":      date.Format("06"),
+		"mm":      date.Format("01"),
+		"dd":      date.Format("02"),
+		"hh":      date.Format("15"),
+		"ii":      date.Format("04"),
+		"ss":      date.Format("05"),
+		"make":    data.CameraMake,
+		"model":   data.CameraModel,
+		"lens":    data.LensModel,
+		"country": data.Country,
+		"city":    data.City,
+		"name":    m.BasePrefix(false),
+		"album":   album,
+		"type":    string(m.MediaType()),
+		"hash":    hash,
+		"crc":     checksum,
+	}
+}
+
+// Place returns the reverse geocoded place of the media file location, or nil if it is unknown.
+func (m *MediaFile) Place() *entity.Place {
+	data := m.MetaData()
+
+	if data.Lat == 0 && data.Lng == 0 {
+		return nil
+	}
+
+	cell := entity.NewCell(data.Lat, data.Lng)
+
+	if err := cell.Find(entity.GeoApi); err != nil {
+		log.Warnf("media: %s (find place of %s)", err, txt.Quote(m.BaseName()))
+		return nil
+	} else if cell.Place == nil || cell.Place.Unknown() {
+		return nil
+	}
+
+	return cell.Place
+}
+
 // CanonicalNameFromFile returns the canonical name of a file derived from the image name.
 func (m *MediaFile) CanonicalNameFromFile() string {
 	basename := filepath.Base(m.FileName())
@@ -274,6 +332,13 @@ func (m *MediaFile) EditedName() string {
 func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err error) {
 	var prefix string
 
+	// Edited versions are related to their original, if it exists.
+	if original := fs.EditedOriginal(m.fileName); original != "" && fs.FileExists(original) {
+		if f, err := NewMediaFile(original); err == nil {
+			return f.RelatedFiles(stripSequence)
+		}
+	}
+
 	if stripSequence {
 		// Strip common name sequences like "copy 2" and escape meta characters.
 		prefix = regexp.QuoteMeta(m.AbsPrefix(true))
@@ -293,7 +358,22 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		matches = append(matches, name)
 	}
 
+	// Add edited versions and JSON sidecar files from Google Photos.
+	if edited := fs.TakeoutEdited(m.fileName); edited != "" {
+		matches = append(matches, edited)
+	}
+
+	matches = append(matches, fs.TakeoutJson(m.fileName))
+
+	found := make(map[string]bool, len(matches))
+
 	for _, fileName := range matches {
+		if fileName == "" || found[fileName] {
+			continue
+		}
+
+		found[fileName] = true
+
 		f, err := NewMediaFile(fileName)
 
 		if err != nil {
@@ -308,6 +388,8 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 
 		if result.Main == nil && f.IsJpeg() {
 			result.Main = f
+		} else if result.Main != nil && f.IsEdited() {
+			// Keep original as main file.
 		} else if f.IsRaw() {
 			result.Main = f
 		} else if f.IsHEIF() {
@@ -718,6 +800,36 @@ func (m *MediaFile) IsXMP() bool {
 	return m.FileType() == fs.FormatXMP
 }
 
+// IsAAE returns true if this is an Apple .AAE adjustments sidecar file.
+func (m *MediaFile) IsAAE() bool {
+	return m.FileType() == fs.FormatAAE
+}
+
+// IsEdited returns true if this is an edited version of another image, e.g. IMG_E1234.HEIC.
+func (m *MediaFile) IsEdited() bool {
+	return fs.IsEdited(m.FileName())
+}
+
+// IsMotionPhoto returns true if this is a Samsung or Google motion photo with an embedded video.
+func (m *MediaFile) IsMotionPhoto() bool {
+	if !m.IsJpeg() {
+		return false
+	}
+
+	_, err := meta.MotionPhoto(m.FileName())
+
+	return err == nil
+}
+
+// IsMotionVideo returns true if this is a video extracted from a motion photo, e.g. PXL_20210101_120000.MP.jpg.mp4.
+func (m *MediaFile) IsMotionVideo() bool {
+	if !m.IsVideo() || m.Root() != entity.RootSidecar {
+		return false
+	}
+
+	return fs.FileExt[strings.ToLower(filepath.Ext(fs.StripExt(m.FileName())))] == fs.FormatJpeg
+}
+
 // IsSidecar returns true if this is a sidecar file (containing metadata).
 func (m *MediaFile) IsSidecar() bool {
 	return m.MediaType() == fs.MediaSidecar
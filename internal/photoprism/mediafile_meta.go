@@ -28,7 +28,23 @@ func (m *MediaFile) SidecarJsonName() string {
 		return jsonName
 	}
 
-	return ""
+	return fs.TakeoutJson(m.fileName)
+}
+
+// TakeoutJsonNames returns the Google Photos Takeout JSON sidecar file names that don't match the media file name,
+// e.g. truncated names or the sidecar of the original if this is an edited image.
+func (m *MediaFile) TakeoutJsonNames() (result []string) {
+	if jsonName := fs.TakeoutJson(m.fileName); jsonName != "" {
+		result = append(result, jsonName)
+	}
+
+	if original := fs.TakeoutOriginal(m.fileName); original == "" {
+		// Not an edited image.
+	} else if jsonName := fs.TakeoutJson(original); jsonName != "" {
+		result = append(result, jsonName)
+	}
+
+	return result
 }
 
 // ExifToolJsonName returns the cached ExifTool metadata file name.
@@ -77,9 +93,33 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 			err = fmt.Errorf("exif not supported: %s", txt.Quote(m.BaseName()))
 		}
 
+		// Parse IPTC IIM metadata embedded in JPEG files, e.g. by Photoshop or Photo Mechanic.
+		if m.IsJpeg() {
+			if iptcErr := m.metaData.IPTC(m.FileName(), m.FileType()); iptcErr != nil {
+				log.Debug(iptcErr)
+			} else {
+				log.Debugf("media: found iptc metadata in %s", txt.Quote(m.BaseName()))
+			}
+		}
+
 		// Parse regular JSON sidecar files ("img_1234.json")
 		if !m.IsSidecar() {
-			if jsonFiles := fs.FormatJson.FindAll(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false); len(jsonFiles) == 0 {
+			jsonFiles := fs.FormatJson.FindAll(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), false)
+
+			// Add Google Photos sidecar files with non-matching names.
+			found := make(map[string]bool, len(jsonFiles))
+
+			for _, jsonName := range jsonFiles {
+				found[jsonName] = true
+			}
+
+			for _, jsonName := range m.TakeoutJsonNames() {
+				if !found[jsonName] {
+					jsonFiles = append(jsonFiles, jsonName)
+				}
+			}
+
+			if len(jsonFiles) == 0 {
 				log.Debugf("media: no original json sidecar file found for %s", txt.Quote(filepath.Base(m.FileName())))
 			} else {
 				for _, jsonFile := range jsonFiles {
@@ -100,6 +140,15 @@ func (m *MediaFile) MetaData() (result meta.Data) {
 			}
 		}
 
+		// Complete video metadata with values from MP4 and QuickTime atoms, so that it's available without ExifTool.
+		if m.IsVideo() {
+			if videoErr := m.metaData.Video(m.FileName()); videoErr != nil {
+				log.Debug(videoErr)
+			} else {
+				err = nil
+			}
+		}
+
 		if err != nil {
 			m.metaData.Error = err
 			log.Debugf("media: %s", err.Error())
@@ -554,13 +554,14 @@ func TestMediaFile_RelatedFiles(t *testing.T) {
 			t.Fatal("main file must not be nil")
 		}
 
-		if len(related.Files) != 1 {
-			t.Fatalf("length is %d, should be 1", len(related.Files))
+		if len(related.Files) != 2 {
+			t.Fatalf("length is %d, should be 2", len(related.Files))
 		}
 
 		assert.Equal(t, "2015-02-04(1).jpg", related.Main.BaseName())
 
 		assert.Equal(t, "2015-02-04(1).jpg", related.Files[0].BaseName())
+		assert.Equal(t, "2015-02-04.jpg(1).json", related.Files[1].BaseName())
 	})
 
 	t.Run("2015-02-04(1).jpg stacked", func(t *testing.T) {
@@ -619,6 +620,50 @@ func TestMediaFile_RelatedFiles_Ordering(t *testing.T) {
 	}
 }
 
+func TestMediaFile_RelatedFiles_Edited(t *testing.T) {
+	conf := config.TestConfig()
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_E4120.JPG")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, mediaFile.IsEdited())
+
+	related, err := mediaFile.RelatedFiles(false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, related.Files, 3)
+	assert.Equal(t, conf.ExamplesPath()+"/IMG_4120.JPG", related.Main.FileName())
+	assert.True(t, related.Files[0].IsAAE())
+}
+
+func TestMediaFile_IsMotionPhoto(t *testing.T) {
+	t.Run("motion-photo.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile("testdata/motion-photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, mediaFile.IsMotionPhoto())
+		assert.False(t, mediaFile.IsMotionVideo())
+	})
+	t.Run("digikam.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile("testdata/digikam.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, mediaFile.IsMotionPhoto())
+	})
+}
+
 func TestMediaFile_SetFilename(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -0,0 +1,24 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Phash returns the perceptual hash of an image (only JPEG supported).
+func (m *MediaFile) Phash(thumbPath string) (hash phash.Hash, err error) {
+	if !m.IsJpeg() {
+		return hash, fmt.Errorf("%s is not a jpeg", txt.Quote(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, txt.Quote(m.BaseName()))
+		return hash, err
+	}
+
+	return phash.DHash(img), nil
+}
@@ -0,0 +1,44 @@
+package photoprism
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_Phash(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := os.TempDir() + "/TestMediaFile_Phash"
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := mediaFile.Phash(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, uint64(0), uint64(hash))
+		assert.Len(t, hash.Hex(), 16)
+	})
+	t.Run("not a jpeg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.Phash(thumbsPath)
+
+		assert.Error(t, err)
+	})
+}
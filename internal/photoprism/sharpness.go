@@ -0,0 +1,24 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Sharpness returns the sharpness score of an image, which is used to choose the best frame of a burst (only JPEG supported).
+func (m *MediaFile) Sharpness(thumbPath string) (score uint32, err error) {
+	if !m.IsJpeg() {
+		return score, fmt.Errorf("%s is not a jpeg", txt.Quote(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, "fit_720")
+
+	if err != nil {
+		log.Debugf("sharpness: %s in %s (resample)", err, txt.Quote(m.BaseName()))
+		return score, err
+	}
+
+	return colors.Sharpness(img), nil
+}
@@ -0,0 +1,43 @@
+package photoprism
+
+import (
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_Sharpness(t *testing.T) {
+	conf := config.TestConfig()
+
+	thumbsPath := os.TempDir() + "/TestMediaFile_Sharpness"
+	defer os.RemoveAll(thumbsPath)
+
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		score, err := mediaFile.Sharpness(thumbsPath)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, score, uint32(0))
+	})
+	t.Run("not a jpeg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/Random.docx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.Sharpness(thumbsPath)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package photoprism
+
+import (
+	"image"
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// ImageSearchDistance is the default max Hamming distance when searching by image, it is
+// higher than query.SimilarDistance to find originals of slightly cropped images.
+const ImageSearchDistance = query.MaxSimilarDistance
+
+// ImageSearchColorDistance is the max palette distance of photos with similar colors when searching by image,
+// it is higher than query.PaletteDistance as the colors of cropped images differ more.
+const ImageSearchColorDistance = 60
+
+// ImageSignature contains the perceptual hash and color signature of an image.
+type ImageSignature struct {
+	Hash      phash.Hash
+	Colors    colors.Colors
+	Luminance colors.LightMap
+}
+
+// NewImageSignature returns the signature of an image in memory, using the same
+// thumbnail sizes as the indexer so that it can be compared with indexed files.
+func NewImageSignature(img image.Image) ImageSignature {
+	fit := thumb.Types["fit_720"]
+	small := thumb.Resample(img, fit.Width, fit.Height, fit.Options...)
+
+	c := thumb.Types["colors"]
+	perception := colors.Perception(thumb.Resample(small, c.Width, c.Height, c.Options...))
+
+	return ImageSignature{
+		Hash:      phash.DHash(small),
+		Colors:    perception.Colors,
+		Luminance: perception.Luminance,
+	}
+}
+
+// Distance returns the weighted sum of the perceptual hash distance and the color signature
+// difference between this signature and a search result, lower values mean more similar.
+func (s ImageSignature) Distance(r query.PhotoResult) int {
+	dist := phash.Bits * 2
+
+	if h, err := phash.Parse(r.FilePhash); err == nil {
+		dist = s.Hash.Distance(h) * 2
+	}
+
+	dist += s.Colors.Distance(colors.HexColors(r.FileColors))
+
+	// Average luminance difference per pixel.
+	if n := len(s.Luminance); n > 0 {
+		dist += s.Luminance.Distance(colors.HexLightMap(r.FileLuminance)) / n
+	}
+
+	return dist
+}
+
+// ImageMatch represents a search result and its distance to an image signature.
+type ImageMatch struct {
+	query.PhotoResult
+	Distance int `json:"Distance"`
+}
+
+// ImageMatches represents a list of search results sorted by distance.
+type ImageMatches []ImageMatch
+
+// Palette returns the colors of this signature as palette to search for photos with similar colors.
+func (s ImageSignature) Palette() colors.Palette {
+	return colors.Cells(s.Colors, s.Luminance)
+}
+
+// Matches returns up to limit search results sorted by distance to this signature, photos that
+// are found more than once are ranked only once.
+func (s ImageSignature) Matches(results query.PhotoResults, limit int) ImageMatches {
+	matches := make(ImageMatches, 0, len(results))
+	found := make(map[string]bool, len(results))
+
+	for _, r := range results {
+		if found[r.PhotoUID] {
+			continue
+		}
+
+		found[r.PhotoUID] = true
+		matches = append(matches, ImageMatch{PhotoResult: r, Distance: s.Distance(r)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	if limit > 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
@@ -0,0 +1,78 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/colors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImageSignature(t *testing.T) {
+	conf := config.TestConfig()
+
+	img, err := imaging.Open(conf.ExamplesPath()+"/cat_brown.jpg", imaging.AutoOrientation(true))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := NewImageSignature(img)
+
+	t.Run("signature", func(t *testing.T) {
+		assert.NotEqual(t, uint64(0), uint64(sig.Hash))
+		assert.Len(t, sig.Colors, 9)
+		assert.Len(t, sig.Luminance, 9)
+	})
+	t.Run("recompressed", func(t *testing.T) {
+		small := NewImageSignature(imaging.Resize(img, 400, 0, imaging.Linear))
+		assert.LessOrEqual(t, sig.Hash.Distance(small.Hash), 4)
+	})
+	t.Run("cropped", func(t *testing.T) {
+		b := img.Bounds()
+		cropped := NewImageSignature(imaging.CropCenter(img, b.Dx()*95/100, b.Dy()*95/100))
+		assert.LessOrEqual(t, sig.Hash.Distance(cropped.Hash), ImageSearchDistance)
+	})
+	t.Run("distance", func(t *testing.T) {
+		same := query.PhotoResult{FilePhash: sig.Hash.Hex(), FileColors: sig.Colors.Hex(), FileLuminance: sig.Luminance.Hex()}
+		other := query.PhotoResult{FilePhash: (^sig.Hash).Hex(), FileColors: "000000000", FileLuminance: "000000000"}
+
+		assert.Equal(t, 0, sig.Distance(same))
+		assert.Less(t, sig.Distance(same), sig.Distance(other))
+	})
+}
+
+func TestImageSignature_Matches(t *testing.T) {
+	sig := ImageSignature{Hash: 0xff, Colors: colors.HexColors("000000000"), Luminance: colors.HexLightMap("000000000")}
+
+	results := query.PhotoResults{
+		{PhotoUID: "a", FilePhash: "0000000000000000", FileColors: "000000000", FileLuminance: "000000000"},
+		{PhotoUID: "b", FilePhash: "00000000000000ff", FileColors: "000000000", FileLuminance: "000000000"},
+		{PhotoUID: "c", FilePhash: "00000000000000ff", FileColors: "111111111", FileLuminance: "000000000"},
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		matches := sig.Matches(results, 0)
+
+		if assert.Len(t, matches, 3) {
+			assert.Equal(t, "b", matches[0].PhotoUID)
+			assert.Equal(t, 0, matches[0].Distance)
+			assert.Equal(t, "c", matches[1].PhotoUID)
+			assert.Equal(t, 9, matches[1].Distance)
+			assert.Equal(t, "a", matches[2].PhotoUID)
+		}
+	})
+	t.Run("limit", func(t *testing.T) {
+		assert.Len(t, sig.Matches(results, 1), 1)
+	})
+	t.Run("duplicates", func(t *testing.T) {
+		assert.Len(t, sig.Matches(append(results, results...), 0), 3)
+	})
+}
+
+func TestImageSignature_Palette(t *testing.T) {
+	sig := ImageSignature{Colors: colors.HexColors("000000000"), Luminance: colors.HexLightMap("000000000")}
+	assert.Len(t, sig.Palette(), 9)
+}
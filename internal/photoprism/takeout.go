@@ -0,0 +1,125 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// takeoutYearFolder matches the names of folders that Google Photos creates for each year, e.g. "Photos from 2019".
+var takeoutYearFolder = regexp.MustCompile(`^Photos from \d{4}$`)
+
+// TakeoutAlbum returns the album for a folder exported by Google Photos Takeout, and creates it if needed.
+// Returns nil if the folder contains no album metadata.
+func TakeoutAlbum(dir string) *entity.Album {
+	fileName := filepath.Join(dir, fs.TakeoutAlbumFile)
+
+	if !fs.FileExists(fileName) {
+		return nil
+	}
+
+	data, err := meta.GAlbumFile(fileName)
+
+	if err != nil {
+		log.Debugf("import: %s", err)
+		return nil
+	} else if takeoutYearFolder.MatchString(data.Title) {
+		return nil
+	}
+
+	album := entity.NewAlbum(data.Title, entity.AlbumDefault)
+
+	if err := album.Find(); err == nil {
+		return album
+	}
+
+	album.AlbumDescription = data.Description
+	album.AlbumLocation = data.Location
+	album.AlbumOrder = entity.SortOrderAlbum
+
+	if data.Date.Exists() {
+		date := data.Date.Time()
+		album.AlbumYear = date.Year()
+		album.AlbumMonth = int(date.Month())
+		album.AlbumDay = date.Day()
+	}
+
+	if err := album.Create(); err != nil {
+		log.Errorf("import: %s (create album %s)", err, txt.Quote(data.Title))
+		return nil
+	}
+
+	log.Infof("import: created album %s", txt.Quote(album.AlbumTitle))
+
+	return album
+}
+
+// TakeoutAlbumOrder returns the album positions of media files in a Google Photos Takeout folder,
+// sorted by the time taken from their JSON sidecar files, since Takeout doesn't export the album order.
+// Files without taken time are added at the end in alphabetical order.
+func TakeoutAlbumOrder(dir string) map[string]int {
+	result := make(map[string]int)
+
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		log.Debugf("import: %s", err)
+		return result
+	}
+
+	type takeoutFile struct {
+		name    string
+		takenAt time.Time
+	}
+
+	var media []takeoutFile
+
+	for _, f := range files {
+		fileName := filepath.Join(dir, f.Name())
+
+		if f.IsDir() || !fs.IsMedia(fileName) {
+			continue
+		}
+
+		m := takeoutFile{name: fileName}
+		jsonName := fs.TakeoutJson(fileName)
+
+		// Edited images have no JSON file and are sorted next to their original.
+		if jsonName == "" {
+			jsonName = fs.TakeoutJson(fs.TakeoutOriginal(fileName))
+		}
+
+		if jsonName == "" {
+			// Do nothing.
+		} else if data, err := meta.GPhotoFile(jsonName); err != nil {
+			log.Debugf("import: %s in %s", err, txt.Quote(filepath.Base(jsonName)))
+		} else if data.TakenAt.Exists() {
+			m.takenAt = data.TakenAt.Time()
+		}
+
+		media = append(media, m)
+	}
+
+	sort.SliceStable(media, func(i, j int) bool {
+		if media[i].takenAt.IsZero() != media[j].takenAt.IsZero() {
+			return media[j].takenAt.IsZero()
+		} else if !media[i].takenAt.Equal(media[j].takenAt) {
+			return media[i].takenAt.Before(media[j].takenAt)
+		}
+
+		return media[i].name < media[j].name
+	})
+
+	for i, m := range media {
+		result[m.name] = i + 1
+	}
+
+	return result
+}
@@ -0,0 +1,161 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeoutAlbum(t *testing.T) {
+	config.TestConfig()
+
+	dir, err := ioutil.TempDir("", "takeout")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	t.Run("no metadata", func(t *testing.T) {
+		assert.Nil(t, TakeoutAlbum(dir))
+	})
+
+	t.Run("album", func(t *testing.T) {
+		if err := fs.Copy("../meta/testdata/gphotos-album-2.json", filepath.Join(dir, fs.TakeoutAlbumFile)); err != nil {
+			t.Fatal(err)
+		}
+
+		album := TakeoutAlbum(dir)
+
+		if album == nil {
+			t.Fatal("album must not be nil")
+		}
+
+		assert.Equal(t, "Summer Trip", album.AlbumTitle)
+		assert.Equal(t, "Sailing on the Baltic Sea", album.AlbumDescription)
+		assert.Equal(t, entity.SortOrderAlbum, album.AlbumOrder)
+		assert.Equal(t, 2019, album.AlbumYear)
+
+		if existing := TakeoutAlbum(dir); existing == nil {
+			t.Fatal("album must not be nil")
+		} else {
+			assert.Equal(t, album.AlbumUID, existing.AlbumUID)
+		}
+	})
+
+	t.Run("year folder", func(t *testing.T) {
+		data := []byte(`{"title": "Photos from 2019", "description": "", "access": "", "date": {"timestamp": "0"}}`)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, fs.TakeoutAlbumFile), data, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, TakeoutAlbum(dir))
+	})
+}
+
+func TestTakeoutAlbumOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "takeout")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for name, data := range map[string]string{
+		"IMG_0001.jpg":        "",
+		"IMG_0001.jpg.json":   `{"title": "IMG_0001.jpg", "photoTakenTime": {"timestamp": "1562410287"}}`,
+		"IMG_0002.jpg":        "",
+		"IMG_0002.jpg.json":   `{"title": "IMG_0002.jpg", "photoTakenTime": {"timestamp": "1530874287"}}`,
+		"IMG_0002-edited.jpg": "",
+		"IMG_0003.jpg":        "",
+		fs.TakeoutAlbumFile:   `{"title": "Summer Trip"}`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result := TakeoutAlbumOrder(dir)
+
+	assert.Len(t, result, 4)
+	assert.Equal(t, 1, result[filepath.Join(dir, "IMG_0002-edited.jpg")])
+	assert.Equal(t, 2, result[filepath.Join(dir, "IMG_0002.jpg")])
+	assert.Equal(t, 3, result[filepath.Join(dir, "IMG_0001.jpg")])
+	assert.Equal(t, 4, result[filepath.Join(dir, "IMG_0003.jpg")])
+	assert.Empty(t, TakeoutAlbumOrder(filepath.Join(dir, "missing")))
+}
+
+func TestMediaFile_RelatedFiles_Takeout(t *testing.T) {
+	conf := config.TestConfig()
+
+	dir, err := ioutil.TempDir("", "takeout")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for src, dest := range map[string]string{
+		filepath.Join(conf.ExamplesPath(), "beach_sand.jpg"): "IMG_1234.jpg",
+		filepath.Join(conf.ExamplesPath(), "beach_wood.jpg"): "IMG_1234-edited.jpg",
+		"../meta/testdata/gphotos-5.json":                    "IMG_1234.jpg.json",
+	} {
+		if err := fs.Copy(src, filepath.Join(dir, dest)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mediaFile, err := NewMediaFile(filepath.Join(dir, "IMG_1234-edited.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("edited", func(t *testing.T) {
+		related, err := mediaFile.RelatedFiles(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, related.Files, 3)
+		assert.Equal(t, filepath.Join(dir, "IMG_1234.jpg"), related.Main.FileName())
+	})
+
+	t.Run("original", func(t *testing.T) {
+		original, err := NewMediaFile(filepath.Join(dir, "IMG_1234.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := original.RelatedFiles(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, related.Files, 3)
+		assert.Equal(t, original.FileName(), related.Main.FileName())
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(dir, "IMG_1234.jpg.json")}, mediaFile.TakeoutJsonNames())
+
+		data := mediaFile.MetaData()
+
+		assert.True(t, data.Favorite)
+		assert.True(t, data.Archived)
+		assert.Equal(t, "Jane Doe, John Doe", data.Subject)
+	})
+}
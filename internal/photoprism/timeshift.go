@@ -0,0 +1,175 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TimeShift represents a worker that shifts the time of multiple photos, e.g. to correct camera clock errors.
+type TimeShift struct {
+	conf *config.Config
+}
+
+// TimeShiftResult represents a photo time change.
+type TimeShiftResult struct {
+	PhotoUID        string    `json:"UID"`
+	PhotoTitle      string    `json:"Title"`
+	TakenAt         time.Time `json:"TakenAt"`
+	TakenAtLocal    time.Time `json:"TakenAtLocal"`
+	NewTakenAt      time.Time `json:"NewTakenAt"`
+	NewTakenAtLocal time.Time `json:"NewTakenAtLocal"`
+	ChangeUID       string    `json:"ChangeUID,omitempty"`
+}
+
+// TimeShiftResults represents a list of photo time changes.
+type TimeShiftResults []TimeShiftResult
+
+// NewTimeShift returns a new time shift worker.
+func NewTimeShift(conf *config.Config) *TimeShift {
+	instance := &TimeShift{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Offset returns the time offset to apply, either fixed or based on the correct local time of a reference photo.
+func (w *TimeShift) Offset(f form.TimeShift) (time.Duration, error) {
+	if !f.Relative() {
+		return time.Duration(f.Offset) * time.Second, nil
+	}
+
+	ref, err := query.PhotoByUID(f.RefPhoto)
+
+	if err != nil {
+		return 0, fmt.Errorf("timeshift: reference photo %s not found", txt.Quote(f.RefPhoto))
+	}
+
+	if ref.TakenAtLocal.IsZero() {
+		ref.TakenAtLocal = ref.TakenAt
+	}
+
+	// The reference time is a local time like TakenAtLocal, so the time zone is ignored.
+	refTime := time.Date(f.RefTime.Year(), f.RefTime.Month(), f.RefTime.Day(),
+		f.RefTime.Hour(), f.RefTime.Minute(), f.RefTime.Second(), 0, time.UTC)
+
+	return refTime.Sub(ref.TakenAtLocal.Round(time.Second)), nil
+}
+
+// Start shifts the time of all matching photos by the same offset and updates moments afterwards.
+// The changes are recorded in the edit history on behalf of the given user, so they can be undone.
+func (w *TimeShift) Start(f form.TimeShift, userUID string) (results TimeShiftResults, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("timeshift: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	offset, err := w.Offset(f)
+
+	if err != nil {
+		return results, err
+	} else if offset == 0 {
+		return results, errors.New("timeshift: offset must not be zero")
+	}
+
+	photos, err := query.TimeShiftPhotos(f)
+
+	if err != nil {
+		return results, err
+	}
+
+	change := entity.NewChange(userUID, entity.ChangeBatch)
+
+	if results, err = w.shift(photos, offset, f.DryRun, change); err != nil || f.DryRun || len(results) == 0 {
+		return results, err
+	}
+
+	if err := entity.UpdatePhotoCounts(); err != nil {
+		log.Errorf("timeshift: %s", err)
+	}
+
+	// Update moments, e.g. to add albums for new months.
+	if err := NewMoments(w.conf).Start(); err != nil {
+		log.Warnf("moments: %s", err)
+	}
+
+	return results, nil
+}
+
+// shift changes the time of the given photos and saves them together with the change unless it's a dry run.
+func (w *TimeShift) shift(photos entity.Photos, offset time.Duration, dryRun bool, change *entity.Change) (results TimeShiftResults, err error) {
+	if !dryRun {
+		if err := mutex.MainWorker.Start(); err != nil {
+			return results, err
+		}
+
+		defer mutex.MainWorker.Stop()
+	}
+
+	log.Infof("timeshift: shifting %d photos by %s", len(photos), offset)
+
+	shifted := make(entity.Photos, 0, len(photos))
+
+	for _, p := range photos {
+		r := TimeShiftResult{
+			PhotoUID:     p.PhotoUID,
+			PhotoTitle:   p.PhotoTitle,
+			TakenAt:      p.TakenAt,
+			TakenAtLocal: p.TakenAtLocal,
+		}
+
+		p.GetDetails()
+		old := p.Clone()
+
+		p.ShiftTime(offset)
+
+		r.NewTakenAt = p.TakenAt
+		r.NewTakenAtLocal = p.TakenAtLocal
+		results = append(results, r)
+
+		if !dryRun {
+			change.Photo(&old, &p)
+			shifted = append(shifted, p)
+		}
+	}
+
+	if dryRun {
+		return results, nil
+	}
+
+	// Save all photos and the change in a single transaction, so that the shift can be undone.
+	if failed, err := entity.SaveTimeShift(shifted, change); failed >= 0 {
+		return TimeShiftResults{}, fmt.Errorf("timeshift: %s (update %s)", err, shifted[failed].String())
+	} else if err != nil {
+		return TimeShiftResults{}, fmt.Errorf("timeshift: %s (save history)", err)
+	}
+
+	for i := range results {
+		results[i].ChangeUID = change.ChangeUID
+	}
+
+	if w.conf.BackupYaml() {
+		for _, p := range shifted {
+			yamlFile := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+			if err := p.LoadLabels(); err != nil {
+				log.Errorf("timeshift: %s (load labels)", err)
+			} else if err := p.SaveAsYaml(yamlFile); err != nil {
+				log.Errorf("timeshift: %s (update yaml)", err)
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Offset(t *testing.T) {
+	w := NewTimeShift(config.TestConfig())
+
+	t.Run("fixed", func(t *testing.T) {
+		offset, err := w.Offset(form.TimeShift{Offset: -90})
+
+		assert.NoError(t, err)
+		assert.Equal(t, -90*time.Second, offset)
+	})
+
+	t.Run("reference photo", func(t *testing.T) {
+		offset, err := w.Offset(form.TimeShift{RefPhoto: "pt9jtdre2lvl0yh8", RefTime: time.Date(2006, 1, 1, 3, 30, 0, 0, time.UTC)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, offset)
+	})
+
+	t.Run("reference photo not found", func(t *testing.T) {
+		_, err := w.Offset(form.TimeShift{RefPhoto: "pt9jtdre2lvl0xxx", RefTime: time.Date(2006, 1, 1, 3, 30, 0, 0, time.UTC)})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTimeShift_Start(t *testing.T) {
+	w := NewTimeShift(config.TestConfig())
+
+	t.Run("dry run", func(t *testing.T) {
+		results, err := w.Start(form.TimeShift{Selection: form.Selection{Photos: []string{"pt9jtdre2lvl0yh8"}}, Offset: 3600, DryRun: true}, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), results[0].TakenAtLocal)
+		assert.Equal(t, time.Date(2006, 1, 1, 3, 0, 0, 0, time.UTC), results[0].NewTakenAtLocal)
+
+		p := entity.Photo{PhotoUID: "pt9jtdre2lvl0yh8"}
+
+		if err := p.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2006, 1, 1, 2, 0, 0, 0, time.UTC), p.TakenAtLocal)
+	})
+
+	t.Run("shift", func(t *testing.T) {
+		f := form.TimeShift{Selection: form.Selection{Photos: []string{"pt9jtdre2lvl0y12"}}, Offset: -86400}
+
+		results, err := w.Start(f, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+
+		p := entity.Photo{PhotoUID: "pt9jtdre2lvl0y12"}
+
+		if err := p.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, time.Date(2015, 11, 10, 9, 7, 18, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, 10, p.PhotoDay)
+		assert.Equal(t, entity.SrcManual, p.TakenSrc)
+
+		// Shift back.
+		f.Offset = 86400
+
+		if _, err := w.Start(f, ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("zero offset", func(t *testing.T) {
+		_, err := w.Start(form.TimeShift{Selection: form.Selection{Photos: []string{"pt9jtdre2lvl0yh8"}}, DryRun: true}, "")
+
+		assert.Error(t, err)
+	})
+}
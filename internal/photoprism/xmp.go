@@ -0,0 +1,42 @@
+package photoprism
+
+import (
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// XmpFileName returns the XMP sidecar file name of a photo, indexed sidecar files like those from
+// Lightroom or darktable are preferred. New files are created in the sidecar path if originals are read-only.
+func XmpFileName(c *config.Config, p entity.Photo) string {
+	if len(p.Files) == 0 {
+		p.PreloadFiles()
+	}
+
+	for _, f := range p.Files {
+		if f.FileType != string(fs.FormatXMP) || f.FileMissing || f.FileRoot != entity.RootSidecar && c.ReadOnly() {
+			continue
+		}
+
+		return FileName(f.FileRoot, f.FileName)
+	}
+
+	sidecarPath := ""
+
+	if c.ReadOnly() {
+		sidecarPath = c.SidecarPath()
+	}
+
+	return p.XmpFileName(c.OriginalsPath(), sidecarPath)
+}
+
+// SaveXmp writes metadata changes to an XMP sidecar file if enabled and returns the file name.
+func SaveXmp(c *config.Config, p entity.Photo) (fileName string, err error) {
+	if !c.WriteXmp() {
+		return "", nil
+	}
+
+	fileName = XmpFileName(c, p)
+
+	return fileName, p.SaveAsXmp(fileName)
+}
@@ -92,6 +92,15 @@ func FileByHash(fileHash string) (file entity.File, err error) {
 	return file, nil
 }
 
+// FileByName finds an indexed file by root and relative file name.
+func FileByName(fileName, fileRoot string) (file entity.File, err error) {
+	if err := Db().Where("file_name = ? AND file_root = ?", fileName, fileRoot).Preload("Photo").First(&file).Error; err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
 // RenameFile renames an indexed file.
 func RenameFile(srcRoot, srcName, destRoot, destName string) error {
 	if srcRoot == "" || srcName == "" || destRoot == "" || destName == "" {
@@ -164,6 +164,24 @@ func TestFileByHash(t *testing.T) {
 	})
 }
 
+func TestFileByName(t *testing.T) {
+	t.Run("file found", func(t *testing.T) {
+		file, err := FileByName("exampleFileName.jpg", entity.RootOriginals)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818", file.FileHash)
+	})
+
+	t.Run("wrong root", func(t *testing.T) {
+		_, err := FileByName("exampleFileName.jpg", entity.RootSidecar)
+
+		assert.Error(t, err)
+	})
+}
+
 func TestSetPhotoPrimary(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		assert.Equal(t, false, entity.FileFixturesExampleXMP.FilePrimary)
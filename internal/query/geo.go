@@ -7,7 +7,6 @@ import (
 
 	"github.com/photoprism/photoprism/pkg/fs"
 
-	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/capture"
@@ -44,18 +43,18 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 	if f.Query != "" {
 		// Filter by label, label category and keywords.
 		var categories []entity.Category
-		var labels []entity.Label
+		var labels entity.Labels
 		var labelIds []uint
 
 		if len(f.Query) < 2 {
 			return results, fmt.Errorf("query too short")
 		}
 
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labels, err = MatchLabels(f.Query, " "); len(labels) == 0 || err != nil {
 			log.Infof("search: label %s not found, using fuzzy search", txt.Quote(f.Query))
 
-			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
-				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
+			if keywords := KeywordExpr("k.keyword", f.Query); keywords != nil {
+				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", keywords)
 			}
 		} else {
 			for _, l := range labels {
@@ -70,9 +69,9 @@ func Geo(f form.GeoSearch) (results GeoResults, err error) {
 				}
 			}
 
-			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
+			if keywords := KeywordExpr("k.keyword", f.Query); keywords != nil {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
-					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", gorm.Expr(likeAny), labelIds)
+					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", keywords, labelIds)
 			} else {
 				s = s.Where("photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
 			}
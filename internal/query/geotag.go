@@ -0,0 +1,20 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// GeotagPhotos returns photos taken in the given time range that have no coordinates yet,
+// or coordinates from sources that may be replaced by GPS tracks.
+func GeotagPhotos(from, to time.Time) (result entity.Photos, err error) {
+	err = Db().
+		Where("taken_src <> '' AND taken_at BETWEEN ? AND ?", from, to).
+		Where("(photo_lat = 0 AND photo_lng = 0 AND place_src <> ?) OR place_src IN (?)",
+			entity.SrcManual, []string{entity.SrcAuto, entity.SrcEstimate, entity.SrcTrack}).
+		Order("taken_at").
+		Find(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotagPhotos(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		results, err := GeotagPhotos(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, p := range results {
+			assert.NotEqual(t, entity.SrcAuto, p.TakenSrc)
+			assert.NotEqual(t, entity.SrcManual, p.PlaceSrc)
+			assert.NotEqual(t, entity.SrcMeta, p.PlaceSrc)
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		results, err := GeotagPhotos(time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1800, 1, 2, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// charLength returns the sql function that counts characters instead of bytes.
+func charLength() string {
+	switch DbDialect() {
+	case MySQL:
+		return "CHAR_LENGTH"
+	default:
+		return "LENGTH"
+	}
+}
+
+// SimilarKeywords returns keywords that differ from a word in search by no more than the
+// tolerated number of typos. Only keywords with the same first letter are compared.
+func SimilarKeywords(search string) (result []string) {
+	for _, w := range txt.UniqueKeywords(search) {
+		max := txt.MaxTypos(w)
+
+		if max == 0 {
+			continue
+		}
+
+		runes := []rune(w)
+
+		var candidates []string
+
+		if err := Db().Model(&entity.Keyword{}).
+			Where(fmt.Sprintf("keyword LIKE ? AND %s(keyword) BETWEEN ? AND ?", charLength()), string(runes[0])+"%", len(runes)-max, len(runes)+max).
+			Pluck("keyword", &candidates).Error; err != nil {
+			log.Errorf("search: %s (similar keywords)", err)
+			continue
+		}
+
+		for _, c := range candidates {
+			if c != w && txt.Similar(w, c) {
+				result = append(result, c)
+			}
+		}
+	}
+
+	return result
+}
+
+// KeywordExpr returns an sql expression that matches keywords starting with or similar to
+// any word in search, or nil if there are no words to search for.
+func KeywordExpr(col, search string) *gorm.SqlExpr {
+	likeAny := LikeAny(col, search)
+
+	if likeAny == "" {
+		return nil
+	}
+
+	similar := SimilarKeywords(search)
+
+	if len(similar) == 0 {
+		return gorm.Expr(likeAny)
+	}
+
+	args := make([]interface{}, len(similar))
+
+	for i, k := range similar {
+		args[i] = k
+	}
+
+	return gorm.Expr(fmt.Sprintf("%s OR %s IN (?%s)", likeAny, col, strings.Repeat(",?", len(similar)-1)), args...)
+}
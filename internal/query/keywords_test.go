@@ -0,0 +1,34 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarKeywords(t *testing.T) {
+	t.Run("typo", func(t *testing.T) {
+		assert.Equal(t, []string{"bridge"}, SimilarKeywords("brigde"))
+	})
+	t.Run("exact", func(t *testing.T) {
+		assert.Empty(t, SimilarKeywords("bridge"))
+	})
+	t.Run("short", func(t *testing.T) {
+		assert.Empty(t, SimilarKeywords("bea"))
+	})
+}
+
+func TestKeywordExpr(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, KeywordExpr("k.keyword", ""))
+	})
+	t.Run("typo", func(t *testing.T) {
+		var ids []uint
+
+		if err := Db().Table("keywords k").Where("?", KeywordExpr("k.keyword", "brigde")).Pluck("k.id", &ids).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []uint{1000000}, ids)
+	})
+}
@@ -68,5 +68,18 @@ func LabelThumbByUID(labelUID string) (file entity.File, err error) {
 		Order("photos.photo_quality DESC, photos_labels.uncertainty ASC").
 		First(&file).Error
 
+	if err == nil {
+		return file, nil
+	}
+
+	// If failed, search for descendants in the label hierarchy
+	err = Db().Where("files.file_primary AND files.deleted_at IS NULL").
+		Joins("JOIN photos_labels ON photos_labels.photo_id = files.photo_id AND photos_labels.uncertainty < 100").
+		Joins("JOIN labels_parents lp ON photos_labels.label_id = lp.label_id").
+		Joins("JOIN labels ON lp.parent_id = labels.id AND labels.label_uid = ?", labelUID).
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.deleted_at IS NULL").
+		Order("lp.depth ASC, photos.photo_quality DESC, photos_labels.uncertainty ASC").
+		First(&file).Error
+
 	return file, err
 }
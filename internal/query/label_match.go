@@ -0,0 +1,86 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MatchLabels returns labels whose slug or synonym matches any word in search. If there is no
+// exact match, labels with a similar slug or synonym are returned to tolerate typos.
+func MatchLabels(search, sep string) (labels entity.Labels, err error) {
+	words := SlugWords(search, sep)
+
+	if len(words) == 0 {
+		return labels, nil
+	}
+
+	if err := Db().Where("label_slug IN (?) OR custom_slug IN (?) OR id IN (SELECT label_id FROM labels_synonyms WHERE synonym_slug IN (?))", words, words, words).
+		Find(&labels).Error; err != nil || len(labels) > 0 {
+		return labels, err
+	}
+
+	return SimilarLabels(words)
+}
+
+// SimilarLabels returns labels whose slug or synonym differs from any of the slugs by no
+// more than the tolerated number of typos.
+func SimilarLabels(slugs []string) (labels entity.Labels, err error) {
+	var candidates []struct {
+		ID   uint
+		Slug string
+	}
+
+	// Only compare slugs with a similar length, slugs are ASCII so that the byte length can be used.
+	minLen, maxLen := 0, 0
+
+	for _, s := range slugs {
+		max := txt.MaxTypos(s)
+
+		if max == 0 {
+			continue
+		}
+
+		if minLen == 0 || len(s)-max < minLen {
+			minLen = len(s) - max
+		}
+
+		if len(s)+max > maxLen {
+			maxLen = len(s) + max
+		}
+	}
+
+	if maxLen == 0 {
+		return labels, nil
+	}
+
+	if err := Db().Raw(`SELECT id, slug FROM (SELECT id, label_slug AS slug FROM labels WHERE deleted_at IS NULL 
+		UNION SELECT id, custom_slug AS slug FROM labels WHERE deleted_at IS NULL 
+		UNION SELECT label_id AS id, synonym_slug AS slug FROM labels_synonyms) AS candidates 
+		WHERE LENGTH(slug) BETWEEN ? AND ?`, minLen, maxLen).
+		Scan(&candidates).Error; err != nil {
+		return labels, err
+	}
+
+	var ids []uint
+
+	for _, c := range candidates {
+		for _, s := range slugs {
+			if txt.Similar(s, c.Slug) {
+				ids = append(ids, c.ID)
+				break
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return labels, nil
+	}
+
+	log.Infof("search: found %d labels similar to %s", len(ids), txt.Quote(strings.Join(slugs, " ")))
+
+	err = Db().Where("id IN (?)", ids).Find(&labels).Error
+
+	return labels, err
+}
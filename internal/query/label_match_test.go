@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchLabels(t *testing.T) {
+	t.Run("slug", func(t *testing.T) {
+		labels, err := MatchLabels("landscape", Or)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, labels, 1) {
+			assert.Equal(t, "landscape", labels[0].LabelSlug)
+		}
+	})
+	t.Run("synonym", func(t *testing.T) {
+		labels, err := MatchLabels("Landschaft|Blume", Or)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, labels, 2)
+	})
+	t.Run("typo", func(t *testing.T) {
+		labels, err := MatchLabels("landsacpe", " ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, labels, 1) {
+			assert.Equal(t, "landscape", labels[0].LabelSlug)
+		}
+	})
+	t.Run("synonym typo", func(t *testing.T) {
+		labels, err := MatchLabels("paisage", " ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, labels, 1) {
+			assert.Equal(t, "landscape", labels[0].LabelSlug)
+		}
+	})
+	t.Run("short words must match exactly", func(t *testing.T) {
+		labels, err := MatchLabels("caw", " ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, labels)
+	})
+	t.Run("empty", func(t *testing.T) {
+		labels, err := MatchLabels("", " ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, labels)
+	})
+}
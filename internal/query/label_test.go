@@ -75,6 +75,16 @@ func TestLabelThumbByUID(t *testing.T) {
 		assert.Equal(t, "bridge2.jpg", file.FileName)
 	})
 
+	t.Run("descendant file found", func(t *testing.T) {
+		file, err := LabelThumbByUID("lt9k3pw1wowuy313")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "bridge2.jpg", file.FileName)
+	})
+
 	t.Run("no file found", func(t *testing.T) {
 		file, err := LabelThumbByUID("14")
 
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// LabelNode represents a label and its children in the label hierarchy.
+type LabelNode struct {
+	LabelResult
+	Children []LabelNode `json:"Children"`
+}
+
+// LabelTree returns the label with the given UID and all of its descendants as nested nodes.
+func LabelTree(labelUID string) (result LabelNode, err error) {
+	if err := Db().Table("labels").Where("label_uid = ? AND deleted_at IS NULL", labelUID).Scan(&result.LabelResult).Error; err != nil {
+		return result, err
+	}
+
+	var descendants []LabelResult
+
+	if err := Db().Table("labels").
+		Where("id IN (SELECT label_id FROM labels_parents WHERE parent_id = ?) AND deleted_at IS NULL", result.ID).
+		Order("custom_slug").Scan(&descendants).Error; err != nil {
+		return result, err
+	}
+
+	var relations []entity.LabelParent
+
+	if err := Db().Where("depth = 1 AND label_id IN (SELECT label_id FROM labels_parents WHERE parent_id = ?)", result.ID).
+		Find(&relations).Error; err != nil {
+		return result, err
+	}
+
+	parents := make(map[uint]map[uint]bool, len(descendants))
+
+	for _, r := range relations {
+		if parents[r.LabelID] == nil {
+			parents[r.LabelID] = make(map[uint]bool)
+		}
+
+		parents[r.LabelID][r.ParentID] = true
+	}
+
+	result.Children = labelNodes(result.ID, descendants, parents, map[uint]bool{result.ID: true})
+
+	return result, nil
+}
+
+// labelNodes recursively returns the child nodes of a label, skipping cyclic relations.
+func labelNodes(parentID uint, descendants []LabelResult, parents map[uint]map[uint]bool, visited map[uint]bool) []LabelNode {
+	result := []LabelNode{}
+
+	for _, l := range descendants {
+		if visited[l.ID] || !parents[l.ID][parentID] {
+			continue
+		}
+
+		visited[l.ID] = true
+		result = append(result, LabelNode{LabelResult: l, Children: labelNodes(l.ID, descendants, parents, visited)})
+		visited[l.ID] = false
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelTree(t *testing.T) {
+	t.Run("places", func(t *testing.T) {
+		tree, err := LabelTree(entity.LabelFixtures.Get("places").LabelUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "places", tree.LabelSlug)
+
+		if assert.Len(t, tree.Children, 1) {
+			assert.Equal(t, "europe", tree.Children[0].LabelSlug)
+
+			if assert.Len(t, tree.Children[0].Children, 1) {
+				assert.Equal(t, "berlin", tree.Children[0].Children[0].LabelSlug)
+				assert.Empty(t, tree.Children[0].Children[0].Children)
+			}
+		}
+	})
+	t.Run("leaf", func(t *testing.T) {
+		tree, err := LabelTree(entity.LabelFixtures.Get("berlin").LabelUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "berlin", tree.LabelSlug)
+		assert.Empty(t, tree.Children)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := LabelTree("lt9k3pw1wowuy999")
+
+		assert.Error(t, err)
+	})
+}
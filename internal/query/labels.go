@@ -58,6 +58,7 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 	if f.Query != "" {
 		var labelIds []uint
 		var categories []entity.Category
+		var descendants []entity.LabelParent
 		var label entity.Label
 
 		slugString := slug.Make(f.Query)
@@ -76,6 +77,12 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 				labelIds = append(labelIds, category.LabelID)
 			}
 
+			Db().Where("parent_id = ?", label.ID).Find(&descendants)
+
+			for _, descendant := range descendants {
+				labelIds = append(labelIds, descendant.LabelID)
+			}
+
 			log.Infof("search: label %s includes %d categories", txt.Quote(label.LabelName), len(labelIds))
 
 			s = s.Where("labels.id IN (?)", labelIds)
@@ -86,6 +93,15 @@ func Labels(f form.LabelSearch) (results []LabelResult, err error) {
 		s = s.Where("labels.label_favorite = 1")
 	}
 
+	if f.Parent != "" {
+		s = s.Where("labels.id IN (SELECT lp.label_id FROM labels_parents lp JOIN labels p ON p.id = lp.parent_id "+
+			"WHERE lp.depth = 1 AND p.label_uid IN (?))", strings.Split(f.Parent, Or))
+	}
+
+	if f.Root {
+		s = s.Where("labels.id IN (SELECT parent_id FROM labels_parents) AND labels.id NOT IN (SELECT label_id FROM labels_parents)")
+	}
+
 	if !f.All {
 		s = s.Where("labels.label_priority >= 0 OR labels.label_favorite = 1")
 	}
@@ -62,6 +62,44 @@ func TestLabels(t *testing.T) {
 			}
 		}
 	})
+	t.Run("search for parent", func(t *testing.T) {
+		result, err := Labels(form.LabelSearch{Query: "places", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		slugs := make([]string, 0, len(result))
+
+		for _, r := range result {
+			slugs = append(slugs, r.LabelSlug)
+		}
+
+		assert.Contains(t, slugs, "europe")
+		assert.Contains(t, slugs, "berlin")
+	})
+	t.Run("children", func(t *testing.T) {
+		result, err := Labels(form.LabelSearch{Parent: entity.LabelFixtures.Get("places").LabelUID, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "europe", result[0].LabelSlug)
+		}
+	})
+	t.Run("root", func(t *testing.T) {
+		result, err := Labels(form.LabelSearch{Root: true, Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "places", result[0].LabelSlug)
+		}
+	})
 	t.Run("search for favorites", func(t *testing.T) {
 		query := form.NewLabelSearch("Favorite:true Count:15")
 		result, err := Labels(query)
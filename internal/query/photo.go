@@ -152,3 +152,13 @@ func FixPrimaries() error {
 
 	return nil
 }
+
+// PhotosEstimate returns photos with known date but without coordinates, or with estimated coordinates.
+func PhotosEstimate(limit, offset int) (entities entity.Photos, err error) {
+	err = Db().
+		Where("taken_src <> ''").
+		Where("(photo_lat = 0 AND photo_lng = 0 AND place_src = ?) OR place_src = ?", entity.SrcAuto, entity.SrcEstimate).
+		Order("photos.id ASC").Limit(limit).Offset(offset).Find(&entities).Error
+
+	return entities, err
+}
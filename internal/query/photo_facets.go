@@ -0,0 +1,151 @@
+package query
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// Facet represents the number of photos matching a single filter value.
+type Facet struct {
+	Value string `json:"Value"`
+	Title string `json:"Title"`
+	Count int    `json:"Count"`
+}
+
+// Facets represents a list of facet values.
+type Facets []Facet
+
+// PhotoFacets contains photo counts grouped by common filter values.
+type PhotoFacets struct {
+	Total     int    `json:"Total"`
+	Years     Facets `json:"Years"`
+	Months    Facets `json:"Months"`
+	Countries Facets `json:"Countries"`
+	Cameras   Facets `json:"Cameras"`
+	Lenses    Facets `json:"Lenses"`
+	Labels    Facets `json:"Labels"`
+	Colors    Facets `json:"Colors"`
+	Types     Facets `json:"Types"`
+	Albums    Facets `json:"Albums"`
+}
+
+// PhotoSearchFacets returns photo counts grouped by year, month, country, camera, lens, label,
+// color, file type and album for all photos matching the search form.
+func PhotoSearchFacets(f form.PhotoSearch) (result PhotoFacets, err error) {
+	start := time.Now()
+
+	if err := f.ParseQueryString(); err != nil {
+		return result, err
+	}
+
+	s, _, err := photoSearchQuery(f)
+
+	if err != nil {
+		return result, err
+	}
+
+	photoIds := s.Select("photos.id").SubQuery()
+
+	facets := func() *gorm.DB {
+		return UnscopedDb().Table("photos").Where("photos.id IN ?", photoIds)
+	}
+
+	var total SearchCount
+
+	if err := facets().Select("COUNT(*) AS total").Scan(&total).Error; err != nil {
+		return result, err
+	}
+
+	result.Total = total.Total
+
+	if err := facets().
+		Select("photos.photo_year AS value, photos.photo_year AS title, COUNT(*) AS count").
+		Group("photos.photo_year").
+		Order("photos.photo_year DESC").
+		Scan(&result.Years).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("photos.photo_month AS value, photos.photo_month AS title, COUNT(*) AS count").
+		Group("photos.photo_month").
+		Order("photos.photo_month").
+		Scan(&result.Months).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("countries.id AS value, countries.country_name AS title, COUNT(*) AS count").
+		Joins("JOIN countries ON countries.id = photos.photo_country").
+		Group("countries.id, countries.country_name").
+		Order("count DESC, countries.country_name").
+		Scan(&result.Countries).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("cameras.id AS value, cameras.camera_name AS title, COUNT(*) AS count").
+		Joins("JOIN cameras ON cameras.id = photos.camera_id").
+		Group("cameras.id, cameras.camera_name").
+		Order("count DESC, cameras.camera_name").
+		Scan(&result.Cameras).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("lenses.id AS value, lenses.lens_name AS title, COUNT(*) AS count").
+		Joins("JOIN lenses ON lenses.id = photos.lens_id").
+		Group("lenses.id, lenses.lens_name").
+		Order("count DESC, lenses.lens_name").
+		Scan(&result.Lenses).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("labels.label_slug AS value, labels.label_name AS title, COUNT(DISTINCT photos.id) AS count").
+		Joins("JOIN photos_labels ON photos_labels.photo_id = photos.id AND photos_labels.uncertainty < 100").
+		Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+		Group("labels.label_slug, labels.label_name").
+		Order("count DESC, labels.label_name").
+		Scan(&result.Labels).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("files.file_main_color AS value, files.file_main_color AS title, COUNT(DISTINCT photos.id) AS count").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary = 1 AND files.deleted_at IS NULL").
+		Where("files.file_main_color <> ''").
+		Group("files.file_main_color").
+		Order("count DESC, files.file_main_color").
+		Scan(&result.Colors).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("files.file_type AS value, files.file_type AS title, COUNT(DISTINCT photos.id) AS count").
+		Joins("JOIN files ON files.photo_id = photos.id AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Where("files.file_type <> ''").
+		Group("files.file_type").
+		Order("count DESC, files.file_type").
+		Scan(&result.Types).Error; err != nil {
+		return result, err
+	}
+
+	if err := facets().
+		Select("albums.album_uid AS value, albums.album_title AS title, COUNT(DISTINCT photos.id) AS count").
+		Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid AND photos_albums.hidden = 0").
+		Joins("JOIN albums ON albums.album_uid = photos_albums.album_uid AND albums.deleted_at IS NULL").
+		Where("albums.album_type = ?", entity.AlbumDefault).
+		Group("albums.album_uid, albums.album_title").
+		Order("count DESC, albums.album_title").
+		Scan(&result.Albums).Error; err != nil {
+		return result, err
+	}
+
+	log.Infof("photos: found facets for %d results matching %s [%s]", result.Total, f.SerializeAll(), time.Since(start))
+
+	return result, nil
+}
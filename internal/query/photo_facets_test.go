@@ -0,0 +1,75 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoSearchFacets(t *testing.T) {
+	t.Run("all photos", func(t *testing.T) {
+		var f form.PhotoSearch
+
+		result, err := PhotoSearchFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 3, result.Total)
+		assert.NotEmpty(t, result.Years)
+		assert.NotEmpty(t, result.Months)
+		assert.NotEmpty(t, result.Cameras)
+		assert.NotEmpty(t, result.Labels)
+		assert.NotEmpty(t, result.Types)
+
+		for _, v := range result.Years {
+			assert.NotEmpty(t, v.Value)
+			assert.LessOrEqual(t, 1, v.Count)
+			assert.GreaterOrEqual(t, result.Total, v.Count)
+		}
+	})
+	t.Run("favorites", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Favorite = true
+
+		result, err := PhotoSearchFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		all, err := PhotoSearchFacets(form.PhotoSearch{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, result.Total)
+		assert.Greater(t, all.Total, result.Total)
+	})
+	t.Run("query with filter", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "year:2014"
+
+		result, err := PhotoSearchFacets(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, v := range result.Years {
+			assert.Equal(t, "2014", v.Value)
+		}
+	})
+	t.Run("label not found", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "label:xxx-not-existing"
+
+		result, err := PhotoSearchFacets(f)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.Total)
+	})
+}
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"math"
+
+	"github.com/photoprism/photoprism/pkg/colors"
+)
+
+// PaletteDistance is the default max CIELAB distance, multiplied by 100, of photos matching a color palette.
+const PaletteDistance = 30
+
+// MaxPaletteDistance is the max supported palette distance, most distances are between 0 and 150.
+const MaxPaletteDistance = 100
+
+// MaxPaletteResults is the max number of photos matching a color palette, closest first. The ids are bound
+// as query parameters, so it must stay well below the SQLite limit of 999 variables.
+const MaxPaletteResults = 500
+
+// ClampPaletteDistance returns a palette distance within the supported range.
+func ClampPaletteDistance(dist int) int {
+	if dist < 0 {
+		return 0
+	} else if dist > MaxPaletteDistance {
+		return MaxPaletteDistance
+	}
+
+	return dist
+}
+
+// PhotosByPalette returns the distances of photos whose primary file matches a list of hex colors
+// like "ff0000|00ff00", closest first and limited to MaxPaletteResults. Distances are CIELAB
+// distances multiplied by 100.
+//
+// Note that files are compared by an approximation of their 3x3 color map: Only the indexed color
+// names and the lightness of each cell are stored, so the example color of a name is used instead
+// of the actual pixel color, see colors.Cells().
+func PhotosByPalette(palette string, maxDist int) (result PhotoDistances, err error) {
+	p, err := colors.ParsePalette(palette)
+
+	if err != nil {
+		return result, err
+	}
+
+	var files []struct {
+		PhotoID       uint
+		FileColors    string
+		FileLuminance string
+	}
+
+	result = make(PhotoDistances)
+
+	maxDist = ClampPaletteDistance(maxDist)
+
+	if err := UnscopedDb().Table("files").
+		Select("photo_id, file_colors, file_luminance").
+		Where("file_primary = 1 AND file_colors <> '' AND file_missing = 0 AND deleted_at IS NULL").
+		Scan(&files).Error; err != nil {
+		return result, err
+	}
+
+	for _, f := range files {
+		cells := colors.Cells(colors.HexColors(f.FileColors), colors.HexLightMap(f.FileLuminance))
+		dist := int(math.Round(p.Distance(cells) * 100))
+
+		if dist <= maxDist {
+			result[f.PhotoID] = dist
+		}
+	}
+
+	return result.Limit(MaxPaletteResults), nil
+}
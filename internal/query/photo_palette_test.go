@@ -0,0 +1,106 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotosByPalette(t *testing.T) {
+	t.Run("green", func(t *testing.T) {
+		result, err := PhotosByPalette("#66BB6A", PaletteDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Logf("distances: %+v", result)
+
+		assert.NotEmpty(t, result)
+
+		for _, dist := range result {
+			assert.LessOrEqual(t, dist, PaletteDistance)
+		}
+	})
+	t.Run("exact", func(t *testing.T) {
+		result, err := PhotosByPalette("#66BB6A", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+	t.Run("max distance", func(t *testing.T) {
+		result, err := PhotosByPalette("#66BB6A", 100000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, len(result), MaxPaletteResults)
+
+		for _, dist := range result {
+			assert.LessOrEqual(t, dist, MaxPaletteDistance)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := PhotosByPalette("green", PaletteDistance)
+		assert.Error(t, err)
+	})
+}
+
+func TestClampPaletteDistance(t *testing.T) {
+	assert.Equal(t, 0, ClampPaletteDistance(-1))
+	assert.Equal(t, 30, ClampPaletteDistance(30))
+	assert.Equal(t, MaxPaletteDistance, ClampPaletteDistance(1500))
+}
+
+func TestPhotoSearch_Palette(t *testing.T) {
+	t.Run("color-dist", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "palette:66bb6a color-dist:1"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("ranked", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Palette = "66bb6a|a1887f"
+		f.Count = 10
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		distances, err := PhotosByPalette(f.Palette, PaletteDistance)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, photos)
+
+		for i := 1; i < len(photos); i++ {
+			assert.LessOrEqual(t, distances[photos[i-1].ID], distances[photos[i].ID])
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "palette:xyz"
+		f.Count = 10
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// RatingCondition returns an sql condition and value for rating filters like "4", ">=4" or "<3".
+// Photos without rating are only matched by "0".
+func RatingCondition(col, filter string) (cond string, rating int, err error) {
+	filter = strings.TrimSpace(filter)
+	op := "="
+
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(filter, prefix) {
+			op = prefix
+			filter = strings.TrimSpace(strings.TrimPrefix(filter, prefix))
+			break
+		}
+	}
+
+	rating, err = strconv.Atoi(filter)
+
+	if err != nil || rating < meta.RatingRejected || rating > meta.RatingMax {
+		return "", 0, fmt.Errorf("invalid rating %s", txt.Quote(filter))
+	}
+
+	switch op {
+	case "<", "<=":
+		return fmt.Sprintf("%s %s ? AND %s <> 0", col, op, col), rating, nil
+	default:
+		return fmt.Sprintf("%s %s ?", col, op), rating, nil
+	}
+}
@@ -0,0 +1,45 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingCondition(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		cond, rating, err := RatingCondition("photo_rating", "4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photo_rating = ?", cond)
+		assert.Equal(t, 4, rating)
+	})
+	t.Run("greater or equal", func(t *testing.T) {
+		cond, rating, err := RatingCondition("photo_rating", ">=3")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photo_rating >= ?", cond)
+		assert.Equal(t, 3, rating)
+	})
+	t.Run("less", func(t *testing.T) {
+		cond, rating, err := RatingCondition("photo_rating", "< 2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photo_rating < ? AND photo_rating <> 0", cond)
+		assert.Equal(t, 2, rating)
+	})
+	t.Run("rejected", func(t *testing.T) {
+		cond, rating, err := RatingCondition("photo_rating", "-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "photo_rating = ?", cond)
+		assert.Equal(t, -1, rating)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := RatingCondition("photo_rating", ">=six")
+		assert.Error(t, err)
+
+		_, _, err = RatingCondition("photo_rating", "7")
+		assert.Error(t, err)
+	})
+}
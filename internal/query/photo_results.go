@@ -32,6 +32,8 @@ type PhotoResult struct {
 	PhotoDay         int           `json:"Day"`
 	PhotoCountry     string        `json:"Country"`
 	PhotoStack       int8          `json:"Stack"`
+	BurstUID         string        `json:"BurstUID"`
+	BurstCount       int           `json:"BurstCount"`
 	PhotoFavorite    bool          `json:"Favorite"`
 	PhotoPrivate     bool          `json:"Private"`
 	PhotoIso         int           `json:"Iso"`
@@ -43,6 +45,8 @@ type PhotoResult struct {
 	PhotoColor       uint8         `json:"Color"`
 	PhotoScan        bool          `json:"Scan"`
 	PhotoPanorama    bool          `json:"Panorama"`
+	PhotoRating      int           `json:"Rating"`
+	PhotoColorLabel  string        `json:"ColorLabel"`
 	CameraID         uint          `json:"CameraID"` // Camera
 	CameraSerial     string        `json:"CameraSerial,omitempty"`
 	CameraSrc        string        `json:"CameraSrc,omitempty"`
@@ -85,6 +89,7 @@ type PhotoResult struct {
 	FileAspectRatio  float32       `json:"-"`
 	FileColors       string        `json:"-"`
 	FileChroma       uint8         `json:"-"`
+	FilePhash        string        `json:"-"`
 	FileLuminance    string        `json:"-"`
 	FileDiff         uint32        `json:"-"`
 	Merged           bool          `json:"Merged"`
@@ -21,24 +21,24 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		return results, 0, err
 	}
 
-	s := UnscopedDb()
+	s, similar, err := photoSearchQuery(f)
+
+	if err != nil {
+		return results, 0, err
+	}
+
 	// s.LogMode(true)
 
-	// Base query.
-	s = s.Table("photos").
-		Select(`photos.*, photos.id AS composite_id,
+	// Select result columns.
+	s = s.Select(`photos.*, photos.id AS composite_id,
 		files.id AS file_id, files.file_uid, files.instance_id, files.file_primary, files.file_sidecar, 
 		files.file_portrait,files.file_video, files.file_missing, files.file_name, files.file_root, files.file_hash, 
 		files.file_codec, files.file_type, files.file_mime, files.file_width, files.file_height, 
 		files.file_aspect_ratio, files.file_orientation, files.file_main_color, files.file_colors, files.file_luminance, 
-		files.file_chroma, files.file_projection, files.file_diff, files.file_duration, files.file_size,
-		cameras.camera_make, cameras.camera_model,
+		files.file_chroma, files.file_projection, files.file_diff, files.file_duration, files.file_size, files.file_phash,
+		cameras.camera_make, cameras.camera_model, bursts.photo_count AS burst_count,
 		lenses.lens_make, lenses.lens_model,
-		places.place_label, places.place_city, places.place_state, places.place_country`).
-		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
-		Joins("LEFT JOIN cameras ON photos.camera_id = cameras.id").
-		Joins("LEFT JOIN lenses ON photos.lens_id = lenses.id").
-		Joins("LEFT JOIN places ON photos.place_id = places.id")
+		places.place_label, places.place_city, places.place_state, places.place_country`)
 
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
@@ -47,6 +47,12 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Limit(MaxResults).Offset(f.Offset)
 	}
 
+	// Rank photos by distance when searching for similar images or colors.
+	if len(similar) > 0 && (f.Order == "" || f.Order == entity.SortOrderSimilar) {
+		s = s.Order(similar.Order())
+		f.Order = entity.SortOrderNewest
+	}
+
 	// Set sort order.
 	switch f.Order {
 	case entity.SortOrderEdited:
@@ -66,12 +72,52 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	case entity.SortOrderSimilar:
 		s = s.Where("files.file_diff > 0")
 		s = s.Order("photos.photo_color, photos.cell_id, files.file_diff, taken_at DESC, files.file_primary DESC")
+	case entity.SortOrderRating:
+		s = s.Order("photos.photo_rating DESC, taken_at DESC, photos.photo_uid, files.file_primary DESC")
 	case entity.SortOrderName:
 		s = s.Order("photos.photo_path, photos.photo_name, files.file_primary DESC")
+	case entity.SortOrderAlbum:
+		if f.Album != "" && f.Filter == "" {
+			s = s.Order("photos_albums.`order`, taken_at, photos.photo_uid, files.file_primary DESC")
+		} else {
+			s = s.Order("taken_at, photos.photo_uid, files.file_primary DESC")
+		}
 	default:
 		s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
 	}
 
+	// Shortcut for known photo ids.
+	if f.ID != "" {
+		s = s.Order("files.file_primary DESC")
+	}
+
+	if err := s.Scan(&results).Error; err != nil {
+		return results, 0, err
+	}
+
+	log.Infof("photos: found %d results for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+
+	if f.Merged {
+		return results.Merged()
+	}
+
+	return results, len(results), nil
+}
+
+// photoSearchQuery returns a query that applies all filters of a parsed search form
+// to photos and their files, cameras, lenses and places, as well as the distances
+// of similar photos if a similarity search was requested.
+func photoSearchQuery(f form.PhotoSearch) (s *gorm.DB, similar PhotoDistances, err error) {
+	s = UnscopedDb()
+
+	// Base query.
+	s = s.Table("photos").
+		Joins("JOIN files ON photos.id = files.photo_id AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("LEFT JOIN cameras ON photos.camera_id = cameras.id").
+		Joins("LEFT JOIN lenses ON photos.lens_id = lenses.id").
+		Joins("LEFT JOIN places ON photos.place_id = places.id").
+		Joins("LEFT JOIN bursts ON photos.burst_uid = bursts.burst_uid")
+
 	if !f.Hidden {
 		s = s.Where("files.file_type = 'jpg' OR files.file_video = 1")
 
@@ -89,31 +135,60 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 
 	// Shortcut for known photo ids.
 	if f.ID != "" {
-		s = s.Where("photos.photo_uid IN (?)", strings.Split(f.ID, Or))
-		s = s.Order("files.file_primary DESC")
+		return s.Where("photos.photo_uid IN (?)", strings.Split(f.ID, Or)), similar, nil
+	}
+
+	// Filter by perceptual image similarity or color palette.
+	if f.Similar != "" {
+		if f.Hamming <= 0 {
+			f.Hamming = SimilarDistance
+		}
+
+		if similar, err = SimilarToPhoto(f.Similar, f.Hamming); err != nil {
+			log.Errorf("search: %s", err)
+			return s, similar, err
+		}
+
+		s = s.Where("photos.id IN (?)", similar.IDs())
+	} else if f.Phash != "" {
+		if f.Hamming <= 0 {
+			f.Hamming = SimilarDistance
+		}
 
-		if result := s.Scan(&results); result.Error != nil {
-			return results, 0, result.Error
+		if similar, err = SimilarToHashes(f.Phash, f.Hamming); err != nil {
+			log.Errorf("search: %s", err)
+			return s, similar, err
 		}
 
-		log.Infof("photos: found %d results for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+		s = s.Where("photos.id IN (?)", similar.IDs())
+	} else if f.Palette != "" {
+		if f.ColorDist <= 0 {
+			f.ColorDist = PaletteDistance
+		}
 
-		if f.Merged {
-			return results.Merged()
+		if similar, err = PhotosByPalette(f.Palette, f.ColorDist); err != nil {
+			log.Errorf("search: %s", err)
+			return s, similar, err
 		}
 
-		return results, len(results), nil
+		// Only the closest photos can be returned when results are ranked by distance.
+		if f.Count > 0 && (f.Order == "" || f.Order == entity.SortOrderSimilar) {
+			similar.Limit(f.Offset + f.Count)
+		}
+
+		s = s.Where("photos.id IN (?)", similar.IDs())
 	}
 
-	// Filter by label, label category and keywords.
+	// Filter by label, label category, label hierarchy and keywords.
 	var categories []entity.Category
-	var labels []entity.Label
+	var descendants []entity.LabelParent
+	var labels entity.Labels
 	var labelIds []uint
 
 	if f.Label != "" {
-		if err := Db().Where(AnySlug("label_slug", f.Label, Or)).Or(AnySlug("custom_slug", f.Label, Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labels, err = MatchLabels(f.Label, Or); len(labels) == 0 || err != nil {
 			log.Errorf("search: labels %s not found", txt.Quote(f.Label))
-			return results, 0, fmt.Errorf("%s not found", txt.Quote(f.Label))
+			return s, similar, fmt.Errorf("%s not found", txt.Quote(f.Label))
 		} else {
 			for _, l := range labels {
 				labelIds = append(labelIds, l.ID)
@@ -125,6 +200,12 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 				for _, category := range categories {
 					labelIds = append(labelIds, category.LabelID)
 				}
+
+				Db().Where("parent_id = ?", l.ID).Find(&descendants)
+
+				for _, descendant := range descendants {
+					labelIds = append(labelIds, descendant.LabelID)
+				}
 			}
 
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = photos.id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
@@ -136,15 +217,15 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	if f.Geo == true {
 		s = s.Where("photos.cell_id <> 'zz'")
 
-		if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
-			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
+		if keywords := KeywordExpr("k.keyword", f.Query); keywords != nil {
+			s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", keywords)
 		}
 	} else if f.Query != "" {
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labels, err = MatchLabels(f.Query, " "); len(labels) == 0 || err != nil {
 			log.Infof("search: label %s not found, using fuzzy search", txt.Quote(f.Query))
 
-			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
-				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(likeAny))
+			if keywords := KeywordExpr("k.keyword", f.Query); keywords != nil {
+				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", keywords)
 			}
 		} else {
 			for _, l := range labels {
@@ -157,11 +238,17 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 				for _, category := range categories {
 					labelIds = append(labelIds, category.LabelID)
 				}
+
+				Db().Where("parent_id = ?", l.ID).Find(&descendants)
+
+				for _, descendant := range descendants {
+					labelIds = append(labelIds, descendant.LabelID)
+				}
 			}
 
-			if likeAny := LikeAny("k.keyword", f.Query); likeAny != "" {
+			if keywords := KeywordExpr("k.keyword", f.Query); keywords != nil {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
-					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", gorm.Expr(likeAny), labelIds)
+					"photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", keywords, labelIds)
 			} else {
 				s = s.Where("photos.id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
 			}
@@ -216,6 +303,20 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("files.file_main_color IN (?)", strings.Split(strings.ToLower(f.Color), Or))
 	}
 
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", strings.Split(strings.ToLower(f.ColorLabel), Or))
+	}
+
+	if f.Rating != "" {
+		cond, rating, err := RatingCondition("photos.photo_rating", f.Rating)
+
+		if err != nil {
+			return s, similar, err
+		}
+
+		s = s.Where(cond, rating)
+	}
+
 	if f.Favorite {
 		s = s.Where("photos.photo_favorite = 1")
 	}
@@ -234,6 +335,13 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_stack = -1")
 	}
 
+	// Show only the best frame of bursts in the default library view, unless a burst is expanded.
+	if f.Burst != "" {
+		s = s.Where("photos.burst_uid = ?", f.Burst)
+	} else if collapseBursts(f) {
+		s = s.Where("bursts.id IS NULL OR bursts.photo_uid = '' OR bursts.photo_uid = photos.photo_uid")
+	}
+
 	if f.Country != "" {
 		s = s.Where("photos.photo_country IN (?)", strings.Split(strings.ToLower(f.Country), Or))
 	}
@@ -369,15 +477,19 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 0)")
 	}
 
-	if err := s.Scan(&results).Error; err != nil {
-		return results, 0, err
-	}
+	return s, similar, nil
+}
 
-	log.Infof("photos: found %d results for %s [%s]", len(results), f.SerializeAll(), time.Since(start))
+// collapseBursts tests if only the best frame of bursts should be returned, which is the case
+// for the default library view without filters, as other frames may match a filter better.
+func collapseBursts(f form.PhotoSearch) bool {
+	f.Filter = ""
+	f.Public = false
+	f.Primary = false
 
-	if f.Merged {
-		return results.Merged()
+	if f.Quality <= 3 {
+		f.Quality = 0
 	}
 
-	return results, len(results), nil
+	return f.Serialize() == ""
 }
@@ -2,6 +2,7 @@ package query
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/entity"
 
@@ -78,6 +79,63 @@ func TestPhotoSearch(t *testing.T) {
 		assert.Equal(t, "dog not found", err.Error())
 		assert.Empty(t, photos)
 	})
+	t.Run("label query synonym", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "label:landschaft"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+	})
+	t.Run("label query typo", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "label:landsacpe"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+	})
+	t.Run("keyword typo", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "brigde"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("label query parent", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Label = "places"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.Equal(t, "pt9jtdre2lvl0yh0", r.PhotoUID)
+		}
+	})
 	t.Run("label query landscape", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "label:landscape Order:relevance"
@@ -296,6 +354,67 @@ func TestPhotoSearch(t *testing.T) {
 
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("form.rating", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "rating:>=4"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.GreaterOrEqual(t, r.PhotoRating, 4)
+		}
+	})
+	t.Run("invalid rating", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "rating:>=abc"
+		f.Count = 10
+		f.Offset = 0
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Error(t, err)
+	})
+	t.Run("form.colorlabel", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "color-label:red"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+
+		for _, r := range photos {
+			assert.Equal(t, "red", r.PhotoColorLabel)
+		}
+	})
+	t.Run("order rating", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Order = entity.SortOrderRating
+		f.Count = 10
+		f.Offset = 0
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+		assert.Equal(t, 5, photos[0].PhotoRating)
+	})
 	t.Run("form.country", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "country:zz"
@@ -507,6 +626,19 @@ func TestPhotoSearch(t *testing.T) {
 		}
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("album order", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Album = "at9lxuqxpogaaba9"
+		f.Order = entity.SortOrderAlbum
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+	})
 	t.Run("search for state", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.State = "KwaZulu-Natal"
@@ -630,3 +762,83 @@ func TestPhotoSearch(t *testing.T) {
 		assert.IsType(t, PhotoResults{}, photos)
 	})
 }
+
+func TestPhotoSearch_Burst(t *testing.T) {
+	burst := entity.NewBurst("", "TestPhotoSearch_Burst", time.Now())
+
+	if err := burst.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	public := entity.PhotoFixtures.Get("Photo04")
+	private := entity.PhotoFixtures.Get("Photo10")
+
+	if err := private.Update("PhotoPrivate", true); err != nil {
+		t.Fatal(err)
+	}
+
+	defer private.Update("PhotoPrivate", false)
+
+	for _, p := range []entity.Photo{public, private} {
+		if err := p.Update("BurstUID", burst.BurstUID); err != nil {
+			t.Fatal(err)
+		}
+
+		defer p.Update("BurstUID", "")
+	}
+
+	if err := burst.UpdateBest(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, public.PhotoUID, burst.PhotoUID)
+
+	search := func(f form.PhotoSearch) (uids []string) {
+		f.Count = 1000
+
+		results, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			uids = append(uids, r.PhotoUID)
+		}
+
+		return uids
+	}
+
+	t.Run("library", func(t *testing.T) {
+		uids := search(form.PhotoSearch{})
+
+		assert.Contains(t, uids, public.PhotoUID)
+		assert.NotContains(t, uids, private.PhotoUID)
+	})
+
+	t.Run("private", func(t *testing.T) {
+		assert.Contains(t, search(form.PhotoSearch{Private: true}), private.PhotoUID)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		assert.Contains(t, search(form.PhotoSearch{Year: private.PhotoYear}), private.PhotoUID)
+	})
+
+	t.Run("expanded", func(t *testing.T) {
+		uids := search(form.PhotoSearch{Burst: burst.BurstUID, Private: true})
+
+		assert.Equal(t, []string{private.PhotoUID}, uids)
+	})
+}
+
+func TestCollapseBursts(t *testing.T) {
+	assert.True(t, collapseBursts(form.PhotoSearch{}))
+	assert.True(t, collapseBursts(form.PhotoSearch{Public: true, Quality: 3, Merged: true, Count: 10, Order: entity.SortOrderNewest}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Query: "flower"}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Label: "flower", Public: true}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Quality: 4}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Album: "at9lxuqxpogaaba7"}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Private: true}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Archived: true}))
+	assert.False(t, collapseBursts(form.PhotoSearch{Before: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}))
+}
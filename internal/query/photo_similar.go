@@ -0,0 +1,285 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SimilarDistance is the default max Hamming distance of similar images.
+const SimilarDistance = 10
+
+// MaxSimilarDistance is the max supported Hamming distance, so that each hex digit
+// of a hash can be used as separate block when searching for candidates.
+const MaxSimilarDistance = phash.Bits/4 - 1
+
+// MaxSimilarResults is the max number of similar photos returned, closest first. The ids are bound
+// as query parameters, so it must stay well below the SQLite limit of 999 variables.
+const MaxSimilarResults = 500
+
+// PhotoDistances maps photo ids to the Hamming distance of their most similar file.
+type PhotoDistances map[uint]int
+
+// IDs returns the photo ids sorted by distance.
+func (d PhotoDistances) IDs() []uint {
+	ids := make([]uint, 0, len(d))
+
+	for id := range d {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if d[ids[i]] == d[ids[j]] {
+			return ids[i] < ids[j]
+		}
+
+		return d[ids[i]] < d[ids[j]]
+	})
+
+	return ids
+}
+
+// Limit removes all but the closest photos.
+func (d PhotoDistances) Limit(n int) PhotoDistances {
+	if len(d) > n {
+		for _, id := range d.IDs()[n:] {
+			delete(d, id)
+		}
+	}
+
+	return d
+}
+
+// Order returns an sql expression that sorts photos by distance.
+func (d PhotoDistances) Order() *gorm.SqlExpr {
+	buckets := make(map[int][]string)
+	var dists []int
+
+	for id, dist := range d {
+		if _, ok := buckets[dist]; !ok {
+			dists = append(dists, dist)
+		}
+
+		buckets[dist] = append(buckets[dist], strconv.FormatUint(uint64(id), 10))
+	}
+
+	if len(dists) == 0 {
+		return gorm.Expr("photos.id")
+	}
+
+	sort.Ints(dists)
+
+	var when []string
+
+	for _, dist := range dists {
+		when = append(when, fmt.Sprintf("WHEN photos.id IN (%s) THEN %d", strings.Join(buckets[dist], ","), dist))
+	}
+
+	return gorm.Expr(fmt.Sprintf("CASE %s ELSE %d END", strings.Join(when, " "), phash.Bits))
+}
+
+// ClampSimilarDistance returns a Hamming distance within the supported range.
+func ClampSimilarDistance(dist int) int {
+	if dist < 0 {
+		return 0
+	} else if dist > MaxSimilarDistance {
+		return MaxSimilarDistance
+	}
+
+	return dist
+}
+
+// phashCandidates returns an sql condition that matches perceptual hashes which may be within the
+// max Hamming distance: Hashes are split into maxDist+1 blocks of hex digits, similar hashes must
+// have at least one identical block.
+func phashCandidates(maxDist int, hashes []phash.Hash) (where string, values []interface{}) {
+	digits := phash.Bits / 4
+	blocks := maxDist + 1
+	var cond []string
+
+	for _, h := range hashes {
+		hex := h.Hex()
+		start := 0
+
+		for b := 0; b < blocks; b++ {
+			size := digits / blocks
+
+			if b < digits%blocks {
+				size++
+			}
+
+			// The first block is a prefix, so that the index can be used.
+			if start == 0 {
+				cond = append(cond, "file_phash LIKE ?")
+				values = append(values, hex[:size]+"%")
+			} else {
+				cond = append(cond, fmt.Sprintf("SUBSTR(file_phash, %d, %d) = ?", start+1, size))
+				values = append(values, hex[start:start+size])
+			}
+
+			start += size
+		}
+	}
+
+	return "(" + strings.Join(cond, " OR ") + ")", values
+}
+
+// SimilarPhotos returns the distances of photos with a file within the max Hamming distance of the perceptual
+// hashes, closest first and limited to MaxSimilarResults.
+func SimilarPhotos(maxDist int, hashes ...phash.Hash) (result PhotoDistances, err error) {
+	var files []struct {
+		PhotoID   uint
+		FilePhash string
+	}
+
+	result = make(PhotoDistances)
+
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	maxDist = ClampSimilarDistance(maxDist)
+
+	where, values := phashCandidates(maxDist, hashes)
+
+	if err := UnscopedDb().Table("files").
+		Select("photo_id, file_phash").
+		Where("file_phash <> '' AND file_missing = 0 AND deleted_at IS NULL").
+		Where(where, values...).
+		Scan(&files).Error; err != nil {
+		return result, err
+	}
+
+	for _, f := range files {
+		h, err := phash.Parse(f.FilePhash)
+
+		if err != nil {
+			continue
+		}
+
+		for _, hash := range hashes {
+			dist := hash.Distance(h)
+
+			if dist > maxDist {
+				continue
+			}
+
+			if prev, ok := result[f.PhotoID]; !ok || dist < prev {
+				result[f.PhotoID] = dist
+			}
+		}
+	}
+
+	return result.Limit(MaxSimilarResults), nil
+}
+
+// SimilarToPhoto returns the distances of all photos that are similar to the primary file of a photo.
+func SimilarToPhoto(photoUID string, maxDist int) (result PhotoDistances, err error) {
+	var file entity.File
+
+	if err := UnscopedDb().Where("photo_uid = ? AND file_primary = 1 AND file_phash <> ''", photoUID).First(&file).Error; err != nil {
+		return result, fmt.Errorf("no perceptual hash found for %s", txt.Quote(photoUID))
+	}
+
+	hash, err := phash.Parse(file.FilePhash)
+
+	if err != nil {
+		return result, err
+	}
+
+	return SimilarPhotos(maxDist, hash)
+}
+
+// SimilarToHashes returns the distances of all photos that are similar to one or more hex encoded perceptual hashes.
+func SimilarToHashes(hashes string, maxDist int) (result PhotoDistances, err error) {
+	var values []phash.Hash
+
+	for _, s := range strings.Split(hashes, Or) {
+		h, err := phash.Parse(strings.TrimSpace(s))
+
+		if err != nil {
+			return result, err
+		}
+
+		values = append(values, h)
+	}
+
+	return SimilarPhotos(maxDist, values...)
+}
+
+// SimilarFile represents a primary file in a group of near-duplicates.
+type SimilarFile struct {
+	PhotoUID  string `json:"PhotoUID"`
+	FileUID   string `json:"UID"`
+	FileName  string `json:"Name"`
+	FileRoot  string `json:"Root"`
+	FileHash  string `json:"Hash"`
+	FilePhash string `json:"Phash"`
+	Distance  int    `json:"Distance"`
+}
+
+// SimilarFiles represents a group of near-duplicates.
+type SimilarFiles []SimilarFile
+
+// NearDuplicates returns groups of photos whose primary files have a perceptual hash within the max Hamming distance.
+//
+// Groups are built transitively, so the distance of each file is the distance to its closest group member.
+func NearDuplicates(maxDist, limit, offset int) (result []SimilarFiles, err error) {
+	var files SimilarFiles
+
+	maxDist = ClampSimilarDistance(maxDist)
+
+	if err := UnscopedDb().Table("files").
+		Select("files.photo_uid, files.file_uid, files.file_name, files.file_root, files.file_hash, files.file_phash").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = 1 AND files.file_phash <> '' AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Order("files.file_name").
+		Scan(&files).Error; err != nil {
+		return result, err
+	}
+
+	hashes := make([]phash.Hash, len(files))
+
+	for i, f := range files {
+		hashes[i], _ = phash.Parse(f.FilePhash)
+	}
+
+	for _, group := range phash.Clusters(hashes, maxDist) {
+		similar := make(SimilarFiles, len(group))
+
+		for i, index := range group {
+			similar[i] = files[index]
+			similar[i].Distance = phash.Bits
+
+			for _, other := range group {
+				if other == index {
+					continue
+				}
+
+				if dist := hashes[index].Distance(hashes[other]); dist < similar[i].Distance {
+					similar[i].Distance = dist
+				}
+			}
+		}
+
+		result = append(result, similar)
+	}
+
+	if offset >= len(result) {
+		return []SimilarFiles{}, nil
+	}
+
+	result = result[offset:]
+
+	if limit > 0 && limit < len(result) {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
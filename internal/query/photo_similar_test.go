@@ -0,0 +1,194 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoDistances_IDs(t *testing.T) {
+	d := PhotoDistances{5: 3, 2: 0, 7: 3, 1: 1}
+	assert.Equal(t, []uint{2, 1, 5, 7}, d.IDs())
+}
+
+func TestPhotoDistances_Limit(t *testing.T) {
+	d := PhotoDistances{5: 3, 2: 0, 7: 3, 1: 1}
+	assert.Equal(t, PhotoDistances{2: 0, 1: 1}, d.Limit(2))
+	assert.Equal(t, PhotoDistances{2: 0, 1: 1}, d.Limit(5))
+}
+
+func TestClampSimilarDistance(t *testing.T) {
+	assert.Equal(t, 0, ClampSimilarDistance(-1))
+	assert.Equal(t, 10, ClampSimilarDistance(10))
+	assert.Equal(t, MaxSimilarDistance, ClampSimilarDistance(64))
+}
+
+func TestSimilarPhotos(t *testing.T) {
+	t.Run("bridge", func(t *testing.T) {
+		hash, err := phash.Parse("1c3c7cfcf8f0e0c0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := SimilarPhotos(2, hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, result[entity.PhotoFixtures.Pointer("Photo04").ID])
+		assert.Equal(t, 1, result[entity.PhotoFixtures.Pointer("Photo02").ID])
+		assert.Equal(t, 2, result[entity.PhotoFixtures.Pointer("Photo03").ID])
+	})
+	t.Run("unbounded", func(t *testing.T) {
+		hash, err := phash.Parse("1c3c7cfcf8f0e0c0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := SimilarPhotos(64, hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, dist := range result {
+			assert.LessOrEqual(t, dist, MaxSimilarDistance)
+		}
+	})
+	t.Run("exact", func(t *testing.T) {
+		hash, err := phash.Parse("1c3c7cfcf8f0e0c0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := SimilarPhotos(0, hash)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestSimilarToPhoto(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := SimilarToPhoto("pt9jtdre2lvl0y11", 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("no hash", func(t *testing.T) {
+		_, err := SimilarToPhoto("pt9jtdre2lvl0y13", 1)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSimilarToHashes(t *testing.T) {
+	t.Run("multiple", func(t *testing.T) {
+		result, err := SimilarToHashes("1c3c7cfcf8f0e0c0|f0e4c2d7c6c2c0e0", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := SimilarToHashes("1c3c7cfcf8f0e0c0|xxx", 0)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotoSearch_Similar(t *testing.T) {
+	t.Run("ranked by distance", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Similar = "pt9jtdre2lvl0y11"
+		f.Hamming = 4
+		f.Count = 10
+		f.Primary = true
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 3) {
+			assert.Equal(t, "pt9jtdre2lvl0y11", photos[0].PhotoUID)
+			assert.Equal(t, "pt9jtdre2lvl0yh9", photos[1].PhotoUID)
+			assert.Equal(t, "pt9jtdre2lvl0yh0", photos[2].PhotoUID)
+		}
+	})
+	t.Run("phash", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Phash = "1c3c7cfcf8f0e0c3"
+		f.Hamming = 2
+		f.Count = 10
+		f.Primary = true
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 3) {
+			assert.Equal(t, "pt9jtdre2lvl0yh0", photos[0].PhotoUID)
+			assert.Equal(t, "1c3c7cfcf8f0e0c3", photos[0].FilePhash)
+		}
+	})
+	t.Run("query", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "similar:pt9jtdre2lvl0y11 hamming:1"
+		f.Count = 10
+		f.Primary = true
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 2)
+	})
+}
+
+func TestNearDuplicates(t *testing.T) {
+	t.Run("bridge", func(t *testing.T) {
+		result, err := NearDuplicates(2, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Len(t, result[0], 3)
+
+			for _, f := range result[0] {
+				assert.LessOrEqual(t, f.Distance, 2)
+			}
+		}
+	})
+	t.Run("offset", func(t *testing.T) {
+		result, err := NearDuplicates(2, 10, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
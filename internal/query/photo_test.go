@@ -80,6 +80,24 @@ func TestPhotosCheck(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosEstimate(t *testing.T) {
+	result, err := PhotosEstimate(100, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.IsType(t, entity.Photos{}, result)
+
+	for _, p := range result {
+		assert.NotEqual(t, entity.SrcAuto, p.TakenSrc)
+
+		if p.PlaceSrc != entity.SrcEstimate {
+			assert.False(t, p.HasLatLng())
+		}
+	}
+}
+
 func TestPhotosOrphaned(t *testing.T) {
 	result, err := PhotosOrphaned()
 
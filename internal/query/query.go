@@ -123,19 +123,16 @@ func LikeAny(col, search string) (where string) {
 	return strings.Join(wheres, " OR ")
 }
 
-// AnySlug returns a where condition that matches any slug in search.
-func AnySlug(col, search, sep string) (where string) {
+// SlugWords returns the slugs of all words in search, including the singular of english words.
+func SlugWords(search, sep string) (words []string) {
 	if search == "" {
-		return ""
+		return words
 	}
 
 	if sep == "" {
 		sep = " "
 	}
 
-	var wheres []string
-	var words []string
-
 	for _, w := range strings.Split(search, sep) {
 		w = strings.TrimSpace(w)
 
@@ -152,10 +149,19 @@ func AnySlug(col, search, sep string) (where string) {
 		}
 	}
 
+	return words
+}
+
+// AnySlug returns a where condition that matches any slug in search.
+func AnySlug(col, search, sep string) (where string) {
+	words := SlugWords(search, sep)
+
 	if len(words) == 0 {
 		return ""
 	}
 
+	var wheres []string
+
 	for _, w := range words {
 		wheres = append(wheres, fmt.Sprintf("%s = '%s'", col, w))
 	}
@@ -0,0 +1,19 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoRevisions returns the edit history of a photo, newest first.
+func PhotoRevisions(photoUID string) (results entity.Revisions, err error) {
+	err = Db().Where("photo_uid = ?", photoUID).Order("id DESC").Find(&results).Error
+
+	return results, err
+}
+
+// ChangeRevisions returns all revisions that belong to the same change, e.g. a batch edit.
+func ChangeRevisions(changeUID string) (results entity.Revisions, err error) {
+	err = Db().Where("change_uid = ?", changeUID).Order("id DESC").Find(&results).Error
+
+	return results, err
+}
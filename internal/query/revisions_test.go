@@ -0,0 +1,52 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoRevisions(t *testing.T) {
+	change := entity.NewChange("", entity.ChangeManual)
+
+	old := entity.Photo{PhotoUID: "pt9jtdre2lvl0y22", PhotoTitle: "Old"}
+	updated := entity.Photo{PhotoUID: "pt9jtdre2lvl0y22", PhotoTitle: "New"}
+
+	change.Photo(&old, &updated)
+
+	if err := change.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("photo", func(t *testing.T) {
+		results, err := PhotoRevisions("pt9jtdre2lvl0y22")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, results) {
+			assert.Equal(t, "PhotoTitle", results[0].Field)
+			assert.Equal(t, "Old", results[0].OldValue)
+			assert.Equal(t, "New", results[0].NewValue)
+		}
+	})
+
+	t.Run("change", func(t *testing.T) {
+		results, err := ChangeRevisions(change.ChangeUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		results, err := PhotoRevisions("pt9jtdre2lvl0xxx")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
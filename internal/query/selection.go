@@ -33,12 +33,13 @@ func PhotoSelection(f form.Selection) (results entity.Photos, err error) {
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
 		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
-		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
+		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))
+		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels_parents lp ON lp.label_id = pl.label_id JOIN labels lh ON lh.id = lp.parent_id AND lh.deleted_at IS NULL WHERE lh.label_uid IN (?))`,
 		concat)
 
 	s := UnscopedDb().Table("photos").
 		Select("photos.*").
-		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels)
+		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels, f.Labels)
 
 	if result := s.Scan(&results); result.Error != nil {
 		return results, result.Error
@@ -72,7 +73,8 @@ func FileSelection(f form.Selection) (results entity.Files, err error) {
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
 		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
-		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
+		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))
+		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels_parents lp ON lp.label_id = pl.label_id JOIN labels lh ON lh.id = lp.parent_id AND lh.deleted_at IS NULL WHERE lh.label_uid IN (?))`,
 		concat)
 
 	s := UnscopedDb().Table("files").
@@ -80,7 +82,7 @@ func FileSelection(f form.Selection) (results entity.Files, err error) {
 		Joins("JOIN photos ON photos.id = files.photo_id").
 		Where("photos.deleted_at IS NULL").
 		Where("files.file_missing = 0").
-		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels).
+		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Labels, f.Labels, f.Labels).
 		Group("files.id")
 
 	if result := s.Scan(&results); result.Error != nil {
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"errors"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// TimeShiftPhotos returns the selected photos, filtered by camera serial and local date range if set.
+func TimeShiftPhotos(f form.TimeShift) (results entity.Photos, err error) {
+	if f.Empty() {
+		return results, errors.New("no items selected")
+	}
+
+	s := Db().Table("photos").Select("photos.*").Where("photos.deleted_at IS NULL")
+
+	if !f.Selection.Empty() {
+		selected, err := PhotoSelection(f.Selection)
+
+		if err != nil {
+			return results, err
+		}
+
+		ids := make([]uint, len(selected))
+
+		for i, p := range selected {
+			ids[i] = p.ID
+		}
+
+		s = s.Where("photos.id IN (?)", ids)
+	}
+
+	if f.CameraSerial != "" {
+		s = s.Where("photos.camera_serial = ?", f.CameraSerial)
+	}
+
+	if !f.After.IsZero() {
+		s = s.Where("photos.taken_at_local >= ?", f.After)
+	}
+
+	if !f.Before.IsZero() {
+		s = s.Where("photos.taken_at_local <= ?", f.Before)
+	}
+
+	if err := s.Order("photos.taken_at_local").Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
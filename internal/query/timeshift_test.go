@@ -0,0 +1,66 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShiftPhotos(t *testing.T) {
+	t.Run("selection", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{Selection: form.Selection{Photos: []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("selection and date range", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{
+			Selection: form.Selection{Photos: []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}},
+			After:     time.Date(2007, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", results[0].PhotoUID)
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{
+			After:  time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC),
+			Before: time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC),
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range results {
+			assert.Equal(t, 2006, p.TakenAtLocal.Year())
+		}
+	})
+
+	t.Run("camera serial", func(t *testing.T) {
+		results, err := TimeShiftPhotos(form.TimeShift{CameraSerial: "no-such-serial"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := TimeShiftPhotos(form.TimeShift{Offset: 3600})
+
+		assert.Error(t, err)
+	})
+}
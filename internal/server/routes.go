@@ -48,6 +48,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteSession(v1)
 
 		api.GetThumb(v1)
+		api.GetTile(v1)
 		api.GetDownload(v1)
 		api.GetVideo(v1)
 		api.CreateZip(v1)
@@ -56,8 +57,14 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetGeo(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
+		api.GetPhotoColors(v1)
 		api.UpdatePhoto(v1)
+		api.GetPhotoHistory(v1)
+		api.RevertPhotoHistory(v1)
 		api.GetPhotos(v1)
+		api.GetPhotoFacets(v1)
+		api.GetNearDuplicates(v1)
+		api.SearchByImage(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
 		api.CreatePhotoLink(v1)
@@ -74,9 +81,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteFile(v1)
 		api.PhotoPrimary(v1)
 		api.PhotoUnstack(v1)
+		api.PhotoBurstBest(v1)
 
 		api.GetLabels(v1)
 		api.UpdateLabel(v1)
+		api.GetLabelTree(v1)
+		api.GetLabelSynonyms(v1)
+		api.UpdateLabelSynonyms(v1)
 		api.GetLabelLinks(v1)
 		api.CreateLabelLink(v1)
 		api.UpdateLabelLink(v1)
@@ -91,14 +102,20 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.Upload(v1)
 		api.StartImport(v1)
 		api.CancelImport(v1)
+		api.PreviewImportTemplate(v1)
 		api.StartIndexing(v1)
 		api.CancelIndexing(v1)
+		api.UploadTracks(v1)
+		api.StartGeotag(v1)
 
 		api.BatchPhotosApprove(v1)
 		api.BatchPhotosArchive(v1)
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
 		api.BatchPhotosDelete(v1)
+		api.BatchPhotosTimeShift(v1)
+		api.BatchPhotosEdit(v1)
+		api.UndoChange(v1)
 		api.BatchAlbumsDelete(v1)
 		api.BatchLabelsDelete(v1)
 
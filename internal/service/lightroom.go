@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceLightroom sync.Once
+
+func initLightroom() {
+	services.Lightroom = photoprism.NewLightroom(Config())
+}
+
+func Lightroom() *photoprism.Lightroom {
+	onceLightroom.Do(initLightroom)
+
+	return services.Lightroom
+}
@@ -24,6 +24,8 @@ var services struct {
 	Import      *photoprism.Import
 	Index       *photoprism.Index
 	Moments     *photoprism.Moments
+	Geotag      *photoprism.Geotag
+	Lightroom   *photoprism.Lightroom
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
 	Nsfw        *nsfw.Detector
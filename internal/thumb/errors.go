@@ -6,4 +6,5 @@ import (
 
 var (
 	ErrThumbNotCached = errors.New("thumbnail not cached")
+	ErrTilesBusy      = errors.New("tiles: too many images queued")
 )
@@ -0,0 +1,47 @@
+package thumb
+
+import (
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotate(t *testing.T) {
+	img, err := imaging.Open("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		result := Rotate(img, 1)
+
+		assert.Equal(t, 750, result.Bounds().Dx())
+		assert.Equal(t, 500, result.Bounds().Dy())
+	})
+	t.Run("rotate 180", func(t *testing.T) {
+		result := Rotate(img, 3)
+
+		assert.Equal(t, 750, result.Bounds().Dx())
+		assert.Equal(t, 500, result.Bounds().Dy())
+	})
+	t.Run("rotate 90 cw", func(t *testing.T) {
+		result := Rotate(img, 6)
+
+		assert.Equal(t, 500, result.Bounds().Dx())
+		assert.Equal(t, 750, result.Bounds().Dy())
+	})
+	t.Run("rotate 90 ccw", func(t *testing.T) {
+		result := Rotate(img, 8)
+
+		assert.Equal(t, 500, result.Bounds().Dx())
+		assert.Equal(t, 750, result.Bounds().Dy())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		result := Rotate(img, 0)
+
+		assert.Equal(t, 750, result.Bounds().Dx())
+		assert.Equal(t, 500, result.Bounds().Dy())
+	})
+}
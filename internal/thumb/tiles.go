@@ -0,0 +1,401 @@
+package thumb
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+const (
+	TileSize     = 256
+	TileOverlap  = 1
+	TileFormat   = fs.FormatJpeg
+	TilesSuffix  = "_tiles"
+	CubeTileSize = 512
+	CubeFaces    = "fbudlr"
+	DeepZoomName = "image.dzi"
+	CubeMapName  = "cube.json"
+)
+
+// TileMaxPixels is the maximum number of pixels of images that tiles are created for.
+var TileMaxPixels = 200000000
+
+var tileMutex = sync.Mutex{}
+
+// DeepZoom represents a Deep Zoom image descriptor as used by viewers like OpenSeadragon.
+type DeepZoom struct {
+	XMLName  xml.Name     `xml:"http://schemas.microsoft.com/deepzoom/2008 Image"`
+	TileSize int          `xml:"TileSize,attr"`
+	Overlap  int          `xml:"Overlap,attr"`
+	Format   string       `xml:"Format,attr"`
+	Size     DeepZoomSize `xml:"Size"`
+}
+
+// DeepZoomSize represents the full resolution of a Deep Zoom image.
+type DeepZoomSize struct {
+	Width  int `xml:"Width,attr"`
+	Height int `xml:"Height,attr"`
+}
+
+// CubeMap represents a multi-resolution cube map as used by 360° viewers like Pannellum.
+type CubeMap struct {
+	Path           string `json:"path"`
+	Extension      string `json:"extension"`
+	TileResolution int    `json:"tileResolution"`
+	MaxLevel       int    `json:"maxLevel"`
+	CubeResolution int    `json:"cubeResolution"`
+}
+
+// TilesPath returns the cache folder for the image tiles of a file.
+func TilesPath(hash, thumbPath string) (string, error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("tiles: file hash is empty or too short (%s)", txt.Quote(hash))
+	}
+
+	if len(thumbPath) == 0 {
+		return "", errors.New("tiles: folder is empty")
+	}
+
+	return path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+TilesSuffix), nil
+}
+
+// TileMaxLevel returns the highest Deep Zoom level for the image size.
+func TileMaxLevel(width, height int) (level int) {
+	for size := 1; size < width || size < height; size *= 2 {
+		level++
+	}
+
+	return level
+}
+
+// DeepZoomTiles creates a Deep Zoom tile pyramid if needed and returns the descriptor filename.
+func DeepZoomTiles(imageFilename, hash, thumbPath string) (fileName string, err error) {
+	dir, err := TilesPath(hash, thumbPath)
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName = filepath.Join(dir, DeepZoomName)
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	tileMutex.Lock()
+	defer tileMutex.Unlock()
+
+	// Created by another request in the meantime?
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	src, err := openTileImage(imageFilename)
+
+	if err != nil {
+		return "", err
+	}
+
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	fullWidth, fullHeight := width, height
+	filesPath := filepath.Join(dir, "image_files")
+
+	for level := TileMaxLevel(width, height); level >= 0; level-- {
+		if src.Bounds().Dx() > width {
+			src = imaging.Resize(src, width, height, imaging.Box)
+		}
+
+		levelPath := filepath.Join(filesPath, strconv.Itoa(level))
+
+		if err := saveTiles(src, TileSize, TileOverlap, func(col, row int) string {
+			return filepath.Join(levelPath, fmt.Sprintf("%d_%d.%s", col, row, TileFormat))
+		}); err != nil {
+			return "", err
+		}
+
+		width, height = (width+1)/2, (height+1)/2
+	}
+
+	result := DeepZoom{
+		TileSize: TileSize,
+		Overlap:  TileOverlap,
+		Format:   string(TileFormat),
+		Size:     DeepZoomSize{Width: fullWidth, Height: fullHeight},
+	}
+
+	data, err := xml.MarshalIndent(result, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	// The descriptor is written last, so that incomplete pyramids are created again.
+	if err := ioutil.WriteFile(fileName, append([]byte(xml.Header), data...), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// CubeTiles creates multi-resolution cube face tiles for an equirectangular panorama if needed
+// and returns the descriptor filename.
+func CubeTiles(imageFilename, hash, thumbPath string) (fileName string, err error) {
+	dir, err := TilesPath(hash, thumbPath)
+
+	if err != nil {
+		return "", err
+	}
+
+	fileName = filepath.Join(dir, CubeMapName)
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	tileMutex.Lock()
+	defer tileMutex.Unlock()
+
+	// Created by another request in the meantime?
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	src, err := openTileImage(imageFilename)
+
+	if err != nil {
+		return "", err
+	}
+
+	// Each face covers 90° of the 360° panorama width, rounded to a power of two multiple of the tile size.
+	faceSize := CubeTileSize
+	maxLevel := 1
+
+	for float64(faceSize)*1.5 < float64(src.Bounds().Dx())/4 {
+		faceSize *= 2
+		maxLevel++
+	}
+
+	filesPath := filepath.Join(dir, "cube_files")
+
+	for _, face := range CubeFaces {
+		faceImg := cubeFace(src, face, faceSize)
+
+		for level := maxLevel; level >= 1; level-- {
+			size := faceSize >> uint(maxLevel-level)
+
+			if faceImg.Bounds().Dx() > size {
+				faceImg = imaging.Resize(faceImg, size, size, imaging.Box)
+			}
+
+			levelPath := filepath.Join(filesPath, strconv.Itoa(level))
+
+			if err := saveTiles(faceImg, CubeTileSize, 0, func(col, row int) string {
+				return filepath.Join(levelPath, fmt.Sprintf("%c%d_%d.%s", face, row, col, TileFormat))
+			}); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	result := CubeMap{
+		Path:           "/cube_files/%l/%s%y_%x",
+		Extension:      string(TileFormat),
+		TileResolution: CubeTileSize,
+		MaxLevel:       maxLevel,
+		CubeResolution: faceSize,
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	// The descriptor is written last, so that incomplete tiles are created again.
+	if err := ioutil.WriteFile(fileName, data, os.ModePerm); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// checkTileImage returns an error if the image can't be read or has more than TileMaxPixels pixels.
+func checkTileImage(fileName string) error {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	config, _, err := image.DecodeConfig(file)
+
+	if err != nil {
+		return fmt.Errorf("tiles: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	} else if pixels := config.Width * config.Height; pixels > TileMaxPixels {
+		return fmt.Errorf("tiles: %s has %d pixels, the maximum is %d", txt.Quote(filepath.Base(fileName)), pixels, TileMaxPixels)
+	}
+
+	return nil
+}
+
+// openTileImage decodes an image for creating tiles without keeping a converted copy in memory.
+func openTileImage(fileName string) (image.Image, error) {
+	if err := checkTileImage(fileName); err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Open(fileName, imaging.AutoOrientation(true))
+
+	if err != nil {
+		return nil, fmt.Errorf("tiles: %s in %s", err, txt.Quote(filepath.Base(fileName)))
+	}
+
+	return img, nil
+}
+
+// saveTiles splits the image into tiles of the given size and overlap.
+func saveTiles(img image.Image, size, overlap int, tileName func(col, row int) string) error {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	for row := 0; row*size < height; row++ {
+		for col := 0; col*size < width; col++ {
+			x0, y0 := bounds.Min.X+col*size, bounds.Min.Y+row*size
+			x1, y1 := x0+size+overlap, y0+size+overlap
+
+			if col > 0 {
+				x0 -= overlap
+			}
+
+			if row > 0 {
+				y0 -= overlap
+			}
+
+			fileName := tileName(col, row)
+
+			if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+				return err
+			}
+
+			tile := imaging.Crop(img, image.Rect(x0, y0, x1, y1))
+
+			if err := imaging.Save(tile, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+				log.Errorf("tiles: failed to save %s", txt.Quote(filepath.Base(fileName)))
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// cubeFace projects an equirectangular panorama onto a cube face.
+func cubeFace(src image.Image, face rune, size int) *image.NRGBA {
+	result := image.NewNRGBA(image.Rect(0, 0, size, size))
+	width, height := float64(src.Bounds().Dx()), float64(src.Bounds().Dy())
+	at := pixelAt(src)
+
+	for py := 0; py < size; py++ {
+		b := 2*(float64(py)+0.5)/float64(size) - 1
+
+		for px := 0; px < size; px++ {
+			a := 2*(float64(px)+0.5)/float64(size) - 1
+
+			var x, y, z float64
+
+			switch face {
+			case 'f':
+				x, y, z = a, -b, 1
+			case 'b':
+				x, y, z = -a, -b, -1
+			case 'l':
+				x, y, z = -1, -b, a
+			case 'r':
+				x, y, z = 1, -b, -a
+			case 'u':
+				x, y, z = a, 1, b
+			case 'd':
+				x, y, z = a, -1, -b
+			}
+
+			lon := math.Atan2(x, z)
+			lat := math.Atan2(y, math.Sqrt(x*x+z*z))
+
+			u := (lon/(2*math.Pi) + 0.5) * width
+			v := (0.5 - lat/math.Pi) * height
+
+			result.SetNRGBA(px, py, bilinear(at, src.Bounds(), u-0.5, v-0.5))
+		}
+	}
+
+	return result
+}
+
+// pixelAt returns a function that reads pixels without converting the whole image first,
+// with fast paths for decoded JPEG and NRGBA images.
+func pixelAt(img image.Image) func(x, y int) color.NRGBA {
+	switch src := img.(type) {
+	case *image.NRGBA:
+		return src.NRGBAAt
+	case *image.YCbCr:
+		return func(x, y int) color.NRGBA {
+			c := src.YCbCrAt(x, y)
+			r, g, b := color.YCbCrToRGB(c.Y, c.Cb, c.Cr)
+			return color.NRGBA{R: r, G: g, B: b, A: 255}
+		}
+	default:
+		return func(x, y int) color.NRGBA {
+			return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+		}
+	}
+}
+
+// bilinear returns the interpolated color at the position, wrapping around horizontally.
+func bilinear(at func(x, y int) color.NRGBA, bounds image.Rectangle, x, y float64) color.NRGBA {
+	width, height := bounds.Dx(), bounds.Dy()
+
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	fx, fy := x-float64(x0), y-float64(y0)
+
+	pixel := func(px, py int) [4]float64 {
+		px = ((px % width) + width) % width
+
+		if py < 0 {
+			py = 0
+		} else if py >= height {
+			py = height - 1
+		}
+
+		c := at(bounds.Min.X+px, bounds.Min.Y+py)
+
+		return [4]float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+	}
+
+	p00, p10, p01, p11 := pixel(x0, y0), pixel(x0+1, y0), pixel(x0, y0+1), pixel(x0+1, y0+1)
+
+	var v [4]uint8
+
+	for i := range v {
+		top := p00[i]*(1-fx) + p10[i]*fx
+		bottom := p01[i]*(1-fx) + p11[i]*fx
+		v[i] = uint8(math.Round(top*(1-fy) + bottom*fy))
+	}
+
+	return color.NRGBA{R: v[0], G: v[1], B: v[2], A: v[3]}
+}
@@ -0,0 +1,95 @@
+package thumb
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// TileQueueSize is the maximum number of images waiting for their tiles to be created.
+const TileQueueSize = 64
+
+type tileJob struct {
+	key           string
+	imageFilename string
+	hash          string
+	thumbPath     string
+	cube          bool
+}
+
+var (
+	tileJobs     = make(chan tileJob, TileQueueSize)
+	tileWorker   sync.Once
+	tileStatus   = sync.Mutex{}
+	tilesPending = make(map[string]bool)
+	tilesFailed  = make(map[string]error)
+)
+
+// QueueTiles schedules the creation of Deep Zoom or cube map tiles in the background,
+// so that requests don't need to wait for large images to be decoded. It returns
+// the error if creating the tiles failed before, and ErrTilesBusy if the queue is full.
+func QueueTiles(imageFilename, hash, thumbPath string, cube bool) error {
+	dir, err := TilesPath(hash, thumbPath)
+
+	if err != nil {
+		return err
+	}
+
+	key := filepath.Join(dir, DeepZoomName)
+
+	if cube {
+		key = filepath.Join(dir, CubeMapName)
+	}
+
+	tileStatus.Lock()
+	defer tileStatus.Unlock()
+
+	if err := tilesFailed[key]; err != nil {
+		return err
+	} else if tilesPending[key] {
+		return nil
+	}
+
+	// Check the image size before it is queued.
+	if err := checkTileImage(imageFilename); err != nil {
+		tilesFailed[key] = err
+		return err
+	}
+
+	tileWorker.Do(func() {
+		go tileWorkerLoop()
+	})
+
+	select {
+	case tileJobs <- tileJob{key: key, imageFilename: imageFilename, hash: hash, thumbPath: thumbPath, cube: cube}:
+		tilesPending[key] = true
+		return nil
+	default:
+		return ErrTilesBusy
+	}
+}
+
+// tileWorkerLoop creates queued tiles one image at a time.
+func tileWorkerLoop() {
+	for job := range tileJobs {
+		var err error
+
+		if job.cube {
+			_, err = CubeTiles(job.imageFilename, job.hash, job.thumbPath)
+		} else {
+			_, err = DeepZoomTiles(job.imageFilename, job.hash, job.thumbPath)
+		}
+
+		if err != nil {
+			log.Error(err)
+		}
+
+		tileStatus.Lock()
+		delete(tilesPending, job.key)
+
+		if err != nil {
+			tilesFailed[job.key] = err
+		}
+
+		tileStatus.Unlock()
+	}
+}
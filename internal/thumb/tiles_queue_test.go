@@ -0,0 +1,48 @@
+package thumb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueTiles(t *testing.T) {
+	t.Run("example.jpg", func(t *testing.T) {
+		hash := "5e8c3d1f0a7b2c4d6e8f0a1b3c5d7e9f1a2b3c4d"
+		dir, err := TilesPath(hash, "testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(filepath.Join("testdata", "5"))
+
+		assert.NoError(t, QueueTiles("testdata/example.jpg", hash, "testdata", false))
+		assert.NoError(t, QueueTiles("testdata/example.jpg", hash, "testdata", false))
+
+		fileName := filepath.Join(dir, DeepZoomName)
+
+		for i := 0; i < 100 && !fs.FileExists(fileName); i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.FileExists(t, fileName)
+	})
+	t.Run("file not found", func(t *testing.T) {
+		assert.Error(t, QueueTiles("testdata/xxx.jpg", "2ab5c0ffee", "testdata", false))
+	})
+	t.Run("too large", func(t *testing.T) {
+		maxPixels := TileMaxPixels
+		TileMaxPixels = 1000
+		defer func() { TileMaxPixels = maxPixels }()
+
+		err := QueueTiles("testdata/example.jpg", "3ab5c0ffee", "testdata", true)
+
+		assert.Error(t, err)
+		assert.Equal(t, err, QueueTiles("testdata/example.jpg", "3ab5c0ffee", "testdata", true))
+	})
+}
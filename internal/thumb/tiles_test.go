@@ -0,0 +1,136 @@
+package thumb
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+const tilesHash = "1d3fba7e3fa1b38a2e3f4b1e8c1d7a9c2f6e0b44"
+
+func TestTilesPath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := TilesPath(tilesHash, "testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "testdata/1/d/3/"+tilesHash+"_tiles", result)
+	})
+	t.Run("hash too short", func(t *testing.T) {
+		_, err := TilesPath("999", "testdata")
+
+		assert.Error(t, err)
+	})
+	t.Run("empty folder", func(t *testing.T) {
+		_, err := TilesPath(tilesHash, "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTileMaxLevel(t *testing.T) {
+	assert.Equal(t, 0, TileMaxLevel(1, 1))
+	assert.Equal(t, 1, TileMaxLevel(2, 1))
+	assert.Equal(t, 8, TileMaxLevel(256, 100))
+	assert.Equal(t, 10, TileMaxLevel(750, 500))
+	assert.Equal(t, 14, TileMaxLevel(9000, 16000))
+}
+
+func TestDeepZoomTiles(t *testing.T) {
+	t.Run("example.jpg", func(t *testing.T) {
+		fileName, err := DeepZoomTiles("testdata/example.jpg", tilesHash, "testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result DeepZoom
+
+		if err := xml.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, TileSize, result.TileSize)
+		assert.Equal(t, TileOverlap, result.Overlap)
+		assert.Equal(t, "jpg", result.Format)
+		assert.Equal(t, 750, result.Size.Width)
+		assert.Equal(t, 500, result.Size.Height)
+
+		filesPath := filepath.Join(filepath.Dir(fileName), "image_files")
+
+		assert.FileExists(t, filepath.Join(filesPath, "0", "0_0.jpg"))
+		assert.FileExists(t, filepath.Join(filesPath, "10", "2_1.jpg"))
+		assert.NoFileExists(t, filepath.Join(filesPath, "10", "3_0.jpg"))
+		assert.NoFileExists(t, filepath.Join(filesPath, "11", "0_0.jpg"))
+
+		if img, err := imaging.Open(filepath.Join(filesPath, "10", "1_0.jpg")); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, TileSize+2*TileOverlap, img.Bounds().Dx())
+			assert.Equal(t, TileSize+TileOverlap, img.Bounds().Dy())
+		}
+
+		if img, err := imaging.Open(filepath.Join(filesPath, "9", "0_0.jpg")); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, 257, img.Bounds().Dx())
+			assert.Equal(t, 250, img.Bounds().Dy())
+		}
+	})
+	t.Run("file not found", func(t *testing.T) {
+		_, err := DeepZoomTiles("testdata/xxx.jpg", "1ab5c0ffee", "testdata")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCubeTiles(t *testing.T) {
+	t.Run("example.jpg", func(t *testing.T) {
+		fileName, err := CubeTiles("testdata/example.jpg", tilesHash, "testdata")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result CubeMap
+
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, CubeTileSize, result.TileResolution)
+		assert.Equal(t, CubeTileSize, result.CubeResolution)
+		assert.Equal(t, 1, result.MaxLevel)
+		assert.Equal(t, "jpg", result.Extension)
+
+		for _, face := range CubeFaces {
+			tileName := filepath.Join(filepath.Dir(fileName), "cube_files", "1", string(face)+"0_0.jpg")
+
+			if img, err := imaging.Open(tileName); err != nil {
+				t.Fatal(err)
+			} else {
+				assert.Equal(t, CubeTileSize, img.Bounds().Dx())
+				assert.Equal(t, CubeTileSize, img.Bounds().Dy())
+			}
+		}
+	})
+}
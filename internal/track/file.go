@@ -0,0 +1,104 @@
+package track
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Extensions maps supported track file extensions to their parser.
+var Extensions = map[string]func(data []byte) (Track, error){
+	".gpx":     ParseGPX,
+	".kml":     ParseKML,
+	".geojson": ParseGeoJSON,
+	".json":    ParseGeoJSON,
+}
+
+// IsTrack tests if the file name has a supported track file extension, use IsTrackFile
+// to also check the type of generic JSON files.
+func IsTrack(fileName string) bool {
+	_, ok := Extensions[strings.ToLower(filepath.Ext(fileName))]
+
+	return ok
+}
+
+// IsTrackFile tests if the file is a supported track file, files with a generic .json
+// extension must contain a GeoJSON feature or feature collection.
+func IsTrackFile(fileName string) bool {
+	if !IsTrack(fileName) {
+		return false
+	} else if strings.ToLower(filepath.Ext(fileName)) != ".json" {
+		return true
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	return err == nil && IsGeoJSON(data)
+}
+
+// ReadFile parses a GPX, KML or GeoJSON track file.
+func ReadFile(fileName string) (Track, error) {
+	parse, ok := Extensions[strings.ToLower(filepath.Ext(fileName))]
+
+	if !ok {
+		return nil, fmt.Errorf("track: unsupported file type %s", filepath.Ext(fileName))
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(filepath.Ext(fileName)) == ".json" && !IsGeoJSON(data) {
+		return nil, fmt.Errorf("track: %s is not a geojson file", filepath.Base(fileName))
+	}
+
+	result, err := parse(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("track: %s in %s", err, filepath.Base(fileName))
+	} else if result.Empty() {
+		return nil, fmt.Errorf("track: no points found in %s", filepath.Base(fileName))
+	}
+
+	return result, nil
+}
+
+// ReadFiles parses multiple track files and merges them into a single track.
+func ReadFiles(fileNames []string) (result Track, errs []error) {
+	for _, fileName := range fileNames {
+		if t, err := ReadFile(fileName); err != nil {
+			errs = append(errs, err)
+		} else {
+			result = result.Merge(t)
+		}
+	}
+
+	return result, errs
+}
+
+// ReadDir parses all track files in a directory and its subdirectories.
+func ReadDir(dir string) (result Track, fileNames []string, errs []error) {
+	err := filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && IsTrackFile(fileName) {
+			fileNames = append(fileNames, fileName)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	result, readErrs := ReadFiles(fileNames)
+
+	return result, fileNames, append(errs, readErrs...)
+}
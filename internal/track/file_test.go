@@ -0,0 +1,132 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrack(t *testing.T) {
+	assert.True(t, IsTrack("ride.GPX"))
+	assert.True(t, IsTrack("walk.kml"))
+	assert.True(t, IsTrack("hike.geojson"))
+	assert.False(t, IsTrack("photo.jpg"))
+}
+
+func TestIsTrackFile(t *testing.T) {
+	assert.True(t, IsTrackFile("testdata/ride.gpx"))
+	assert.True(t, IsTrackFile("testdata/hike.geojson"))
+	assert.False(t, IsTrackFile("testdata/takeout.json"))
+	assert.False(t, IsTrackFile("testdata/missing.json"))
+}
+
+func TestReadFile(t *testing.T) {
+	t.Run("ride.gpx", func(t *testing.T) {
+		result, err := ReadFile("testdata/ride.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 5)
+		assert.Equal(t, time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), result.Start())
+		assert.Equal(t, 52.52, result[0].Lat)
+		assert.Equal(t, 13.4, result[0].Lng)
+		assert.Equal(t, float64(34), result[0].Altitude)
+	})
+
+	t.Run("walk.kml", func(t *testing.T) {
+		result, err := ReadFile("testdata/walk.kml")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 3)
+		assert.Equal(t, time.Date(2020, 5, 2, 11, 0, 0, 0, time.UTC), result[2].Time)
+		assert.Equal(t, 52.5163, result[0].Lat)
+		assert.Equal(t, 13.3777, result[0].Lng)
+		assert.Equal(t, float64(35), result[0].Altitude)
+		assert.Equal(t, 52.52, result[2].Lat)
+	})
+
+	t.Run("hike.geojson", func(t *testing.T) {
+		result, err := ReadFile("testdata/hike.geojson")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 3)
+		assert.Equal(t, 47.4, result[0].Lat)
+		assert.Equal(t, float64(11), result[0].Lng)
+		assert.Equal(t, time.Date(2020, 5, 3, 7, 20, 0, 0, time.UTC), result.End())
+	})
+
+	t.Run("not geojson", func(t *testing.T) {
+		_, err := ReadFile("testdata/takeout.json")
+
+		assert.EqualError(t, err, "track: takeout.json is not a geojson file")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := ReadFile("testdata/ride.txt")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ReadFile("testdata/missing.gpx")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestReadDir(t *testing.T) {
+	result, fileNames, errs := ReadDir("testdata")
+
+	assert.Empty(t, errs)
+	assert.Len(t, fileNames, 3)
+	assert.Len(t, result, 11)
+	assert.Equal(t, time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), result.Start())
+	assert.Equal(t, time.Date(2020, 5, 3, 7, 20, 0, 0, time.UTC), result.End())
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	t.Run("feature", func(t *testing.T) {
+		result, err := ParseGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [13.4, 52.5]}, "properties": {"time": "2020-05-01T08:00:00Z"}}`))
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("multi line", func(t *testing.T) {
+		result, err := ParseGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "MultiLineString", "coordinates": [[[13.4, 52.5], [13.5, 52.6]], [[13.6, 52.7]]]}, "properties": {"coordTimes": [["2020-05-01T08:00:00Z", "2020-05-01T08:01:00Z"], ["2020-05-01T08:02:00Z"]]}}`))
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, 52.7, result[2].Lat)
+	})
+
+	t.Run("no times", func(t *testing.T) {
+		result, err := ParseGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[13.4, 52.5], [13.5, 52.6]]}}`))
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseGeoJSON([]byte(`{"type": "Polygon"}`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIsGeoJSON(t *testing.T) {
+	assert.True(t, IsGeoJSON([]byte(`{"type": "FeatureCollection", "features": []}`)))
+	assert.True(t, IsGeoJSON([]byte(`{"type": "Feature"}`)))
+	assert.False(t, IsGeoJSON([]byte(`{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1588320000"}}`)))
+	assert.False(t, IsGeoJSON([]byte(`[1, 2, 3]`)))
+	assert.False(t, IsGeoJSON([]byte(`invalid`)))
+}
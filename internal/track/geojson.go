@@ -0,0 +1,164 @@
+package track
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// geoJsonFeature represents a GeoJSON feature, see https://tools.ietf.org/html/rfc7946.
+type geoJsonFeature struct {
+	Type     string `json:"type"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJsonDocument represents a GeoJSON feature collection or a single feature.
+type geoJsonDocument struct {
+	geoJsonFeature
+	Features []geoJsonFeature `json:"features"`
+}
+
+// IsGeoJSON tests if data is a GeoJSON feature or feature collection, so that other
+// JSON files like Google Takeout metadata aren't mistaken for tracks.
+func IsGeoJSON(data []byte) bool {
+	doc := struct {
+		Type string `json:"type"`
+	}{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+
+	return doc.Type == "Feature" || doc.Type == "FeatureCollection"
+}
+
+// ParseGeoJSON returns the timestamped points of a GeoJSON document. LineString times are
+// read from the "coordTimes" or "times" property, Point times from the "time" property.
+func ParseGeoJSON(data []byte) (Track, error) {
+	doc := geoJsonDocument{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	features := doc.Features
+
+	if doc.Type == "Feature" {
+		features = append(features, doc.geoJsonFeature)
+	} else if doc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("unsupported geojson type %q", doc.Type)
+	}
+
+	var points []Point
+
+	for _, f := range features {
+		switch f.Geometry.Type {
+		case "Point":
+			var c []float64
+
+			if err := json.Unmarshal(f.Geometry.Coordinates, &c); err != nil {
+				return nil, err
+			}
+
+			if p := geoJsonCoord(c); len(c) >= 2 {
+				p.Time = ParseTime(geoJsonString(f.Properties["time"]))
+				points = append(points, p)
+			}
+		case "LineString":
+			var c [][]float64
+
+			if err := json.Unmarshal(f.Geometry.Coordinates, &c); err != nil {
+				return nil, err
+			}
+
+			points = append(points, geoJsonLine(c, geoJsonTimes(f.Properties))...)
+		case "MultiLineString":
+			var c [][][]float64
+
+			if err := json.Unmarshal(f.Geometry.Coordinates, &c); err != nil {
+				return nil, err
+			}
+
+			times := geoJsonTimes(f.Properties)
+
+			for _, line := range c {
+				if len(times) < len(line) {
+					break
+				}
+
+				points = append(points, geoJsonLine(line, times[:len(line)])...)
+				times = times[len(line):]
+			}
+		}
+	}
+
+	return New(points...), nil
+}
+
+// geoJsonLine returns line coordinates combined with their timestamps.
+func geoJsonLine(coords [][]float64, times []time.Time) (result []Point) {
+	for i := 0; i < len(coords) && i < len(times); i++ {
+		p := geoJsonCoord(coords[i])
+		p.Time = times[i]
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// geoJsonCoord returns a point from GeoJSON coordinates in the order longitude, latitude, altitude.
+func geoJsonCoord(c []float64) (p Point) {
+	if len(c) < 2 {
+		return p
+	}
+
+	p.Lng, p.Lat = c[0], c[1]
+
+	if len(c) > 2 {
+		p.Altitude = c[2]
+	}
+
+	return p
+}
+
+// geoJsonTimes returns the flattened coordinate times of a feature.
+func geoJsonTimes(properties map[string]interface{}) (result []time.Time) {
+	values, ok := properties["coordTimes"]
+
+	if !ok {
+		values, ok = properties["times"]
+	}
+
+	if !ok {
+		return result
+	}
+
+	var add func(v interface{})
+
+	add = func(v interface{}) {
+		if list, ok := v.([]interface{}); ok {
+			for _, item := range list {
+				add(item)
+			}
+		} else {
+			result = append(result, ParseTime(geoJsonString(v)))
+		}
+	}
+
+	add(values)
+
+	return result
+}
+
+// geoJsonString returns a property value as string.
+func geoJsonString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return ""
+}
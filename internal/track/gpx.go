@@ -0,0 +1,61 @@
+package track
+
+import (
+	"encoding/xml"
+)
+
+// gpxDocument represents the parts of a GPX 1.0 / 1.1 file needed for geotagging.
+type gpxDocument struct {
+	Waypoints []gpxPoint `xml:"wpt"`
+	Routes    []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// gpxPoint represents a GPX waypoint, route point or track point.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lng  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// ParseGPX returns the timestamped points of a GPX document.
+func ParseGPX(data []byte) (Track, error) {
+	doc := gpxDocument{}
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var points []Point
+
+	add := func(p gpxPoint) {
+		points = append(points, Point{Time: ParseTime(p.Time), Lat: p.Lat, Lng: p.Lng, Altitude: p.Ele})
+	}
+
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				add(p)
+			}
+		}
+	}
+
+	for _, rte := range doc.Routes {
+		for _, p := range rte.Points {
+			add(p)
+		}
+	}
+
+	for _, p := range doc.Waypoints {
+		add(p)
+	}
+
+	return New(points...), nil
+}
@@ -0,0 +1,116 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseKML returns the timestamped points of a KML document, supporting gx:Track elements
+// as well as point placemarks with a time stamp.
+func ParseKML(data []byte) (Track, error) {
+	var points []Point
+	var stack []string
+	var whens []time.Time
+	var coords []Point
+	var placemarkTime time.Time
+	var placemarkPoint *Point
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	parent := func(name string) bool {
+		for _, s := range stack {
+			if s == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for {
+		token, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+
+			switch name {
+			case "Track":
+				whens = nil
+				coords = nil
+			case "Placemark":
+				placemarkTime = time.Time{}
+				placemarkPoint = nil
+			case "when", "coord", "coordinates":
+				var s string
+
+				if err := d.DecodeElement(&s, &t); err != nil {
+					return nil, err
+				}
+
+				switch {
+				case name == "when" && parent("Track"):
+					whens = append(whens, ParseTime(s))
+				case name == "coord" && parent("Track"):
+					coords = append(coords, kmlCoord(strings.Fields(s)))
+				case name == "when" && parent("TimeStamp"):
+					placemarkTime = ParseTime(s)
+				case name == "coordinates" && parent("Point"):
+					p := kmlCoord(strings.Split(strings.TrimSpace(s), ","))
+					placemarkPoint = &p
+				}
+
+				continue
+			}
+
+			stack = append(stack, name)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+			switch t.Name.Local {
+			case "Track":
+				for i := 0; i < len(whens) && i < len(coords); i++ {
+					p := coords[i]
+					p.Time = whens[i]
+					points = append(points, p)
+				}
+			case "Placemark":
+				if placemarkPoint != nil && !placemarkTime.IsZero() {
+					p := *placemarkPoint
+					p.Time = placemarkTime
+					points = append(points, p)
+				}
+			}
+		}
+	}
+
+	return New(points...), nil
+}
+
+// kmlCoord returns a point from KML coordinate values in the order longitude, latitude, altitude.
+func kmlCoord(values []string) (p Point) {
+	if len(values) < 2 {
+		return p
+	}
+
+	p.Lng, _ = strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	p.Lat, _ = strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+
+	if len(values) > 2 {
+		p.Altitude, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return p
+}
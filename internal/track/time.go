@@ -0,0 +1,31 @@
+package track
+
+import (
+	"strings"
+	"time"
+)
+
+// timeLayouts contains the supported track time formats.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+}
+
+// ParseTime parses a track point timestamp, times without offset are assumed to be UTC.
+func ParseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+
+	return time.Time{}
+}
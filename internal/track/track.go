@@ -0,0 +1,160 @@
+/*
+
+Package track provides GPS track parsing and position lookups for geotagging photos.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package track
+
+import (
+	"sort"
+	"time"
+)
+
+// Point represents a timestamped track position.
+type Point struct {
+	Time     time.Time
+	Lat      float64
+	Lng      float64
+	Altitude float64
+}
+
+// Valid tests if the point has a time and valid coordinates.
+func (p Point) Valid() bool {
+	if p.Time.IsZero() || (p.Lat == 0 && p.Lng == 0) {
+		return false
+	}
+
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// Track represents a list of track points sorted by time.
+type Track []Point
+
+// New returns a sorted track containing all valid points.
+func New(points ...Point) Track {
+	result := make(Track, 0, len(points))
+
+	for _, p := range points {
+		if p.Valid() {
+			p.Time = p.Time.UTC()
+			result = append(result, p)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result
+}
+
+// Merge returns a new sorted track containing the points of both tracks.
+func (t Track) Merge(other Track) Track {
+	points := make([]Point, 0, len(t)+len(other))
+	points = append(points, t...)
+	points = append(points, other...)
+
+	return New(points...)
+}
+
+// Empty tests if the track has no points.
+func (t Track) Empty() bool {
+	return len(t) == 0
+}
+
+// Start returns the time of the first track point.
+func (t Track) Start() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t[0].Time
+}
+
+// End returns the time of the last track point.
+func (t Track) End() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t[len(t)-1].Time
+}
+
+// Position returns the interpolated position at the given time, provided that the surrounding
+// track points are not more than maxGap apart.
+func (t Track) Position(at time.Time, maxGap time.Duration) (result Point, ok bool) {
+	if t.Empty() || at.Before(t.Start()) || at.After(t.End()) {
+		return result, false
+	}
+
+	at = at.UTC()
+
+	// Index of the first point not before the given time.
+	i := sort.Search(len(t), func(i int) bool {
+		return !t[i].Time.Before(at)
+	})
+
+	next := t[i]
+
+	if next.Time.Equal(at) {
+		return next, true
+	}
+
+	prev := t[i-1]
+	gap := next.Time.Sub(prev.Time)
+
+	if maxGap > 0 && gap > maxGap {
+		return result, false
+	}
+
+	f := float64(at.Sub(prev.Time)) / float64(gap)
+	dLng := next.Lng - prev.Lng
+
+	// Take the short way when crossing the antimeridian.
+	if dLng > 180 {
+		dLng -= 360
+	} else if dLng < -180 {
+		dLng += 360
+	}
+
+	result = Point{
+		Time:     at,
+		Lat:      prev.Lat + (next.Lat-prev.Lat)*f,
+		Lng:      prev.Lng + dLng*f,
+		Altitude: prev.Altitude + (next.Altitude-prev.Altitude)*f,
+	}
+
+	if result.Lng > 180 {
+		result.Lng -= 360
+	} else if result.Lng < -180 {
+		result.Lng += 360
+	}
+
+	return result, true
+}
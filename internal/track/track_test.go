@@ -0,0 +1,109 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("sorted", func(t *testing.T) {
+		result := New(
+			Point{Time: time.Date(2020, 5, 1, 8, 1, 0, 0, time.UTC), Lat: 1, Lng: 1},
+			Point{Time: time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), Lat: 2, Lng: 2},
+			Point{Lat: 3, Lng: 3},
+			Point{Time: time.Date(2020, 5, 1, 8, 2, 0, 0, time.UTC), Lat: 91, Lng: 2},
+		)
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, float64(2), result[0].Lat)
+		assert.Equal(t, float64(1), result[1].Lat)
+	})
+}
+
+func TestTrack_Merge(t *testing.T) {
+	a := New(Point{Time: time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), Lat: 1, Lng: 1})
+	b := New(Point{Time: time.Date(2020, 5, 1, 7, 0, 0, 0, time.UTC), Lat: 2, Lng: 2})
+
+	result := a.Merge(b)
+
+	assert.Len(t, result, 2)
+	assert.Equal(t, time.Date(2020, 5, 1, 7, 0, 0, 0, time.UTC), result.Start())
+	assert.Equal(t, time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), result.End())
+}
+
+func TestTrack_Position(t *testing.T) {
+	start := time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	track := New(
+		Point{Time: start, Lat: 52.0, Lng: 13.0, Altitude: 100},
+		Point{Time: start.Add(time.Minute), Lat: 52.2, Lng: 13.2, Altitude: 200},
+		Point{Time: start.Add(time.Hour), Lat: 53.0, Lng: 14.0},
+	)
+
+	t.Run("exact", func(t *testing.T) {
+		p, ok := track.Position(start, 5*time.Minute)
+
+		assert.True(t, ok)
+		assert.Equal(t, 52.0, p.Lat)
+		assert.Equal(t, 13.0, p.Lng)
+	})
+
+	t.Run("interpolated", func(t *testing.T) {
+		p, ok := track.Position(start.Add(30*time.Second), 5*time.Minute)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.1, p.Lat, 0.00001)
+		assert.InDelta(t, 13.1, p.Lng, 0.00001)
+		assert.InDelta(t, 150, p.Altitude, 0.00001)
+	})
+
+	t.Run("gap too large", func(t *testing.T) {
+		_, ok := track.Position(start.Add(30*time.Minute), 5*time.Minute)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("no max gap", func(t *testing.T) {
+		p, ok := track.Position(start.Add(30*time.Minute+30*time.Second), 0)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 52.6, p.Lat, 0.00001)
+	})
+
+	t.Run("outside", func(t *testing.T) {
+		_, ok := track.Position(start.Add(-time.Second), 5*time.Minute)
+
+		assert.False(t, ok)
+
+		_, ok = track.Position(start.Add(2*time.Hour), 5*time.Minute)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		track := New(
+			Point{Time: start, Lat: 10, Lng: 179},
+			Point{Time: start.Add(time.Minute), Lat: 10, Lng: -179},
+		)
+
+		p, ok := track.Position(start.Add(15*time.Second), time.Minute)
+
+		assert.True(t, ok)
+		assert.InDelta(t, 179.5, p.Lng, 0.00001)
+
+		p, ok = track.Position(start.Add(45*time.Second), time.Minute)
+
+		assert.True(t, ok)
+		assert.InDelta(t, -179.5, p.Lng, 0.00001)
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	assert.Equal(t, time.Date(2020, 5, 2, 9, 0, 0, 0, time.UTC), ParseTime("2020-05-02T11:00:00+02:00"))
+	assert.Equal(t, time.Date(2020, 5, 2, 11, 0, 0, 500000000, time.UTC), ParseTime("2020-05-02T11:00:00.5Z"))
+	assert.Equal(t, time.Date(2020, 5, 2, 11, 0, 0, 0, time.UTC), ParseTime("2020-05-02T11:00:00"))
+	assert.True(t, ParseTime("").IsZero())
+	assert.True(t, ParseTime("yesterday").IsZero())
+}
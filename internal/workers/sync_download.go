@@ -158,12 +158,15 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 			done[mf.FileName()] = true
 			related.Files = rf
 
+			indexOpt := photoprism.IndexOptionsAll()
+			indexOpt.Account = a.AccName
+
 			if a.SyncFilenames {
 				log.Infof("sync: indexing %s and related files", file.RemoteName)
 				indexJobs <- photoprism.IndexJob{
 					FileName: mf.FileName(),
 					Related:  related,
-					IndexOpt: photoprism.IndexOptionsAll(),
+					IndexOpt: indexOpt,
 					Ind:      service.Index(),
 				}
 			} else {
@@ -171,7 +174,7 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 				importJobs <- photoprism.ImportJob{
 					FileName:  mf.FileName(),
 					Related:   related,
-					IndexOpt:  photoprism.IndexOptionsAll(),
+					IndexOpt:  indexOpt,
 					ImportOpt: photoprism.ImportOptionsMove(baseDir),
 					Imp:       service.Import(),
 				}
@@ -34,6 +34,7 @@ package colors
 import (
 	"fmt"
 	"image/color"
+	"strconv"
 	"strings"
 )
 
@@ -138,6 +139,28 @@ func (c Colors) Hex() (result string) {
 	return result
 }
 
+// HexColors returns the colors of a hex encoded string, see Colors.Hex().
+func HexColors(s string) (result Colors) {
+	for _, r := range s {
+		if v, err := strconv.ParseUint(string(r), 16, 8); err == nil {
+			result = append(result, Color(v))
+		}
+	}
+
+	return result
+}
+
+// Distance returns the number of different colors, e.g. in two 3x3 color maps.
+func (c Colors) Distance(other Colors) (result int) {
+	for i := 0; i < len(c) || i < len(other); i++ {
+		if i >= len(c) || i >= len(other) || c[i] != other[i] {
+			result++
+		}
+	}
+
+	return result
+}
+
 func (c Colors) List() []map[string]string {
 	result := make([]map[string]string, 0, len(c))
 
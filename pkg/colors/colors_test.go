@@ -83,3 +83,15 @@ func TestChroma_Int(t *testing.T) {
 		assert.Equal(t, 155, perception.Chroma.Int())
 	})
 }
+
+func TestHexColors(t *testing.T) {
+	assert.Equal(t, Colors{Orange, Lime, Black}, HexColors("DA0"))
+	assert.Equal(t, Colors{Orange, Black}, HexColors("Dx0"))
+	assert.Empty(t, HexColors(""))
+}
+
+func TestColors_Distance(t *testing.T) {
+	assert.Equal(t, 0, Colors{Orange, Lime, Black}.Distance(Colors{Orange, Lime, Black}))
+	assert.Equal(t, 1, Colors{Orange, Lime, Black}.Distance(Colors{Orange, Red, Black}))
+	assert.Equal(t, 2, Colors{Orange, Lime, Black}.Distance(Colors{Orange}))
+}
@@ -1,5 +1,7 @@
 package colors
 
+import "strconv"
+
 type LightMap []Luminance
 
 // Hex returns all luminance value as a hex encoded string.
@@ -11,6 +13,35 @@ func (m LightMap) Hex() (result string) {
 	return result
 }
 
+// HexLightMap returns the luminance values of a hex encoded string, see LightMap.Hex().
+func HexLightMap(s string) (result LightMap) {
+	for _, r := range s {
+		if v, err := strconv.ParseUint(string(r), 16, 8); err == nil {
+			result = append(result, Luminance(v))
+		}
+	}
+
+	return result
+}
+
+// Distance returns the sum of all luminance differences.
+func (m LightMap) Distance(other LightMap) (result int) {
+	for i := 0; i < len(m) || i < len(other); i++ {
+		switch {
+		case i >= len(m):
+			result += int(other[i])
+		case i >= len(other):
+			result += int(m[i])
+		case m[i] > other[i]:
+			result += int(m[i] - other[i])
+		default:
+			result += int(other[i] - m[i])
+		}
+	}
+
+	return result
+}
+
 type diffValue struct {
 	a []int
 	b []int
@@ -82,3 +82,15 @@ func TestLightMap_Diff(t *testing.T) {
 		t.Logf("values: %d, %d, %d, %d", d1, d2, d3, d4)
 	})
 }
+
+func TestHexLightMap(t *testing.T) {
+	assert.Equal(t, LightMap{8, 8, 3, 6, 11, 13, 4, 9, 6}, HexLightMap("8836BD496"))
+	assert.Empty(t, HexLightMap(""))
+}
+
+func TestLightMap_Distance(t *testing.T) {
+	a := HexLightMap("8836BD496")
+	assert.Equal(t, 0, a.Distance(a))
+	assert.Equal(t, 3, a.Distance(HexLightMap("9836BD494")))
+	assert.Equal(t, 15, a.Distance(HexLightMap("8836BD4")))
+}
@@ -0,0 +1,155 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Palette represents a list of colors to search for.
+type Palette []colorful.Color
+
+// ParsePalette returns the palette for a list of hex colors like "#ff0000|00ff00".
+func ParsePalette(s string) (result Palette, err error) {
+	values := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '|' || r == ',' || r == ' '
+	})
+
+	for _, v := range values {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "#")
+
+		if len(v) == 3 {
+			v = string([]byte{v[0], v[0], v[1], v[1], v[2], v[2]})
+		}
+
+		c, err := colorful.Hex("#" + v)
+
+		if err != nil || len(v) != 6 {
+			return result, fmt.Errorf("colors: invalid hex color %q", v)
+		}
+
+		result = append(result, c)
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("colors: empty palette")
+	}
+
+	return result, nil
+}
+
+// Hex returns the palette as hex colors separated by "|".
+func (p Palette) Hex() string {
+	values := make([]string, len(p))
+
+	for i, c := range p {
+		values[i] = c.Hex()
+	}
+
+	return strings.Join(values, "|")
+}
+
+// Colorful returns the example color for an indexed color.
+func (c Color) Colorful() colorful.Color {
+	result, _ := colorful.Hex(ColorExamples[c])
+
+	return result
+}
+
+// Cells returns an approximation of the original color map: indexed colors keep their
+// hue and chroma, while the lightness is taken from the light map if available.
+func Cells(c Colors, m LightMap) []colorful.Color {
+	result := make([]colorful.Color, len(c))
+
+	for i, indexed := range c {
+		color := indexed.Colorful()
+
+		if i < len(m) {
+			h, chroma, _ := color.Hcl()
+			color = colorful.Hcl(h, chroma, float64(m[i])/15).Clamped()
+		}
+
+		result[i] = color
+	}
+
+	return result
+}
+
+// Distance returns the average CIELAB distance between the palette colors and the closest cells
+// of a color map. Cells that are not the closest match contribute a smaller share, so that images
+// where a color covers a larger area rank higher.
+func (p Palette) Distance(cells []colorful.Color) float64 {
+	if len(p) == 0 || len(cells) == 0 {
+		return math.MaxFloat64
+	}
+
+	var sum float64
+
+	for _, color := range p {
+		min := math.MaxFloat64
+		avg := 0.0
+
+		for _, cell := range cells {
+			d := color.DistanceLab(cell)
+			avg += d
+
+			if d < min {
+				min = d
+			}
+		}
+
+		avg = avg / float64(len(cells))
+
+		sum += 0.75*min + 0.25*avg
+	}
+
+	return sum / float64(len(p))
+}
+
+// DominantColor represents the share of an indexed color in a color map.
+type DominantColor struct {
+	Slug    string `json:"Slug"`
+	Name    string `json:"Name"`
+	Example string `json:"Example"`
+	Percent int    `json:"Percent"`
+}
+
+// DominantColors represents a list of colors sorted by share.
+type DominantColors []DominantColor
+
+// Dominant returns the colors of a color map sorted by their share in percent.
+func (c Colors) Dominant() DominantColors {
+	if len(c) == 0 {
+		return DominantColors{}
+	}
+
+	counts := make(map[Color]int)
+
+	for _, indexed := range c {
+		counts[indexed]++
+	}
+
+	result := make(DominantColors, 0, len(counts))
+
+	for indexed, count := range counts {
+		result = append(result, DominantColor{
+			Slug:    indexed.Name(),
+			Name:    strings.Title(indexed.Name()),
+			Example: ColorExamples[indexed],
+			Percent: int(math.Round(float64(count*100) / float64(len(c)))),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Percent == result[j].Percent {
+			return result[i].Slug < result[j].Slug
+		}
+
+		return result[i].Percent > result[j].Percent
+	})
+
+	return result
+}
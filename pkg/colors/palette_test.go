@@ -0,0 +1,95 @@
+package colors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePalette(t *testing.T) {
+	t.Run("single", func(t *testing.T) {
+		p, err := ParsePalette("#ff0000")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "#ff0000", p.Hex())
+	})
+	t.Run("multiple", func(t *testing.T) {
+		p, err := ParsePalette("FF0000|00ff00, #00f")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, p, 3)
+		assert.Equal(t, "#ff0000|#00ff00|#0000ff", p.Hex())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParsePalette("red")
+		assert.Error(t, err)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := ParsePalette(" | ")
+		assert.Error(t, err)
+	})
+}
+
+func TestColor_Colorful(t *testing.T) {
+	assert.Equal(t, "#ef5350", Red.Colorful().Hex())
+}
+
+func TestCells(t *testing.T) {
+	t.Run("without light map", func(t *testing.T) {
+		cells := Cells(Colors{Red, Blue}, nil)
+		assert.Equal(t, "#ef5350", cells[0].Hex())
+		assert.Equal(t, "#2196f3", cells[1].Hex())
+	})
+	t.Run("with light map", func(t *testing.T) {
+		cells := Cells(Colors{Red, Red}, LightMap{2, 14})
+		_, _, dark := cells[0].Hcl()
+		_, _, light := cells[1].Hcl()
+		assert.Less(t, dark, light)
+	})
+}
+
+func TestPalette_Distance(t *testing.T) {
+	red, _ := ParsePalette("ef5350")
+
+	t.Run("exact", func(t *testing.T) {
+		assert.InDelta(t, 0.0, red.Distance(Cells(Colors{Red}, nil)), 0.0001)
+	})
+	t.Run("coverage", func(t *testing.T) {
+		more := red.Distance(Cells(Colors{Red, Red, Red, Red, Red, Red, Red, Red, Blue}, nil))
+		less := red.Distance(Cells(Colors{Red, Blue, Blue, Blue, Blue, Blue, Blue, Blue, Blue}, nil))
+		assert.Less(t, more, less)
+	})
+	t.Run("nearest color", func(t *testing.T) {
+		orange := red.Distance(Cells(Colors{Orange}, nil))
+		blue := red.Distance(Cells(Colors{Blue}, nil))
+		assert.Less(t, orange, blue)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Greater(t, red.Distance(nil), 1000.0)
+	})
+}
+
+func TestColors_Dominant(t *testing.T) {
+	t.Run("sorted", func(t *testing.T) {
+		result := Colors{Red, Red, Blue, Red, Blue, White, Red, Red, Red}.Dominant()
+
+		if assert.Len(t, result, 3) {
+			assert.Equal(t, "red", result[0].Slug)
+			assert.Equal(t, "Red", result[0].Name)
+			assert.Equal(t, 67, result[0].Percent)
+			assert.Equal(t, "blue", result[1].Slug)
+			assert.Equal(t, 22, result[1].Percent)
+			assert.Equal(t, "white", result[2].Slug)
+			assert.Equal(t, "#F5F5F5", result[2].Example)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Len(t, Colors{}.Dominant(), 0)
+	})
+}
@@ -1,5 +1,13 @@
 package colors
 
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
 // Information on how an image looks like in terms of colors and light.
 type ColorPerception struct {
 	Colors    Colors
@@ -7,3 +15,44 @@ type ColorPerception struct {
 	Luminance LightMap
 	Chroma    Chroma
 }
+
+// Perception returns the ColorPerception of a small image, e.g. a 3x3 pixel thumbnail.
+func Perception(img image.Image) (perception ColorPerception) {
+	bounds := img.Bounds()
+	width, height := bounds.Max.X, bounds.Max.Y
+	pixels := float64(width * height)
+	chromaSum := 0.0
+
+	colorCount := make(map[Color]uint16)
+	var mainColorCount uint16
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			rgb, _ := colorful.MakeColor(color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: uint8(a)})
+			i := Colorful(rgb)
+			perception.Colors = append(perception.Colors, i)
+
+			if _, ok := colorCount[i]; ok == true {
+				colorCount[i] += Weights[i]
+			} else {
+				colorCount[i] = Weights[i]
+			}
+
+			if colorCount[i] > mainColorCount {
+				mainColorCount = colorCount[i]
+				perception.MainColor = i
+			}
+
+			_, c, l := rgb.Hcl()
+
+			chromaSum += c
+
+			perception.Luminance = append(perception.Luminance, Luminance(math.Round(l*15)))
+		}
+	}
+
+	perception.Chroma = Chroma(math.Round((chromaSum / pixels) * 100))
+
+	return perception
+}
@@ -0,0 +1,28 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerception(t *testing.T) {
+	t.Run("red", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 3, 3))
+
+		for y := 0; y < 3; y++ {
+			for x := 0; x < 3; x++ {
+				img.Set(x, y, color.RGBA{R: 0xEF, G: 0x53, B: 0x50, A: 0xff})
+			}
+		}
+
+		p := Perception(img)
+
+		assert.Equal(t, Red, p.MainColor)
+		assert.Equal(t, "EEEEEEEEE", p.Colors.Hex())
+		assert.Len(t, p.Luminance, 9)
+		assert.Less(t, 0, p.Chroma.Int())
+	})
+}
@@ -0,0 +1,42 @@
+package colors
+
+import (
+	"image"
+	"math"
+)
+
+// Sharpness returns the variance of the Laplacian of an image's luminance. Higher values indicate
+// sharper images with more detail, so that the best frame of a burst can be chosen.
+func Sharpness(img image.Image) uint32 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	luma := make([]float64, width*height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			luma[y*width+x] = (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+		}
+	}
+
+	var sum, sumSquares float64
+
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			i := y*width + x
+			l := luma[i-width] + luma[i+width] + luma[i-1] + luma[i+1] - 4*luma[i]
+			sum += l
+			sumSquares += l * l
+		}
+	}
+
+	n := float64((width - 2) * (height - 2))
+	mean := sum / n
+
+	return uint32(math.Round(sumSquares/n - mean*mean))
+}
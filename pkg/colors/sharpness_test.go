@@ -0,0 +1,44 @@
+package colors
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharpness(t *testing.T) {
+	t.Run("uniform", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 16, 16))
+
+		for i := range img.Pix {
+			img.Pix[i] = 128
+		}
+
+		assert.Equal(t, uint32(0), Sharpness(img))
+	})
+
+	t.Run("edges", func(t *testing.T) {
+		checkers := image.NewGray(image.Rect(0, 0, 16, 16))
+		gradient := image.NewGray(image.Rect(0, 0, 16, 16))
+
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				if (x/2+y/2)%2 == 0 {
+					checkers.SetGray(x, y, color.Gray{Y: 255})
+				}
+
+				gradient.SetGray(x, y, color.Gray{Y: uint8(x * 16)})
+			}
+		}
+
+		assert.Greater(t, Sharpness(checkers), Sharpness(gradient))
+	})
+
+	t.Run("too small", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 2, 2))
+
+		assert.Equal(t, uint32(0), Sharpness(img))
+	})
+}
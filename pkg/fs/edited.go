@@ -0,0 +1,39 @@
+package fs
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+// EditedSuffix is appended to the names of edited images, e.g. by Google Photos or when importing files.
+const EditedSuffix = "-edited"
+
+// appleEditedName matches the names of images edited on iOS devices, e.g. "IMG_E1234.HEIC".
+var appleEditedName = regexp.MustCompile(`(?i)^(IMG_)E(\d+)`)
+
+// AppleOriginal returns the original file name of an image edited on an iOS device like "IMG_E1234.HEIC",
+// or an empty string if the file name doesn't match.
+func AppleOriginal(fileName string) string {
+	base := filepath.Base(fileName)
+
+	if !appleEditedName.MatchString(base) {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(fileName), appleEditedName.ReplaceAllString(base, "${1}${2}"))
+}
+
+// EditedOriginal returns the original file name of an edited image as created by Apple or Google Photos,
+// or an empty string if it's not an edited version.
+func EditedOriginal(fileName string) string {
+	if original := AppleOriginal(fileName); original != "" {
+		return original
+	}
+
+	return TakeoutOriginal(fileName)
+}
+
+// IsEdited tests if the file name belongs to an edited version of another image.
+func IsEdited(fileName string) bool {
+	return EditedOriginal(fileName) != ""
+}
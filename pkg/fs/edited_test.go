@@ -0,0 +1,34 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppleOriginal(t *testing.T) {
+	t.Run("edited", func(t *testing.T) {
+		assert.Equal(t, "/photos/IMG_1234.HEIC", AppleOriginal("/photos/IMG_E1234.HEIC"))
+	})
+	t.Run("lower case", func(t *testing.T) {
+		assert.Equal(t, "img_4120.jpg", AppleOriginal("img_e4120.jpg"))
+	})
+	t.Run("original", func(t *testing.T) {
+		assert.Equal(t, "", AppleOriginal("/photos/IMG_1234.HEIC"))
+	})
+	t.Run("no number", func(t *testing.T) {
+		assert.Equal(t, "", AppleOriginal("/photos/IMG_EDIT.jpg"))
+	})
+}
+
+func TestEditedOriginal(t *testing.T) {
+	assert.Equal(t, "IMG_1234.HEIC", EditedOriginal("IMG_E1234.HEIC"))
+	assert.Equal(t, "IMG_1234.jpg", EditedOriginal("IMG_1234-edited.jpg"))
+	assert.Equal(t, "", EditedOriginal("IMG_1234.jpg"))
+}
+
+func TestIsEdited(t *testing.T) {
+	assert.True(t, IsEdited("IMG_E4120.JPG"))
+	assert.True(t, IsEdited("20190609_105922_ABCD1234-edited.jpg"))
+	assert.False(t, IsEdited("IMG_4120.JPG"))
+}
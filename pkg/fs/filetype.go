@@ -50,6 +50,8 @@ const (
 	YamlExt = ".yml"
 	JpegExt = ".jpg"
 	AvcExt  = ".avc"
+	Mp4Ext  = ".mp4"
+	XmpExt  = ".xmp"
 )
 
 // FileExt contains the filename extensions of file formats known to PhotoPrism.
@@ -0,0 +1,80 @@
+package fs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// TakeoutJsonMaxLen is the maximum length of JSON sidecar file names in Google Photos Takeout archives.
+const TakeoutJsonMaxLen = 51
+
+// TakeoutAlbumFile is the name of the album metadata file in Google Photos Takeout folders.
+const TakeoutAlbumFile = "metadata.json"
+
+// TakeoutOriginal returns the original file name of an edited Google Photos image like "IMG_1234-edited.jpg",
+// or an empty string if the file name has no edited suffix.
+func TakeoutOriginal(fileName string) string {
+	ext := filepath.Ext(fileName)
+	prefix := strings.TrimSuffix(fileName, ext)
+
+	if !strings.HasSuffix(strings.ToLower(prefix), EditedSuffix) {
+		return ""
+	}
+
+	return prefix[:len(prefix)-len(EditedSuffix)] + ext
+}
+
+// TakeoutEdited returns the file name of the edited version of a Google Photos image, if it exists.
+func TakeoutEdited(fileName string) string {
+	if TakeoutOriginal(fileName) != "" {
+		return ""
+	}
+
+	ext := filepath.Ext(fileName)
+
+	if edited := strings.TrimSuffix(fileName, ext) + EditedSuffix + ext; FileExists(edited) {
+		return edited
+	}
+
+	return ""
+}
+
+// TakeoutJson returns the JSON sidecar file name of a media file in a Google Photos Takeout archive, if it exists.
+// Takeout truncates long file names and appends sequence numbers of duplicates after the extension,
+// so that the names don't always match, e.g. "IMG_1234(1).jpg" and "IMG_1234.jpg(1).json".
+func TakeoutJson(fileName string) string {
+	if fileName == "" {
+		return ""
+	}
+
+	dir := filepath.Dir(fileName)
+	base := filepath.Base(fileName)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext)
+
+	names := []string{takeoutJsonName(base, "")}
+
+	if i := strings.LastIndex(prefix, "("); i > 0 && strings.HasSuffix(prefix, ")") {
+		names = append(names, takeoutJsonName(prefix[:i]+ext, prefix[i:]))
+	}
+
+	for _, name := range names {
+		if jsonName := filepath.Join(dir, name); FileExists(jsonName) {
+			return jsonName
+		}
+	}
+
+	return ""
+}
+
+// takeoutJsonName returns the JSON file name for a media file name and optional duplicate sequence like "(1)",
+// truncated to the maximum number of characters used by Google Photos.
+func takeoutJsonName(name, seq string) string {
+	runes := []rune(name)
+
+	if max := TakeoutJsonMaxLen - len([]rune(seq)) - len(".json"); len(runes) > max {
+		name = string(runes[:max])
+	}
+
+	return name + seq + ".json"
+}
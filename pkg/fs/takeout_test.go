@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeoutOriginal(t *testing.T) {
+	t.Run("edited", func(t *testing.T) {
+		assert.Equal(t, "/takeout/IMG_1234.jpg", TakeoutOriginal("/takeout/IMG_1234-edited.jpg"))
+	})
+	t.Run("upper case", func(t *testing.T) {
+		assert.Equal(t, "IMG_1234.JPG", TakeoutOriginal("IMG_1234-EDITED.JPG"))
+	})
+	t.Run("original", func(t *testing.T) {
+		assert.Equal(t, "", TakeoutOriginal("/takeout/IMG_1234.jpg"))
+	})
+}
+
+func TestTakeoutJsonName(t *testing.T) {
+	t.Run("short", func(t *testing.T) {
+		assert.Equal(t, "IMG_1234.jpg(1).json", takeoutJsonName("IMG_1234.jpg", "(1)"))
+	})
+	t.Run("unicode", func(t *testing.T) {
+		result := takeoutJsonName("Überraschungsparty für Großmütter und Großväter.jpg", "")
+		assert.Equal(t, "Überraschungsparty für Großmütter und Großväte.json", result)
+		assert.True(t, utf8.ValidString(result))
+	})
+}
+
+func TestTakeoutJson(t *testing.T) {
+	dir, err := ioutil.TempDir("", "takeout")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	longName := "Screenshot_20190405-123456_Some Application Name.jpg"
+
+	for _, name := range []string{
+		"IMG_1234.jpg",
+		"IMG_1234-edited.jpg",
+		"IMG_1234.jpg.json",
+		"IMG_1234(1).jpg",
+		"IMG_1234.jpg(1).json",
+		"IMG_5678.jpg",
+		longName,
+		"Screenshot_20190405-123456_Some Application Na.json",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(dir, "IMG_1234.jpg.json"), TakeoutJson(filepath.Join(dir, "IMG_1234.jpg")))
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(dir, "IMG_1234.jpg(1).json"), TakeoutJson(filepath.Join(dir, "IMG_1234(1).jpg")))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(dir, "Screenshot_20190405-123456_Some Application Na.json"), TakeoutJson(filepath.Join(dir, longName)))
+	})
+	t.Run("edited", func(t *testing.T) {
+		assert.Equal(t, "", TakeoutJson(filepath.Join(dir, "IMG_1234-edited.jpg")))
+		assert.Equal(t, filepath.Join(dir, "IMG_1234.jpg.json"), TakeoutJson(TakeoutOriginal(filepath.Join(dir, "IMG_1234-edited.jpg"))))
+	})
+	t.Run("missing", func(t *testing.T) {
+		assert.Equal(t, "", TakeoutJson(filepath.Join(dir, "IMG_5678.jpg")))
+	})
+	t.Run("TakeoutEdited", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(dir, "IMG_1234-edited.jpg"), TakeoutEdited(filepath.Join(dir, "IMG_1234.jpg")))
+		assert.Equal(t, "", TakeoutEdited(filepath.Join(dir, "IMG_1234-edited.jpg")))
+		assert.Equal(t, "", TakeoutEdited(filepath.Join(dir, "IMG_5678.jpg")))
+	})
+}
@@ -0,0 +1,183 @@
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultPathTemplate files originals by year and month using their canonical name.
+const DefaultPathTemplate = "{yyyy}/{mm}/{yyyy}{mm}{dd}_{hh}{ii}{ss}_{crc}"
+
+// PathUnknown replaces empty token values, e.g. if a photo has no camera information.
+const PathUnknown = "Unknown"
+
+// pathValueMaxLen is the max number of characters of a single token value.
+const pathValueMaxLen = 64
+
+// pathInvalidChars must not be used in path templates and are replaced in token values.
+const pathInvalidChars = `\:*?"<>|`
+
+// PathTokens describes the tokens supported in path templates.
+var PathTokens = map[string]string{
+	"yyyy":    "Year, e.g. 2020",
+	"yy":      "Year without century, e.g. 20",
+	"mm":      "Month, 01-12",
+	"dd":      "Day of month, 01-31",
+	"hh":      "Hour, 00-23",
+	"ii":      "Minute, 00-59",
+	"ss":      "Second, 00-59",
+	"make":    "Camera make, e.g. Canon",
+	"model":   "Camera model, e.g. EOS 6D",
+	"lens":    "Lens model",
+	"country": "Country name from location or metadata",
+	"city":    "City from location or metadata",
+	"name":    "Original file name without extension",
+	"album":   "Album title",
+	"type":    "Media type, e.g. image, raw, or video",
+	"hash":    "Short SHA1 hash, first 7 characters",
+	"crc":     "CRC32 checksum as used in canonical names",
+}
+
+// PathValues maps path template tokens to their values.
+type PathValues map[string]string
+
+// PathTemplate represents a destination path template, e.g. "{yyyy}/{make}/{name}".
+type PathTemplate string
+
+// Tokens returns the distinct tokens used in the template in alphabetical order.
+func (t PathTemplate) Tokens() (result []string, err error) {
+	found := make(map[string]bool)
+
+	err = t.parse(func(literal string) {}, func(token string) {
+		if !found[token] {
+			found[token] = true
+			result = append(result, token)
+		}
+	})
+
+	sort.Strings(result)
+
+	return result, err
+}
+
+// Uses tests if the template contains the token.
+func (t PathTemplate) Uses(token string) bool {
+	return strings.Contains(string(t), "{"+token+"}")
+}
+
+// Validate returns an error if the template can't be used to create destination paths.
+func (t PathTemplate) Validate() error {
+	s := strings.TrimSpace(string(t))
+
+	if s == "" {
+		return errors.New("template is empty")
+	}
+
+	if strings.HasPrefix(s, "/") {
+		return errors.New("template must be a relative path")
+	}
+
+	if strings.ContainsAny(s, pathInvalidChars) {
+		return fmt.Errorf("template must not contain any of %s", pathInvalidChars)
+	}
+
+	if err := t.parse(func(literal string) {}, func(token string) {}); err != nil {
+		return err
+	}
+
+	for _, dir := range strings.Split(s, "/") {
+		switch {
+		case dir == "":
+			return errors.New("template contains an empty folder or file name")
+		case strings.HasPrefix(dir, "."):
+			return fmt.Errorf("folder and file names must not start with a dot (%s)", dir)
+		}
+	}
+
+	return nil
+}
+
+// Render replaces the tokens with their values and returns the resulting relative path
+// with slash separators and without file extension.
+func (t PathTemplate) Render(values PathValues) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	err := t.parse(func(literal string) {
+		b.WriteString(literal)
+	}, func(token string) {
+		b.WriteString(PathValue(values[token]))
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return path.Clean(strings.TrimSpace(b.String())), nil
+}
+
+// parse splits the template into literals and tokens.
+func (t PathTemplate) parse(literal func(string), token func(string)) error {
+	s := string(t)
+
+	for len(s) > 0 {
+		start := strings.IndexAny(s, "{}")
+
+		if start < 0 {
+			literal(s)
+			return nil
+		} else if s[start] == '}' {
+			return errors.New("template contains a closing brace without opening brace")
+		}
+
+		literal(s[:start])
+
+		end := strings.IndexAny(s[start+1:], "{}")
+
+		if end < 0 || s[start+1+end] == '{' {
+			return errors.New("template contains an opening brace without closing brace")
+		}
+
+		name := s[start+1 : start+1+end]
+
+		if _, ok := PathTokens[name]; !ok {
+			return fmt.Errorf("unknown template token {%s}", name)
+		}
+
+		token(name)
+
+		s = s[start+end+2:]
+	}
+
+	return nil
+}
+
+// PathValue returns a token value that is safe to use as (part of) a folder or file name.
+func PathValue(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '/' || strings.ContainsRune(pathInvalidChars, r) || unicode.IsControl(r) {
+			return '_'
+		}
+
+		return r
+	}, s)
+
+	if r := []rune(s); len(r) > pathValueMaxLen {
+		s = string(r[:pathValueMaxLen])
+	}
+
+	s = strings.Trim(s, " .")
+
+	if s == "" {
+		return PathUnknown
+	}
+
+	return s
+}
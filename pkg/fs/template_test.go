@@ -0,0 +1,109 @@
+package fs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathTemplate_Tokens(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		tokens, err := PathTemplate(DefaultPathTemplate).Tokens()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"crc", "dd", "hh", "ii", "mm", "ss", "yyyy"}, tokens)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := PathTemplate("{yyyy}/{foo}").Tokens()
+
+		assert.EqualError(t, err, "unknown template token {foo}")
+	})
+}
+
+func TestPathTemplate_Uses(t *testing.T) {
+	assert.True(t, PathTemplate("{album}/{name}").Uses("album"))
+	assert.False(t, PathTemplate(DefaultPathTemplate).Uses("album"))
+}
+
+func TestPathTemplate_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, PathTemplate(DefaultPathTemplate).Validate())
+		assert.NoError(t, PathTemplate("{country}/{city}/{yyyy}-{mm}-{dd} {name}").Validate())
+		assert.NoError(t, PathTemplate("Imported").Validate())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate(" ").Validate(), "template is empty")
+	})
+	t.Run("absolute", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("/{yyyy}/{name}").Validate(), "template must be a relative path")
+	})
+	t.Run("parent", func(t *testing.T) {
+		assert.Error(t, PathTemplate("../{name}").Validate())
+	})
+	t.Run("trailing slash", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{yyyy}/").Validate(), "template contains an empty folder or file name")
+	})
+	t.Run("invalid char", func(t *testing.T) {
+		assert.Error(t, PathTemplate("{yyyy}\\{name}").Validate())
+	})
+	t.Run("braces", func(t *testing.T) {
+		assert.EqualError(t, PathTemplate("{yyyy/{name}").Validate(), "template contains an opening brace without closing brace")
+		assert.EqualError(t, PathTemplate("{yyyy").Validate(), "template contains an opening brace without closing brace")
+		assert.EqualError(t, PathTemplate("yyyy}/{name}").Validate(), "template contains a closing brace without opening brace")
+	})
+}
+
+func TestPathTemplate_Render(t *testing.T) {
+	values := PathValues{
+		"yyyy":  "2019",
+		"mm":    "07",
+		"dd":    "05",
+		"hh":    "15",
+		"ii":    "32",
+		"ss":    "30",
+		"crc":   "C167C6FD",
+		"make":  "Canon",
+		"model": "EOS 6D",
+		"name":  "IMG_2567",
+		"album": "Holiday: 2019/2020",
+	}
+
+	t.Run("default", func(t *testing.T) {
+		result, err := PathTemplate(DefaultPathTemplate).Render(values)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2019/07/20190705_153230_C167C6FD", result)
+	})
+	t.Run("camera", func(t *testing.T) {
+		result, err := PathTemplate("{make} {model}/{yyyy}/{name}").Render(values)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Canon EOS 6D/2019/IMG_2567", result)
+	})
+	t.Run("sanitized", func(t *testing.T) {
+		result, err := PathTemplate("{album}/{name}").Render(values)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Holiday_ 2019_2020/IMG_2567", result)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		result, err := PathTemplate("{country}/{city}/{name}").Render(values)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Unknown/Unknown/IMG_2567", result)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := PathTemplate("{name").Render(values)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPathValue(t *testing.T) {
+	assert.Equal(t, "Unknown", PathValue(""))
+	assert.Equal(t, "Unknown", PathValue(".."))
+	assert.Equal(t, "a_b_c", PathValue("a/b\\c"))
+	assert.Equal(t, "Berlin", PathValue(" Berlin. "))
+	assert.Len(t, []rune(PathValue(strings.Repeat("x", 100))), 64)
+}
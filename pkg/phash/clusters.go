@@ -0,0 +1,95 @@
+package phash
+
+// Clusters groups hashes with a Hamming distance of at most maxDist and returns the
+// indexes of all groups with more than one member.
+//
+// Hashes are split into maxDist+1 blocks: similar hashes must have at least one
+// identical block, so only hashes that share a block need to be compared.
+func Clusters(hashes []Hash, maxDist int) (result [][]int) {
+	if len(hashes) < 2 || maxDist < 0 {
+		return result
+	}
+
+	if maxDist >= Bits {
+		maxDist = Bits - 1
+	}
+
+	blocks := maxDist + 1
+	size := Bits / blocks
+	parent := make([]int, len(hashes))
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var root func(i int) int
+
+	root = func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+
+		return i
+	}
+
+	for b := 0; b < blocks; b++ {
+		shift := uint(b * size)
+		width := size
+
+		// The last block takes the remaining bits.
+		if b == blocks-1 {
+			width = Bits - b*size
+		}
+
+		mask := Hash(1)<<uint(width) - 1
+
+		if width == Bits {
+			mask = ^Hash(0)
+		}
+
+		buckets := make(map[Hash][]int)
+
+		for i, h := range hashes {
+			key := (h >> shift) & mask
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, bucket := range buckets {
+			for x := 0; x < len(bucket); x++ {
+				for y := x + 1; y < len(bucket); y++ {
+					i, j := bucket[x], bucket[y]
+
+					if hashes[i].Distance(hashes[j]) > maxDist {
+						continue
+					}
+
+					if ri, rj := root(i), root(j); ri != rj {
+						parent[rj] = ri
+					}
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var order []int
+
+	for i := range hashes {
+		r := root(i)
+
+		if _, ok := groups[r]; !ok {
+			order = append(order, r)
+		}
+
+		groups[r] = append(groups[r], i)
+	}
+
+	for _, r := range order {
+		if len(groups[r]) > 1 {
+			result = append(result, groups[r])
+		}
+	}
+
+	return result
+}
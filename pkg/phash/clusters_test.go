@@ -0,0 +1,34 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusters(t *testing.T) {
+	hashes := []Hash{
+		0xff00ff00ff00ff00,
+		0x0f0f0f0f0f0f0f0f,
+		0xff00ff00ff00ff01,
+		0x0f0f0f0f0f0f0f0e,
+		0xaaaaaaaaaaaaaaaa,
+		0xff00ff00ff00ff03,
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		assert.Empty(t, Clusters(hashes, 0))
+	})
+	t.Run("distance 1", func(t *testing.T) {
+		result := Clusters(hashes, 1)
+		assert.Equal(t, [][]int{{0, 2, 5}, {1, 3}}, result)
+	})
+	t.Run("distance 64", func(t *testing.T) {
+		result := Clusters(hashes, 64)
+		assert.Len(t, result, 1)
+		assert.Len(t, result[0], 6)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Clusters(nil, 4))
+	})
+}
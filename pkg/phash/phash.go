@@ -0,0 +1,108 @@
+/*
+
+Package phash provides perceptual image hashes for finding similar images.
+
+A perceptual hash changes only slightly when an image is resized, re-encoded or
+lightly edited, so the number of different bits (Hamming distance) can be used
+to detect near-duplicates. See http://www.hackerfactor.com/blog/?/archives/529-Kind-of-Like-That.html
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package phash
+
+import (
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// Bits is the number of bits in a hash.
+const Bits = 64
+
+// Hash represents a 64-bit perceptual image hash.
+type Hash uint64
+
+// DHash returns the difference hash of an image: It is scaled down to 9x8 grayscale
+// pixels and each bit is set if a pixel is brighter than its right neighbor.
+func DHash(img image.Image) Hash {
+	if img == nil {
+		return 0
+	}
+
+	small := imaging.Resize(imaging.Grayscale(img), 9, 8, imaging.Box)
+
+	var h Hash
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			left := small.Pix[small.PixOffset(x, y)]
+			right := small.Pix[small.PixOffset(x+1, y)]
+
+			h <<= 1
+
+			if left > right {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// Parse returns the hash for a hex string.
+func Parse(s string) (Hash, error) {
+	if len(s) != Bits/4 {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	h, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	return Hash(h), nil
+}
+
+// Hex returns the hash as fixed length hex string.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Distance returns the number of different bits (Hamming distance).
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Similarity returns the similarity of two hashes in percent.
+func (h Hash) Similarity(other Hash) int {
+	return 100 - h.Distance(other)*100/Bits
+}
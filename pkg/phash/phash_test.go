@@ -0,0 +1,73 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDHash(t *testing.T) {
+	t.Run("resized", func(t *testing.T) {
+		img, err := imaging.Open("testdata/chameleon_lime.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h := DHash(img)
+		small := DHash(imaging.Resize(img, 320, 0, imaging.Lanczos))
+
+		assert.NotEqual(t, Hash(0), h)
+		assert.LessOrEqual(t, h.Distance(small), 4)
+	})
+	t.Run("different", func(t *testing.T) {
+		chameleon, err := imaging.Open("testdata/chameleon_lime.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cat, err := imaging.Open("testdata/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, DHash(chameleon).Distance(DHash(cat)), 10)
+	})
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, Hash(0), DHash(nil))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		h, err := Parse("00ff00ff00ff00ff")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Hash(0x00ff00ff00ff00ff), h)
+		assert.Equal(t, "00ff00ff00ff00ff", h.Hex())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.Error(t, err)
+		_, err = Parse("zzff00ff00ff00ff")
+		assert.Error(t, err)
+	})
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(^Hash(0)))
+}
+
+func TestHash_Similarity(t *testing.T) {
+	assert.Equal(t, 100, Hash(0xff).Similarity(0xff))
+	assert.Equal(t, 0, Hash(0).Similarity(^Hash(0)))
+	assert.Equal(t, 50, Hash(0xffffffff).Similarity(0))
+}
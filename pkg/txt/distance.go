@@ -0,0 +1,89 @@
+package txt
+
+// Distance returns the edit distance of two strings, i.e. the number of runes that must be
+// inserted, deleted, replaced or swapped with their neighbor to turn one string into the other.
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	if len(s) == 0 {
+		return len(t)
+	} else if len(t) == 0 {
+		return len(s)
+	}
+
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			// Transposition of two adjacent runes.
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] && prev2[j-2]+1 < curr[j] {
+				curr[j] = prev2[j-2] + 1
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(t)]
+}
+
+// MaxTypos returns the number of typos tolerated when matching a word: short words
+// must match exactly, longer words may contain one or two typos.
+func MaxTypos(s string) int {
+	switch n := len([]rune(s)); {
+	case n < 5:
+		return 0
+	case n < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Similar tests if two strings differ by no more than the tolerated number of typos.
+func Similar(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	max := MaxTypos(a)
+
+	if max == 0 {
+		return false
+	}
+
+	// Skip the distance calculation if the length difference is too large.
+	if d := len([]rune(a)) - len([]rune(b)); d > max || -d > max {
+		return false
+	}
+
+	return Distance(a, b) <= max
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+
+	if c < a {
+		a = c
+	}
+
+	return a
+}
@@ -0,0 +1,46 @@
+package txt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		assert.Equal(t, 0, Distance("landscape", "landscape"))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, 3, Distance("", "dog"))
+		assert.Equal(t, 3, Distance("dog", ""))
+	})
+	t.Run("kitten", func(t *testing.T) {
+		assert.Equal(t, 3, Distance("kitten", "sitting"))
+	})
+	t.Run("transposition", func(t *testing.T) {
+		assert.Equal(t, 1, Distance("flowre", "flower"))
+	})
+	t.Run("missing letter", func(t *testing.T) {
+		assert.Equal(t, 1, Distance("landscape", "landscpe"))
+	})
+	t.Run("unicode", func(t *testing.T) {
+		assert.Equal(t, 1, Distance("kätzchen", "katzchen"))
+		assert.Equal(t, 1, Distance("собака", "собаки"))
+	})
+}
+
+func TestMaxTypos(t *testing.T) {
+	assert.Equal(t, 0, MaxTypos("dog"))
+	assert.Equal(t, 1, MaxTypos("flower"))
+	assert.Equal(t, 2, MaxTypos("landscapes"))
+	assert.Equal(t, 1, MaxTypos("собака"))
+}
+
+func TestSimilar(t *testing.T) {
+	assert.True(t, Similar("dog", "dog"))
+	assert.False(t, Similar("dog", "dig"))
+	assert.True(t, Similar("flowre", "flower"))
+	assert.False(t, Similar("flower", "flowers-and-trees"))
+	assert.True(t, Similar("landsacpe", "landscape"))
+	assert.False(t, Similar("landscape", "handshake"))
+}